@@ -0,0 +1,139 @@
+package backstage
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+)
+
+const (
+	accessPolicyAllow = "allow"
+	accessPolicyDeny  = "deny"
+)
+
+// accessPolicy restricts the kinds and namespaces of entities data sources may read. Kinds and namespaces are matched case-insensitively.
+type accessPolicy struct {
+	// denyByDefault reports whether kinds and namespaces not allowed explicitly are denied.
+	denyByDefault bool
+
+	allowedKinds      map[string]bool
+	deniedKinds       map[string]bool
+	allowedNamespaces map[string]bool
+	deniedNamespaces  map[string]bool
+}
+
+// newAccessPolicy creates the access policy from its configuration. It returns nil if no policy is configured.
+func newAccessPolicy(config *providerAccessPolicyModel) *accessPolicy {
+	if config == nil {
+		return nil
+	}
+
+	return &accessPolicy{
+		denyByDefault:     config.Default.ValueString() == accessPolicyDeny,
+		allowedKinds:      lowerSet(config.AllowedKinds),
+		deniedKinds:       lowerSet(config.DeniedKinds),
+		allowedNamespaces: lowerSet(config.AllowedNamespaces),
+		deniedNamespaces:  lowerSet(config.DeniedNamespaces),
+	}
+}
+
+// allows reports whether entities of the kind in the namespace may be read. A nil policy allows everything.
+func (a *accessPolicy) allows(kind string, namespace string) bool {
+	if a == nil {
+		return true
+	}
+
+	return a.permits(a.allowedKinds, a.deniedKinds, kind) && a.permits(a.allowedNamespaces, a.deniedNamespaces, namespace)
+}
+
+// permits reports whether the value is not denied and, if the policy denies by default, explicitly allowed.
+func (a *accessPolicy) permits(allowed map[string]bool, denied map[string]bool, value string) bool {
+	value = strings.ToLower(value)
+	if denied[value] {
+		return false
+	}
+
+	return !a.denyByDefault || allowed[value]
+}
+
+// checkAccess adds an error to diagnostics and returns false, if the access policy of the provider denies reading entities of the kind in the
+// namespace.
+func (p *providerData) checkAccess(kind string, namespace string, diags *diag.Diagnostics) bool {
+	if p.policy.allows(kind, namespace) {
+		return true
+	}
+
+	diags.AddError(fmt.Sprintf("Access to Backstage %s kind denied", kind),
+		fmt.Sprintf("Reading Backstage %s kind in namespace %s is denied by access_policy of the provider.", kind, namespace))
+	return false
+}
+
+// allowsRelated reports whether the access policy of the provider allows reading the entity of the kind in the namespace related to the one
+// read, e.g. the system of a component. A warning is added to diagnostics, if it does not, as the entity read is allowed regardless.
+func (p *providerData) allowsRelated(kind string, namespace string, attribute path.Path, diags *diag.Diagnostics) bool {
+	if p.policy.allows(kind, namespace) {
+		return true
+	}
+
+	diags.AddAttributeWarning(attribute, fmt.Sprintf("Access to Backstage %s kind denied", kind),
+		fmt.Sprintf("Backstage %s kind in namespace %s is not resolved, as reading it is denied by access_policy of the provider.", kind, namespace))
+	return false
+}
+
+// allowedEntities returns the entities the access policy of the provider allows reading. A warning is added to diagnostics, if any entities
+// are left out.
+func (p *providerData) allowedEntities(entities []backstage.Entity, diags *diag.Diagnostics) []backstage.Entity {
+	if p.policy == nil {
+		return entities
+	}
+
+	allowed := make([]backstage.Entity, 0, len(entities))
+	for _, e := range entities {
+		if p.policy.allows(e.Kind, e.Metadata.Namespace) {
+			allowed = append(allowed, e)
+		}
+	}
+
+	if denied := len(entities) - len(allowed); denied > 0 {
+		diags.AddWarning("Backstage entities denied", fmt.Sprintf("%d Backstage entities are left out, as reading them is denied by "+
+			"access_policy of the provider.", denied))
+	}
+
+	return allowed
+}
+
+// allowedRefs returns the entity references the access policy of the provider allows reading. A warning is added to diagnostics, if any
+// references are left out.
+func (p *providerData) allowedRefs(refs []string, diags *diag.Diagnostics) []string {
+	if p.policy == nil {
+		return refs
+	}
+
+	allowed := make([]string, 0, len(refs))
+	for _, r := range refs {
+		kind, namespace, _ := parseEntityRef(r, "", backstage.DefaultNamespaceName)
+		if p.policy.allows(kind, namespace) {
+			allowed = append(allowed, r)
+		}
+	}
+
+	if denied := len(refs) - len(allowed); denied > 0 {
+		diags.AddWarning("Backstage entities denied", fmt.Sprintf("%d Backstage entities are left out, as reading them is denied by "+
+			"access_policy of the provider.", denied))
+	}
+
+	return allowed
+}
+
+// lowerSet converts the values to a set of lower case values.
+func lowerSet(values []string) map[string]bool {
+	set := make(map[string]bool, len(values))
+	for _, v := range values {
+		set[strings.ToLower(v)] = true
+	}
+
+	return set
+}
@@ -0,0 +1,94 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// defaultCacheWarmConcurrency is the default number of entities read at a time when warming the cache.
+const defaultCacheWarmConcurrency = 8
+
+// warmCache reads the entities matching the filters into the cache for responses of the Backstage API, up to concurrency of them at a time, so
+// the data sources reading them later in the run are served from the cache. Failures are warned about only, as the data sources read the
+// entities from Backstage themselves then.
+func (p *providerData) warmCache(ctx context.Context, filters []string, concurrency int, diags *diag.Diagnostics) {
+	attribute := path.Root("cache").AtName("warm_filters")
+
+	entities, response, err := p.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: filters,
+		Fields:  []string{"kind", "metadata.name", "metadata.namespace"},
+	})
+	if err != nil {
+		diags.AddAttributeWarning(attribute, "Could not warm cache",
+			p.withRequestID(fmt.Sprintf("Could not list Backstage entities matching %v: %s", filters, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		diags.AddAttributeWarning(attribute, "Could not warm cache",
+			p.withRequestID(fmt.Sprintf("Could not list Backstage entities matching %v: %s", filters, response.Status)))
+		return
+	}
+
+	tflog.Info(ctx, fmt.Sprintf("Warming cache with %d Backstage entities", len(entities)))
+	var failed atomic.Int64
+	var wg sync.WaitGroup
+	slots := make(chan struct{}, concurrency)
+	for _, e := range entities {
+		wg.Add(1)
+		slots <- struct{}{}
+		go func() {
+			defer func() { <-slots; wg.Done() }()
+			if response, err := p.getEntity(ctx, e.Kind, e.Metadata.Name, e.Metadata.Namespace); err != nil || response.StatusCode != http.StatusOK {
+				failed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := failed.Load(); n > 0 {
+		diags.AddAttributeWarning(attribute, "Could not warm cache",
+			p.withRequestID(fmt.Sprintf("Could not read %d of %d Backstage entities matching %v, they are read when the data sources need them.",
+				n, len(entities), filters)))
+	}
+}
+
+// getEntity reads the entity the way the data source of its kind does, through the typed services of the client for the built-in kinds and
+// from the catalog API directly otherwise, so the cached response is served to the data source.
+func (p *providerData) getEntity(ctx context.Context, kind, name, namespace string) (*http.Response, error) {
+	var response *http.Response
+	var err error
+	switch strings.ToLower(kind) {
+	case strings.ToLower(backstage.KindAPI):
+		_, response, err = p.client.Catalog.APIs.Get(ctx, name, namespace)
+	case strings.ToLower(backstage.KindComponent):
+		_, response, err = p.client.Catalog.Components.Get(ctx, name, namespace)
+	case strings.ToLower(backstage.KindDomain):
+		_, response, err = p.client.Catalog.Domains.Get(ctx, name, namespace)
+	case strings.ToLower(backstage.KindGroup):
+		_, response, err = p.client.Catalog.Groups.Get(ctx, name, namespace)
+	case strings.ToLower(backstage.KindLocation):
+		_, response, err = p.client.Catalog.Locations.Get(ctx, name, namespace)
+	case strings.ToLower(backstage.KindResource):
+		_, response, err = p.client.Catalog.Resources.Get(ctx, name, namespace)
+	case strings.ToLower(backstage.KindSystem):
+		_, response, err = p.client.Catalog.Systems.Get(ctx, name, namespace)
+	case strings.ToLower(backstage.KindUser):
+		_, response, err = p.client.Catalog.Users.Get(ctx, name, namespace)
+	default:
+		response, err = p.doJSON(ctx, http.MethodGet, fmt.Sprintf(pathEntityByName, url.PathEscape(strings.ToLower(kind)), url.PathEscape(namespace),
+			url.PathEscape(name)), nil, nil)
+	}
+
+	return response, err
+}
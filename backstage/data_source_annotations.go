@@ -0,0 +1,163 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &annotationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &annotationsDataSource{}
+)
+
+// NewAnnotationsDataSource is a helper function to simplify the provider implementation.
+func NewAnnotationsDataSource() datasource.DataSource {
+	return &annotationsDataSource{}
+}
+
+// annotationsDataSource is the data source implementation.
+type annotationsDataSource struct {
+	*providerData
+}
+
+type annotationsDataSourceModel struct {
+	ID              types.String                 `tfsdk:"id"`
+	EntityRefs      []types.String               `tfsdk:"entity_refs"`
+	Keys            []types.String               `tfsdk:"keys"`
+	Values          map[string]map[string]string `tfsdk:"values"`
+	SensitiveValues map[string]map[string]string `tfsdk:"sensitive_values"`
+	MissingRefs     []types.String               `tfsdk:"missing_refs"`
+}
+
+const (
+	descriptionAnnotationsEntityRefs = "Entity references to the entities to read the annotations of, e.g. `component:default/artist-web`. The " +
+		"namespace defaults to `default`, the kind must be set."
+	descriptionAnnotationsKeys   = "Keys of the annotations to read, e.g. `github.com/project-slug`."
+	descriptionAnnotationsID     = "Sorted canonical entity references to the entities, separated by commas."
+	descriptionAnnotationsValues = "Values of the annotations keyed by the canonical entity references to the entities, e.g. " +
+		"`component:default/artist-web`, and by the keys of the annotations. Annotations the entities do not have are left out, and so are " +
+		"entities that do not exist."
+	descriptionAnnotationsSensitiveValues = "Values of the annotations listed in `sensitive_annotations` of the provider, keyed like `values`. " +
+		"Empty, if `sensitive_annotations_handling` of the provider strips them."
+	descriptionAnnotationsMissingRefs = "Canonical entity references to the entities that do not exist, or that reading is denied by " +
+		"`access_policy` of the provider, sorted."
+)
+
+// Metadata returns the data source type name.
+func (d *annotationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_annotations"
+}
+
+// Schema defines the schema for the data source.
+func (d *annotationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the values of some annotations of several entities of Backstage Software Catalog, " +
+			"with a single request reading only the annotations of the entities. Lighter than reading the entities, when modules only need " +
+			"their annotations, e.g. the slugs of their repositories.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionAnnotationsID},
+			"entity_refs": schema.ListAttribute{Required: true, MarkdownDescription: descriptionAnnotationsEntityRefs, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(
+					stringvalidator.RegexMatches(regexp.MustCompile(patternEntityRefWithKind), "must be an entity reference with a kind, e.g. `component:artist-web`"),
+				)}},
+			"keys": schema.ListAttribute{Required: true, MarkdownDescription: descriptionAnnotationsKeys, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))}},
+			"values": schema.MapAttribute{Computed: true, MarkdownDescription: descriptionAnnotationsValues,
+				ElementType: types.MapType{ElemType: types.StringType}},
+			"sensitive_values": schema.MapAttribute{Computed: true, Sensitive: true, MarkdownDescription: descriptionAnnotationsSensitiveValues,
+				ElementType: types.MapType{ElemType: types.StringType}},
+			"missing_refs": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionAnnotationsMissingRefs, ElementType: types.StringType},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *annotationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *annotationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state annotationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requested := map[string]bool{}
+	for _, r := range state.EntityRefs {
+		requested[canonicalTargetRef(r.ValueString())] = true
+	}
+	refs := sortedKeys(requested)
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting annotations of entities %v from Backstage API", refs))
+	entities, response, err := d.entitiesByRefs(ctx, refs, []string{"kind", "metadata.name", "metadata.namespace", "metadata.annotations"})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage entities",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", refs, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage entities",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", refs, response.Status)))
+		return
+	}
+
+	state.ID = types.StringValue(strings.Join(refs, ","))
+	state.Values, state.SensitiveValues = map[string]map[string]string{}, map[string]map[string]string{}
+	found := map[string]bool{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		ref := canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)
+		found[ref] = true
+		values, sensitiveValues := map[string]string{}, map[string]string{}
+		for _, k := range state.Keys {
+			v, ok := e.Metadata.Annotations[k.ValueString()]
+			if !ok {
+				continue
+			}
+			switch {
+			case !d.sensitiveAnnotations[k.ValueString()]:
+				values[k.ValueString()] = v
+			case !d.stripSensitiveAnnotations:
+				sensitiveValues[k.ValueString()] = v
+			}
+		}
+		state.Values[ref] = values
+		if len(sensitiveValues) > 0 {
+			state.SensitiveValues[ref] = sensitiveValues
+		}
+	}
+
+	state.MissingRefs = []types.String{}
+	for _, r := range refs {
+		if !found[r] {
+			state.MissingRefs = append(state.MissingRefs, types.StringValue(r))
+		}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_annotations", EntityRef: state.ID.ValueString()})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceAnnotations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceAnnotationsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_annotations.test", "id", "component:default/artist-web,component:default/non-existent-component-a9ab8"),
+					resource.TestCheckResourceAttrSet("data.backstage_annotations.test", "values.component:default/artist-web.backstage.io/techdocs-ref"),
+					resource.TestCheckResourceAttr("data.backstage_annotations.test", "missing_refs.#", "1"),
+					resource.TestCheckResourceAttr("data.backstage_annotations.test", "missing_refs.0", "component:default/non-existent-component-a9ab8"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceAnnotationsConfig = `
+data "backstage_annotations" "test" {
+  entity_refs = ["component:artist-web", "component:non-existent-component-a9ab8"]
+  keys        = ["backstage.io/techdocs-ref"]
+}
+`
@@ -7,9 +7,12 @@ import (
 	"regexp"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,19 +30,43 @@ func NewApiDataSource() datasource.DataSource {
 
 // apiDataSource is the data source implementation.
 type apiDataSource struct {
-	client *backstage.Client
+	*providerData
 }
 
 type apiDataSourceModel struct {
-	ID         types.String          `tfsdk:"id"`
-	Name       types.String          `tfsdk:"name"`
-	Namespace  types.String          `tfsdk:"namespace"`
-	ApiVersion types.String          `tfsdk:"api_version"`
-	Kind       types.String          `tfsdk:"kind"`
-	Metadata   *entityMetadataModel  `tfsdk:"metadata"`
-	Relations  []entityRelationModel `tfsdk:"relations"`
-	Spec       *apiSpecModel         `tfsdk:"spec"`
-	Fallback   *apiFallbackModel     `tfsdk:"fallback"`
+	ID                types.String            `tfsdk:"id"`
+	Name              types.String            `tfsdk:"name"`
+	UID               types.String            `tfsdk:"uid"`
+	Title             types.String            `tfsdk:"title"`
+	Namespace         types.String            `tfsdk:"namespace"`
+	ResolvedNamespace types.String            `tfsdk:"resolved_namespace"`
+	ExpectedOwner     types.String            `tfsdk:"expected_owner"`
+	ApiVersion        types.String            `tfsdk:"api_version"`
+	Kind              types.String            `tfsdk:"kind"`
+	ContentHash       types.String            `tfsdk:"content_hash"`
+	DefinitionSHA256  types.String            `tfsdk:"definition_sha256"`
+	DefinitionSize    types.Int64             `tfsdk:"definition_size"`
+	Status            *entityStatusModel      `tfsdk:"status"`
+	CatalogURL        types.String            `tfsdk:"catalog_url"`
+	TechDocsURL       types.String            `tfsdk:"techdocs_url"`
+	ParsedOwner       *entityRefModel         `tfsdk:"parsed_owner"`
+	Metadata          *entityMetadataModel    `tfsdk:"metadata"`
+	Relations         []entityRelationModel   `tfsdk:"relations"`
+	TargetsByType     types.Map               `tfsdk:"targets_by_type"`
+	Spec              *apiSpecModel           `tfsdk:"spec"`
+	AnnotationKeys    []types.String          `tfsdk:"annotation_keys"`
+	Query             types.String            `tfsdk:"query"`
+	QueryResult       jsontypes.Normalized    `tfsdk:"query_result"`
+	FollowMoves       types.Bool              `tfsdk:"follow_moves"`
+	MovedTo           *entityMovedToModel     `tfsdk:"moved_to"`
+	FollowAliases     types.Bool              `tfsdk:"follow_aliases"`
+	AliasedTo         *entityAliasedToModel   `tfsdk:"aliased_to"`
+	RequestInfo       *entityRequestInfoModel `tfsdk:"request_info"`
+	WaitFor           *entityWaitForModel     `tfsdk:"wait_for"`
+	Fallback          *apiFallbackModel       `tfsdk:"fallback"`
+	FallbackFile      types.String            `tfsdk:"fallback_file"`
+	FallbackSources   []types.String          `tfsdk:"fallback_sources"`
+	FallbackSource    types.String            `tfsdk:"fallback_source"`
 }
 
 type apiSpecModel struct {
@@ -67,11 +94,14 @@ func (d *apiDataSource) Metadata(_ context.Context, req datasource.MetadataReque
 }
 
 const (
-	descriptionApiSpecType       = "Type of the API definition."
-	descriptionApiSpecLifecycle  = "Lifecycle state of the API."
-	descriptionApiSpecOwner      = "An entity reference to the owner of the API"
-	descriptionApiSpecDefinition = "Definition of the API, based on the format defined by the type."
-	descriptionApiSpecSystem     = "An entity reference to the system that the API belongs to."
+	descriptionApiSpecType         = "Type of the API definition."
+	descriptionApiSpecLifecycle    = "Lifecycle state of the API."
+	descriptionApiSpecOwner        = "An entity reference to the owner of the API"
+	descriptionApiSpecDefinition   = "Definition of the API, based on the format defined by the type."
+	descriptionApiSpecSystem       = "An entity reference to the system that the API belongs to."
+	descriptionApiDefinitionSHA256 = "Hex encoded SHA-256 checksum of `spec.definition`, which changes only when the content of the definition " +
+		"does. Useful as a trigger of resources publishing the definition, instead of comparing the definition itself."
+	descriptionApiDefinitionSize = "Size of `spec.definition` in bytes."
 	descriptionApiFallback       = "A complete replica of the `API` as it would exist in backstage. Set this to provide a fallback in case the Backstage instance is not functioning, is down, or is unrealiable."
 )
 
@@ -81,14 +111,39 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 		MarkdownDescription: "Use this data source to get a specific " +
 			"[API entity](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-api) from Backstage Software Catalog.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
-			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+			"id":                schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash":      schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"definition_sha256": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionApiDefinitionSHA256},
+			"definition_size":   schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionApiDefinitionSize},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":  schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"name": schema.StringAttribute{Optional: true, Computed: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
 					regexp.MustCompile(patternEntityName),
 					"must follow Backstage format restrictions",
 				),
 			}},
+			"uid": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityUID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("title")),
+				stringvalidator.ConflictsWith(path.MatchRoot("namespace")),
+			}},
+			"title": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitle, Validators: catalogFilterValidators},
 			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
@@ -96,8 +151,10 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 					"must follow Backstage format restrictions",
 				),
 			}},
-			"api_version": schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
-			"kind":        schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"expected_owner":     schema.StringAttribute{Optional: true, Description: descriptionEntityExpectedOwner},
+			"api_version":        schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionEntityApiVersionRequested},
+			"kind":               schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
 			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
 				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
 				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
@@ -116,6 +173,8 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
 					},
 				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
 			}},
 			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -129,6 +188,8 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 						}},
 				},
 			}},
+			"targets_by_type": schema.MapAttribute{Computed: true, Description: descriptionEntityTargetsByType,
+				ElementType: types.ListType{ElemType: types.StringType}},
 			"spec": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
 				"type":       schema.StringAttribute{Computed: true, Description: descriptionApiSpecType},
 				"lifecycle":  schema.StringAttribute{Computed: true, Description: descriptionApiSpecLifecycle},
@@ -136,6 +197,38 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 				"definition": schema.StringAttribute{Computed: true, Description: descriptionApiSpecDefinition},
 				"system":     schema.StringAttribute{Computed: true, Description: descriptionApiSpecSystem},
 			}},
+			"parsed_owner": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityParsedOwner, Attributes: map[string]schema.Attribute{
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRef},
+				"kind":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefKind},
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefNamespace},
+				"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefName},
+			}},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"query":           schema.StringAttribute{Optional: true, Description: descriptionEntityQuery, Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"query_result":    schema.StringAttribute{Computed: true, Description: descriptionEntityQueryResult, CustomType: jsontypes.NormalizedType{}},
+			"follow_moves":    schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowMoves},
+			"moved_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMovedTo, Attributes: map[string]schema.Attribute{
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToNamespace},
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToRef},
+			}},
+			"follow_aliases": schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowAliases},
+			"aliased_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityAliasedTo, Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToName},
+				"ref":  schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToRef},
+			}},
+			"request_info": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRequestInfo, Attributes: map[string]schema.Attribute{
+				"request_id":  schema.StringAttribute{Computed: true, Description: descriptionEntityRequestInfoRequestID},
+				"status_code": schema.Int64Attribute{Computed: true, Description: descriptionEntityRequestInfoStatusCode},
+				"headers":     schema.MapAttribute{Computed: true, Description: descriptionEntityRequestInfoHeaders, ElementType: types.StringType},
+			}},
+			"wait_for": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityWaitFor, Attributes: map[string]schema.Attribute{
+				"previous_etag":   schema.StringAttribute{Optional: true, Description: descriptionEntityWaitForPreviousEtag},
+				"timeout_seconds": schema.Int64Attribute{Optional: true, Description: descriptionEntityWaitForTimeoutSeconds},
+			}},
+			"fallback_file": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackFile, Validators: fallbackFileValidators},
+			"fallback_sources": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackSources, ElementType: types.StringType,
+				Validators: fallbackSourcesValidators},
+			"fallback_source": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityFallbackSource},
 			"fallback": schema.SingleNestedAttribute{Optional: true, Description: descriptionApiFallback, Attributes: map[string]schema.Attribute{
 				"id": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
 				"name": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
@@ -155,15 +248,17 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 				"api_version": schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
 				"kind":        schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
 				"metadata": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
-					"uid":         schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
-					"etag":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
-					"name":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
-					"namespace":   schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
-					"title":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle},
-					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription},
+					"uid":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
+					"etag":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
+					"name":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
+					"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
+					"title":     schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle, Validators: fallbackTitleValidators},
+					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription,
+						Validators: fallbackDescriptionValidators},
 					"labels":      schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
 					"annotations": schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
-					"tags":        schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+					"tags": schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType,
+						Validators: fallbackTagsValidators},
 					"links": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
 						Attributes: map[string]schema.Attribute{
 							"url":   schema.StringAttribute{Optional: true, Description: descriptionEntityLinkURL},
@@ -172,6 +267,8 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 							"type":  schema.StringAttribute{Optional: true, Description: descriptionEntityLinkType},
 						},
 					}},
+					"sensitive_annotations": schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+						ElementType: types.StringType},
 				}},
 				"relations": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -186,9 +283,9 @@ func (d *apiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, re
 					},
 				}},
 				"spec": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
-					"type":       schema.StringAttribute{Optional: true, Description: descriptionApiSpecType},
-					"lifecycle":  schema.StringAttribute{Optional: true, Description: descriptionApiSpecLifecycle},
-					"owner":      schema.StringAttribute{Optional: true, Description: descriptionApiSpecOwner},
+					"type":       schema.StringAttribute{Optional: true, Description: descriptionApiSpecType, Validators: fallbackTypeValidators},
+					"lifecycle":  schema.StringAttribute{Optional: true, Description: descriptionApiSpecLifecycle, Validators: fallbackLifecycleValidators},
+					"owner":      schema.StringAttribute{Optional: true, Description: descriptionApiSpecOwner, Validators: fallbackOwnerValidators},
 					"definition": schema.StringAttribute{Optional: true, Description: descriptionApiSpecDefinition},
 					"system":     schema.StringAttribute{Optional: true, Description: descriptionApiSpecSystem},
 				}},
@@ -203,7 +300,7 @@ func (d *apiDataSource) Configure(_ context.Context, req datasource.ConfigureReq
 		return
 	}
 
-	d.client = req.ProviderData.(*backstage.Client)
+	d.providerData = req.ProviderData.(*providerData)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -215,15 +312,55 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		return
 	}
 
-	if state.Namespace.IsNull() {
-		state.Namespace = types.StringValue(backstage.DefaultNamespaceName)
+	requestedApiVersion := state.ApiVersion
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+	lookupResponse, lookupErr := d.resolveUID(ctx, backstage.KindAPI, state.UID, &state.Name, &state.ResolvedNamespace)
+	if !state.Title.IsNull() {
+		lookupResponse, lookupErr = d.resolveTitle(ctx, backstage.KindAPI, state.Title, state.Namespace, &state.Name, &state.ResolvedNamespace)
+	}
+
+	if !d.checkAccess(backstage.KindAPI, state.ResolvedNamespace.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	if state.Fallback != nil {
+		d.applyFallbackDefaults(backstage.KindAPI, state.Fallback, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Getting API kind %s/%s from Backstage API", state.Name.ValueString(), state.Namespace.ValueString()))
-	api, response, err := d.client.Catalog.APIs.Get(ctx, state.Name.ValueString(), state.Namespace.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Getting API kind %s/%s from Backstage API", state.Name.ValueString(), state.ResolvedNamespace.ValueString()))
+	api, response, err := waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ApiEntityV1alpha1, *http.Response, error) {
+		if lookupErr != nil || lookupResponse != nil {
+			return nil, lookupResponse, lookupErr
+		}
+		return d.client.Catalog.APIs.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+	}, func(e *backstage.ApiEntityV1alpha1) string { return e.Metadata.Etag })
+	if movedTo := d.findMove(ctx, state.FollowMoves, backstage.KindAPI, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); movedTo != nil {
+		state.MovedTo = movedTo
+		state.ResolvedNamespace = movedTo.Namespace
+		api, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ApiEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.APIs.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.ApiEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	if aliasedTo := d.findAlias(ctx, state.FollowAliases, backstage.KindAPI, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); aliasedTo != nil {
+		state.AliasedTo = aliasedTo
+		api, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ApiEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.APIs.Get(ctx, aliasedTo.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.ApiEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	state.RequestInfo = d.requestInfo(ctx, response, &resp.Diagnostics)
+	state.FallbackSource = types.StringNull()
+	if err != nil || response.StatusCode != http.StatusOK {
+		state.FallbackSource = d.resolveFallback(ctx, backstage.KindAPI, state.ResolvedNamespace.ValueString(), state.Name.ValueString(), state.FallbackSources,
+			state.FallbackFile, &state.Fallback, &resp.Diagnostics)
+	}
 	if err != nil {
 		const shortErr = "Error reading Backstage API kind"
-		longErr := fmt.Sprintf("Could not read Backstage API kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), err.Error())
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage API kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), err.Error()))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -231,9 +368,9 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if response.StatusCode != http.StatusOK {
+	if err == nil && response.StatusCode != http.StatusOK {
 		const shortErr = "Error reading Backstage API kind"
-		longErr := fmt.Sprintf("Could not read Backstage API kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), response.Status)
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage API kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), response.Status))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -241,7 +378,9 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 	// Rebuild state from fallback when configured
-	if (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil {
+	fallback := (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil
+	if fallback {
+		d.metrics.Count("fallbacks_total", 1, map[string]string{"kind": backstage.KindAPI})
 		if state.Fallback.ID.IsNull() {
 			state.Fallback.ID = types.StringValue("123456789")
 		}
@@ -253,7 +392,9 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		}
 		state.ID = state.Fallback.ID
 		state.Name = state.Fallback.Name
-		state.Namespace = state.Fallback.Namespace
+		if !state.Fallback.Namespace.IsNull() {
+			state.ResolvedNamespace = state.Fallback.Namespace
+		}
 		state.ApiVersion = state.Fallback.ApiVersion
 		state.Kind = state.Fallback.Kind
 		state.Metadata = state.Fallback.Metadata
@@ -261,14 +402,18 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		state.Spec = state.Fallback.Spec
 	}
 	if err == nil && response.StatusCode == http.StatusOK {
+		d.saveLastRead(ctx, backstage.KindAPI, api.Metadata.Namespace, api.Metadata.Name, api)
+		state.QueryResult = d.queryEntity(ctx, state.Query, backstage.KindAPI, api.Metadata.Namespace, api.Metadata.Name, &resp.Diagnostics)
 		state.ID = types.StringValue(api.Metadata.UID)
+		state.ContentHash = entityContentHash(api)
+		state.Status = entityStatus(api.Status)
 		state.ApiVersion = types.StringValue(api.ApiVersion)
 		state.Kind = types.StringValue(api.Kind)
 
 		for _, i := range api.Relations {
 			state.Relations = append(state.Relations, entityRelationModel{
 				Type:      types.StringValue(i.Type),
-				TargetRef: types.StringValue(i.TargetRef),
+				TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
 				Target: &entityRelationTargetModel{
 					Kind:      types.StringValue(i.Target.Kind),
 					Name:      types.StringValue(i.Target.Name),
@@ -277,11 +422,11 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		}
 
 		state.Spec = &apiSpecModel{
-			Type:       types.StringValue(api.Spec.Type),
-			Lifecycle:  types.StringValue(api.Spec.Lifecycle),
-			Owner:      types.StringValue(api.Spec.Owner),
-			Definition: types.StringValue(api.Spec.Definition),
-			System:     types.StringValue(api.Spec.System),
+			Type:       d.optionalString(api.Spec.Type),
+			Lifecycle:  d.optionalString(api.Spec.Lifecycle),
+			Owner:      d.optionalString(api.Spec.Owner),
+			Definition: d.optionalString(api.Spec.Definition),
+			System:     d.optionalString(api.Spec.System),
 		}
 
 		state.Metadata = &entityMetadataModel{
@@ -289,8 +434,8 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 			Etag:        types.StringValue(api.Metadata.Etag),
 			Name:        types.StringValue(api.Metadata.Name),
 			Namespace:   types.StringValue(api.Metadata.Namespace),
-			Title:       types.StringValue(api.Metadata.Title),
-			Description: types.StringValue(api.Metadata.Description),
+			Title:       d.optionalString(api.Metadata.Title),
+			Description: d.optionalString(api.Metadata.Description),
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		}
@@ -310,13 +455,44 @@ func (d *apiDataSource) Read(ctx context.Context, req datasource.ReadRequest, re
 		for _, v := range api.Metadata.Links {
 			state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
 				URL:   types.StringValue(v.URL),
-				Title: types.StringValue(v.Title),
-				Icon:  types.StringValue(v.Icon),
-				Type:  types.StringValue(v.Type),
+				Title: d.optionalString(v.Title),
+				Icon:  d.optionalString(v.Icon),
+				Type:  d.optionalString(v.Type),
 			})
 		}
 	}
 
+	var owner types.String
+	state.DefinitionSHA256, state.DefinitionSize = types.StringNull(), types.Int64Null()
+	if state.Spec != nil {
+		owner = state.Spec.Owner
+		if !state.Spec.Definition.IsNull() {
+			state.DefinitionSHA256 = contentHash([]byte(state.Spec.Definition.ValueString()))
+			state.DefinitionSize = types.Int64Value(int64(len(state.Spec.Definition.ValueString())))
+		}
+	}
+	state.ParsedOwner = parseOwnerRef(owner, state.ResolvedNamespace.ValueString())
+	checkExpectedOwner(state.ExpectedOwner, owner, "API", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	state.TargetsByType = relationTargetsByType(ctx, state.Relations, &resp.Diagnostics)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	checkApiVersion(requestedApiVersion, state.ApiVersion, "API", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{
+		DataSource: "backstage_api",
+		EntityRef:  fmt.Sprintf("api:%s/%s", state.ResolvedNamespace.ValueString(), state.Name.ValueString()),
+		Fallback:   fallback,
+	})
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
@@ -1,6 +1,9 @@
 package backstage
 
 import (
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -69,6 +72,9 @@ func TestAccApiDataSource_WithFallback(t *testing.T) {
 					resource.TestCheckResourceAttr("data.backstage_api.test", "spec.lifecycle", "production"),
 					resource.TestCheckResourceAttr("data.backstage_api.test", "spec.owner", "team-a"),
 					resource.TestCheckResourceAttr("data.backstage_api.test", "spec.definition", "https://example.com/api-spec"),
+					resource.TestCheckResourceAttr("data.backstage_api.test", "definition_sha256",
+						"4889ef6d73020713a50ef91702c3a1ae616c30a239bf4770836e01c355237aac"),
+					resource.TestCheckResourceAttr("data.backstage_api.test", "definition_size", "28"),
 					resource.TestCheckResourceAttr("data.backstage_api.test", "spec.system", "system-x"),
 					resource.TestCheckResourceAttr("data.backstage_api.test", "metadata.labels.key", "value"),
 				),
@@ -76,3 +82,56 @@ func TestAccApiDataSource_WithFallback(t *testing.T) {
 		},
 	})
 }
+
+func TestAccApiDataSource_WithFallbackSources(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "catalog-info.yaml")
+	if err := os.WriteFile(file, []byte(testAccApiDataSourceFallbackFile), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + fmt.Sprintf(`
+					data "backstage_api" "test" {
+						name             = "non_existent_api_a9ab8"
+						fallback_file    = %q
+						fallback_sources = ["file", "inline"]
+						fallback = {
+							name = "inline_api"
+						}
+					}
+				`, file),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_api.test", "fallback_source", "file"),
+					resource.TestCheckResourceAttr("data.backstage_api.test", "name", "non_existent_api_a9ab8"),
+					resource.TestCheckResourceAttr("data.backstage_api.test", "spec.type", "openapi"),
+					resource.TestCheckResourceAttr("data.backstage_api.test", "spec.owner", "team-a"),
+					resource.TestCheckResourceAttr("data.backstage_api.test", "metadata.tags.0", "rest"),
+				),
+			},
+		},
+	})
+}
+
+const testAccApiDataSourceFallbackFile = `
+apiVersion: backstage.io/v1alpha1
+kind: Component
+metadata:
+  name: artist-web
+spec:
+  type: website
+---
+apiVersion: backstage.io/v1alpha1
+kind: API
+metadata:
+  name: non_existent_api_a9ab8
+  tags:
+    - rest
+spec:
+  type: openapi
+  lifecycle: production
+  owner: team-a
+  definition: https://example.com/api-spec
+`
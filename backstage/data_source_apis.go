@@ -0,0 +1,327 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &apisDataSource{}
+	_ datasource.DataSourceWithConfigure = &apisDataSource{}
+)
+
+// NewApisDataSource is a helper function to simplify the provider implementation.
+func NewApisDataSource() datasource.DataSource {
+	return &apisDataSource{}
+}
+
+// apisDataSource is the data source implementation.
+type apisDataSource struct {
+	*providerData
+}
+
+type apisDataSourceModel struct {
+	ID                 types.String             `tfsdk:"id"`
+	Namespace          types.String             `tfsdk:"namespace"`
+	Type               types.String             `tfsdk:"type"`
+	Lifecycle          types.String             `tfsdk:"lifecycle"`
+	System             types.String             `tfsdk:"system"`
+	IncludeRelations   types.Bool               `tfsdk:"include_relations"`
+	IncludeDefinitions types.Bool               `tfsdk:"include_definitions"`
+	Apis               []apisItemModel          `tfsdk:"apis"`
+	EntitiesByRef      map[string]apisItemModel `tfsdk:"entities_by_ref"`
+	Limit              types.Int64              `tfsdk:"limit"`
+	Offset             types.Int64              `tfsdk:"offset"`
+	PageSize           types.Int64              `tfsdk:"page_size"`
+	OrderBy            []entityOrderModel       `tfsdk:"order_by"`
+	NameRegex          types.String             `tfsdk:"name_regex"`
+	TitleRegex         types.String             `tfsdk:"title_regex"`
+	CountOnly          types.Bool               `tfsdk:"count_only"`
+	RefsOnly           types.Bool               `tfsdk:"refs_only"`
+	TotalCount         types.Int64              `tfsdk:"total_count"`
+	Projection         types.String             `tfsdk:"projection"`
+	ProjectionResults  []jsontypes.Normalized   `tfsdk:"projection_results"`
+	Refs               []types.String           `tfsdk:"refs"`
+}
+
+type apisItemModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Ref         types.String   `tfsdk:"ref"`
+	Name        types.String   `tfsdk:"name"`
+	Namespace   types.String   `tfsdk:"namespace"`
+	Title       types.String   `tfsdk:"title"`
+	Description types.String   `tfsdk:"description"`
+	Type        types.String   `tfsdk:"type"`
+	Lifecycle   types.String   `tfsdk:"lifecycle"`
+	Owner       types.String   `tfsdk:"owner"`
+	System      types.String   `tfsdk:"system"`
+	Definition  types.String   `tfsdk:"definition"`
+	ProvidedBy  []types.String `tfsdk:"provided_by"`
+	ConsumedBy  []types.String `tfsdk:"consumed_by"`
+}
+
+const (
+	relationAPIProvidedBy = "apiProvidedBy"
+	relationAPIConsumedBy = "apiConsumedBy"
+
+	descriptionApisNamespace = "Namespace of the APIs. If not set, APIs of all namespaces are returned."
+	descriptionApisType      = "Type of the APIs, e.g. `openapi`, `asyncapi` or `grpc`. If not set, APIs of all types are returned."
+	descriptionApisLifecycle = "Lifecycle state of the APIs, e.g. `production`. If not set, APIs in all lifecycle states are returned."
+	descriptionApisSystem    = "An entity reference to the system of the APIs, e.g. `system:default/audio-playback`. The kind and namespace " +
+		"default to `system` and `namespace` of the data source, or `default` if it is not set."
+	descriptionApisIncludeDefinitions = "Whether to include the definitions of the APIs (default: false). Definitions may be large, so they are " +
+		"left out unless needed."
+	descriptionApisIncludeRelations = "Whether to include entity references to the components providing and consuming each API, from its " +
+		"`apiProvidedBy` and `apiConsumedBy` relations (default: false)."
+	descriptionApisApis          = "APIs sorted by their entity references."
+	descriptionApisRef           = "Entity reference to the API, e.g. `api:default/petstore`."
+	descriptionApisProvidedBy    = "Sorted entity references to the entities providing the API, if `include_relations` is set."
+	descriptionApisConsumedBy    = "Sorted entity references to the entities consuming the API, if `include_relations` is set."
+	descriptionApisDefinition    = "Definition of the API, based on the format defined by the type, if `include_definitions` is set."
+	descriptionApisDataSourceID  = "Identifier of the list of APIs."
+	descriptionApisDataSourceDoc = "Use this data source to get a list of " +
+		"[API entities](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-api) from Backstage Software Catalog, " +
+		"optionally with the components providing and consuming each of them. Useful to synchronize API gateways without a query per API."
+)
+
+// Metadata returns the data source type name.
+func (d *apisDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_apis"
+}
+
+// Schema defines the schema for the data source.
+func (d *apisDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: descriptionApisDataSourceDoc,
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionApisDataSourceID},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionApisNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"type":                schema.StringAttribute{Optional: true, Description: descriptionApisType, Validators: catalogFilterValidators},
+			"lifecycle":           schema.StringAttribute{Optional: true, Description: descriptionApisLifecycle, Validators: catalogFilterValidators},
+			"system":              schema.StringAttribute{Optional: true, Description: descriptionApisSystem, Validators: catalogFilterValidators},
+			"include_relations":   schema.BoolAttribute{Optional: true, Description: descriptionApisIncludeRelations},
+			"include_definitions": schema.BoolAttribute{Optional: true, Description: descriptionApisIncludeDefinitions},
+			"limit": schema.Int64Attribute{Optional: true, Description: descriptionEntityLimit, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"offset": schema.Int64Attribute{Optional: true, Description: descriptionEntityOffset, Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			}},
+			"page_size": schema.Int64Attribute{Optional: true, Description: descriptionEntityPageSize, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"order_by": schema.ListNestedAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderBy, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{Required: true, MarkdownDescription: descriptionEntityOrderByField, Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					}},
+					"direction": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderByDirection, Validators: []validator.String{
+						stringvalidator.OneOf(orderAscending, orderDescending),
+					}},
+				},
+			}},
+			"name_regex":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityNameRegex},
+			"title_regex": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitleRegex},
+			"count_only":  schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCountOnly},
+			"refs_only":   schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityRefsOnly},
+			"total_count": schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionEntityTotalCount},
+			"projection":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityProjection},
+			"projection_results": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntityProjectionResults,
+				ElementType: jsontypes.NormalizedType{}},
+			"refs": schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"apis": schema.ListNestedAttribute{Computed: true, Description: descriptionApisApis, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+					"ref":         schema.StringAttribute{Computed: true, Description: descriptionApisRef},
+					"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+					"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+					"type":        schema.StringAttribute{Computed: true, Description: descriptionApiSpecType},
+					"lifecycle":   schema.StringAttribute{Computed: true, Description: descriptionApiSpecLifecycle},
+					"owner":       schema.StringAttribute{Computed: true, Description: descriptionApiSpecOwner},
+					"system":      schema.StringAttribute{Computed: true, Description: descriptionApiSpecSystem},
+					"definition":  schema.StringAttribute{Computed: true, Description: descriptionApisDefinition},
+					"provided_by": schema.ListAttribute{Computed: true, Description: descriptionApisProvidedBy, ElementType: types.StringType},
+					"consumed_by": schema.ListAttribute{Computed: true, Description: descriptionApisConsumedBy, ElementType: types.StringType},
+				},
+			}},
+		},
+	}
+
+	// The map holds the items of the list keyed by their references.
+	resp.Schema.Attributes["entities_by_ref"] = schema.MapNestedAttribute{Computed: true, MarkdownDescription: descriptionEntitiesByRef,
+		NestedObject: resp.Schema.Attributes["apis"].(schema.ListNestedAttribute).NestedObject}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *apisDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *apisDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state apisDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileEntityRegexp(state.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	titleRegex := compileEntityRegexp(state.TitleRegex, path.Root("title_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := "kind=api"
+	if !state.Namespace.IsNull() {
+		filter += ",metadata.namespace=" + state.Namespace.ValueString()
+	}
+	if !state.Type.IsNull() {
+		filter += ",spec.type=" + state.Type.ValueString()
+	}
+	if !state.Lifecycle.IsNull() {
+		filter += ",spec.lifecycle=" + state.Lifecycle.ValueString()
+	}
+	// The system is matched on the partOf relation, which holds the normalized reference, unlike spec.system.
+	if !state.System.IsNull() {
+		namespace := state.Namespace.ValueString()
+		if namespace == "" {
+			namespace = backstage.DefaultNamespaceName
+		}
+		_, namespace, name := parseEntityRef(state.System.ValueString(), backstage.KindSystem, namespace)
+		filter += strings.ToLower(fmt.Sprintf(",relations.partOf=system:%s/%s", namespace, name))
+	}
+
+	fields := []string{"kind", "metadata.uid", "metadata.name", "metadata.namespace", "metadata.title", "metadata.description", "spec.type",
+		"spec.lifecycle", "spec.owner", "spec.system"}
+	if state.IncludeRelations.ValueBool() {
+		fields = append(fields, "relations")
+	}
+	if state.IncludeDefinitions.ValueBool() {
+		fields = append(fields, "spec.definition")
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting APIs %s from Backstage API", filter))
+	entities, response, err := d.listEntities(ctx, &backstage.ListEntityOptions{
+		Filters: []string{filter},
+		Fields:  projectionFields(state.Projection, countFields(state.CountOnly, fields)),
+		Order:   listEntityOrder(state.OrderBy, orderByName),
+	}, state.Limit, state.Offset, state.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage APIs", d.withRequestID(fmt.Sprintf("Could not read Backstage APIs: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage APIs", d.withRequestID(fmt.Sprintf("Could not read Backstage APIs: %s", response.Status)))
+		return
+	}
+
+	state.ID = types.StringValue(filter)
+	state.Apis = []apisItemModel{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		if !matchesEntityRegexps(e.Metadata, nameRegex, titleRegex) {
+			continue
+		}
+		api := apisItemModel{
+			ID:          types.StringValue(e.Metadata.UID),
+			Ref:         types.StringValue(strings.ToLower(fmt.Sprintf("api:%s/%s", e.Metadata.Namespace, e.Metadata.Name))),
+			Name:        types.StringValue(e.Metadata.Name),
+			Namespace:   types.StringValue(e.Metadata.Namespace),
+			Title:       d.optionalString(e.Metadata.Title),
+			Description: d.optionalString(e.Metadata.Description),
+			Type:        specString(e.Spec, "type"),
+			Lifecycle:   specString(e.Spec, "lifecycle"),
+			Owner:       specString(e.Spec, "owner"),
+			System:      specString(e.Spec, "system"),
+			Definition:  specString(e.Spec, "definition"),
+		}
+
+		if state.IncludeRelations.ValueBool() {
+			api.ProvidedBy = relationTargets(e.Relations, relationAPIProvidedBy)
+			api.ConsumedBy = relationTargets(e.Relations, relationAPIConsumedBy)
+		}
+
+		state.Apis = append(state.Apis, api)
+	}
+
+	// Entities ordered by the catalog as set in order_by keep their order.
+	if len(state.OrderBy) == 0 {
+		sort.Slice(state.Apis, func(i, j int) bool { return state.Apis[i].Ref.ValueString() < state.Apis[j].Ref.ValueString() })
+	}
+
+	refs := make([]string, 0, len(state.Apis))
+	for _, a := range state.Apis {
+		refs = append(refs, a.Ref.ValueString())
+	}
+	state.Refs = refsSet(refs)
+	state.TotalCount = types.Int64Value(int64(len(state.Refs)))
+	state.ProjectionResults = d.projectEntities(state.Projection, entities, refs, &resp.Diagnostics)
+	state.EntitiesByRef = entitiesByRef(state.Apis, func(i apisItemModel) types.String { return i.Ref })
+	if state.RefsOnly.ValueBool() || state.CountOnly.ValueBool() {
+		state.Apis, state.EntitiesByRef = []apisItemModel{}, map[string]apisItemModel{}
+	}
+	if state.CountOnly.ValueBool() {
+		state.Refs = []types.String{}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_apis", Filters: []string{filter}})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// specString returns the string value of the field of the spec of an entity, or null if it is not set.
+func specString(spec map[string]interface{}, field string) types.String {
+	if v, ok := spec[field].(string); ok {
+		return types.StringValue(v)
+	}
+
+	return types.StringNull()
+}
+
+// relationTargets returns the sorted references to the targets of the relations of the type.
+func relationTargets(relations []backstage.EntityRelation, relationType string) []types.String {
+	refs := []string{}
+	for _, r := range relations {
+		if r.Type == relationType {
+			refs = append(refs, r.TargetRef)
+		}
+	}
+	sort.Strings(refs)
+
+	targets := make([]types.String, 0, len(refs))
+	for _, r := range refs {
+		targets = append(targets, types.StringValue(r))
+	}
+
+	return targets
+}
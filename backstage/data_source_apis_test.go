@@ -0,0 +1,76 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceApis(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceApisConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_apis.test", "id", "kind=api,metadata.namespace=default"),
+					resource.TestCheckResourceAttrSet("data.backstage_apis.test", "apis.0.ref"),
+					resource.TestCheckResourceAttrSet("data.backstage_apis.test", "refs.#"),
+					resource.TestCheckResourceAttrSet("data.backstage_apis.test", "apis.0.lifecycle"),
+					resource.TestCheckNoResourceAttr("data.backstage_apis.test", "apis.0.provided_by"),
+					resource.TestCheckNoResourceAttr("data.backstage_apis.test", "apis.0.definition"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceApisConfig = `
+data "backstage_apis" "test" {
+  namespace = "default"
+}
+`
+
+func TestAccDataSourceApis_WithRelations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_apis" "test" {
+						include_relations = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_apis.test", "id", "kind=api"),
+					resource.TestCheckResourceAttrSet("data.backstage_apis.test", "apis.0.provided_by.#"),
+					resource.TestCheckResourceAttrSet("data.backstage_apis.test", "apis.0.consumed_by.#"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceApis_WithFilters(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_apis" "test" {
+						type                = "openapi"
+						system              = "audio-playback"
+						include_definitions = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_apis.test", "id",
+						"kind=api,spec.type=openapi,relations.partOf=system:default/audio-playback"),
+					resource.TestCheckResourceAttr("data.backstage_apis.test", "apis.0.type", "openapi"),
+					resource.TestCheckResourceAttr("data.backstage_apis.test", "apis.0.system", "audio-playback"),
+					resource.TestCheckResourceAttrSet("data.backstage_apis.test", "apis.0.definition"),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,95 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &capabilitiesDataSource{}
+	_ datasource.DataSourceWithConfigure = &capabilitiesDataSource{}
+)
+
+// NewCapabilitiesDataSource is a helper function to simplify the provider implementation.
+func NewCapabilitiesDataSource() datasource.DataSource {
+	return &capabilitiesDataSource{}
+}
+
+// capabilitiesDataSource is the data source implementation.
+type capabilitiesDataSource struct {
+	*providerData
+}
+
+type capabilitiesDataSourceModel struct {
+	ID              types.String `tfsdk:"id"`
+	EntitiesByQuery types.Bool   `tfsdk:"entities_by_query"`
+	EntitiesByRefs  types.Bool   `tfsdk:"entities_by_refs"`
+	EntityFacets    types.Bool   `tfsdk:"entity_facets"`
+	ScaffolderV2    types.Bool   `tfsdk:"scaffolder_v2"`
+}
+
+const (
+	descriptionCapabilitiesID              = "Base URL of the Backstage instance."
+	descriptionCapabilitiesEntitiesByQuery = "Whether the catalog supports querying entities with cursor based pagination (Backstage 1.7+). Required by " +
+		"`page_size` and `page_cursor` of `backstage_entities`."
+	descriptionCapabilitiesEntitiesByRefs = "Whether the catalog supports reading entities in batches by their refs (Backstage 1.1+)."
+	descriptionCapabilitiesEntityFacets   = "Whether the catalog supports reading facets of entities."
+	descriptionCapabilitiesScaffolderV2   = "Whether the scaffolder supports the v2 API, including dry-runs of templates (Backstage 1.4+)."
+)
+
+// Metadata returns the data source type name.
+func (d *capabilitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_capabilities"
+}
+
+// Schema defines the schema for the data source.
+func (d *capabilitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to detect which APIs used by the provider are supported by the Backstage instance. Older " +
+			"versions of Backstage lack some of them, so the flags tell which features of the provider work against the instance.",
+		Attributes: map[string]schema.Attribute{
+			"id":                schema.StringAttribute{Computed: true, Description: descriptionCapabilitiesID},
+			"entities_by_query": schema.BoolAttribute{Computed: true, MarkdownDescription: descriptionCapabilitiesEntitiesByQuery},
+			"entities_by_refs":  schema.BoolAttribute{Computed: true, Description: descriptionCapabilitiesEntitiesByRefs},
+			"entity_facets":     schema.BoolAttribute{Computed: true, Description: descriptionCapabilitiesEntityFacets},
+			"scaffolder_v2":     schema.BoolAttribute{Computed: true, Description: descriptionCapabilitiesScaffolderV2},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *capabilitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *capabilitiesDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	caps, err := d.capabilities(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error detecting capabilities of Backstage instance",
+			d.withRequestID(fmt.Sprintf("Could not detect capabilities of Backstage instance %s: %s", d.baseURL, err.Error())))
+		return
+	}
+
+	state := capabilitiesDataSourceModel{
+		ID:              types.StringValue(d.baseURL),
+		EntitiesByQuery: types.BoolValue(caps.EntitiesByQuery),
+		EntitiesByRefs:  types.BoolValue(caps.EntitiesByRefs),
+		EntityFacets:    types.BoolValue(caps.EntityFacets),
+		ScaffolderV2:    types.BoolValue(caps.ScaffolderV2),
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,27 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceCapabilities(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceCapabilitiesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.backstage_capabilities.test", "id"),
+					resource.TestCheckResourceAttr("data.backstage_capabilities.test", "entities_by_query", "true"),
+					resource.TestCheckResourceAttr("data.backstage_capabilities.test", "entities_by_refs", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceCapabilitiesConfig = `
+data "backstage_capabilities" "test" {}
+`
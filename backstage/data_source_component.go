@@ -7,9 +7,14 @@ import (
 	"regexp"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,19 +32,81 @@ func NewComponentDataSource() datasource.DataSource {
 
 // componentDataSource is the data source implementation.
 type componentDataSource struct {
-	client *backstage.Client
+	*providerData
 }
 
 type componentDataSourceModel struct {
-	ID         types.String            `tfsdk:"id"`
-	Name       types.String            `tfsdk:"name"`
-	Namespace  types.String            `tfsdk:"namespace"`
-	ApiVersion types.String            `tfsdk:"api_version"`
-	Kind       types.String            `tfsdk:"kind"`
-	Metadata   *entityMetadataModel    `tfsdk:"metadata"`
-	Relations  []entityRelationModel   `tfsdk:"relations"`
-	Spec       *componentSpecModel     `tfsdk:"spec"`
-	Fallback   *componentFallbackModel `tfsdk:"fallback"`
+	ID                types.String                  `tfsdk:"id"`
+	Name              types.String                  `tfsdk:"name"`
+	UID               types.String                  `tfsdk:"uid"`
+	Title             types.String                  `tfsdk:"title"`
+	Annotation        map[string]string             `tfsdk:"annotation"`
+	Namespace         types.String                  `tfsdk:"namespace"`
+	ResolvedNamespace types.String                  `tfsdk:"resolved_namespace"`
+	ExpectedOwner     types.String                  `tfsdk:"expected_owner"`
+	ApiVersion        types.String                  `tfsdk:"api_version"`
+	Kind              types.String                  `tfsdk:"kind"`
+	ContentHash       types.String                  `tfsdk:"content_hash"`
+	Status            *entityStatusModel            `tfsdk:"status"`
+	CatalogURL        types.String                  `tfsdk:"catalog_url"`
+	TechDocsURL       types.String                  `tfsdk:"techdocs_url"`
+	ParsedOwner       *entityRefModel               `tfsdk:"parsed_owner"`
+	Metadata          *entityMetadataModel          `tfsdk:"metadata"`
+	Relations         []entityRelationModel         `tfsdk:"relations"`
+	TargetsByType     types.Map                     `tfsdk:"targets_by_type"`
+	Spec              *componentSpecModel           `tfsdk:"spec"`
+	GitOps            *entityGitOpsModel            `tfsdk:"gitops"`
+	ResolveSystem     types.Bool                    `tfsdk:"resolve_system"`
+	ResolvedSystem    *componentResolvedSystemModel `tfsdk:"resolved_system"`
+	ResolvedDomain    *componentResolvedDomainModel `tfsdk:"resolved_domain"`
+	ResolveAPIs       types.Bool                    `tfsdk:"resolve_provided_apis"`
+	ResolvedAPIs      []componentResolvedAPIModel   `tfsdk:"resolved_provided_apis"`
+	AnnotationKeys    []types.String                `tfsdk:"annotation_keys"`
+	Query             types.String                  `tfsdk:"query"`
+	QueryResult       jsontypes.Normalized          `tfsdk:"query_result"`
+	FollowMoves       types.Bool                    `tfsdk:"follow_moves"`
+	MovedTo           *entityMovedToModel           `tfsdk:"moved_to"`
+	FollowAliases     types.Bool                    `tfsdk:"follow_aliases"`
+	AliasedTo         *entityAliasedToModel         `tfsdk:"aliased_to"`
+	RequestInfo       *entityRequestInfoModel       `tfsdk:"request_info"`
+	WaitFor           *entityWaitForModel           `tfsdk:"wait_for"`
+	Fallback          *componentFallbackModel       `tfsdk:"fallback"`
+	FallbackFile      types.String                  `tfsdk:"fallback_file"`
+	FallbackSources   []types.String                `tfsdk:"fallback_sources"`
+	FallbackSource    types.String                  `tfsdk:"fallback_source"`
+}
+
+type componentResolvedSystemModel struct {
+	ID          types.String `tfsdk:"id"`
+	Ref         types.String `tfsdk:"ref"`
+	Name        types.String `tfsdk:"name"`
+	Namespace   types.String `tfsdk:"namespace"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Owner       types.String `tfsdk:"owner"`
+	Domain      types.String `tfsdk:"domain"`
+}
+
+type componentResolvedDomainModel struct {
+	ID          types.String `tfsdk:"id"`
+	Ref         types.String `tfsdk:"ref"`
+	Name        types.String `tfsdk:"name"`
+	Namespace   types.String `tfsdk:"namespace"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Owner       types.String `tfsdk:"owner"`
+}
+
+type componentResolvedAPIModel struct {
+	ID         types.String `tfsdk:"id"`
+	Ref        types.String `tfsdk:"ref"`
+	Name       types.String `tfsdk:"name"`
+	Namespace  types.String `tfsdk:"namespace"`
+	Title      types.String `tfsdk:"title"`
+	Type       types.String `tfsdk:"type"`
+	Lifecycle  types.String `tfsdk:"lifecycle"`
+	Owner      types.String `tfsdk:"owner"`
+	Definition types.String `tfsdk:"definition"`
 }
 
 type componentSpecModel struct {
@@ -73,7 +140,18 @@ const (
 	descriptionComponentSpecConsumesAPIs   = "An array of entity references to the APIs that are consumed by the component."
 	descriptionComponentSpecDependsOn      = "An array of entity references to the components and resources that the component depends on."
 	descriptionComponentSpecSystem         = "An entity reference to the system that the component belongs to."
-	descriptionComponentFallback           = "A complete replica of the `Component` as it would exist in backstage. Set this to provide a fallback in case the Backstage instance is not functioning, is down, or is unrealiable."
+	descriptionComponentResolveSystem      = "Whether to resolve `spec.system` of the component into the `System` entity and its `Domain` entity, exposed as " +
+		"`resolved_system` and `resolved_domain` (default: false). Entities the `access_policy` of the provider denies reading are left null, " +
+		"with a warning."
+	descriptionComponentResolvedSystem = "The `System` entity the component belongs to, if `resolve_system` is set and the component belongs to a system."
+	descriptionComponentResolvedDomain = "The `Domain` entity the system of the component belongs to, if `resolve_system` is set and the system belongs " +
+		"to a domain."
+	descriptionComponentResolveProvidedAPIs = "Whether to resolve `spec.providesApis` of the component into the `API` entities, exposed with their " +
+		"definitions as `resolved_provided_apis` (default: false). The entities are read with a single request."
+	descriptionComponentResolvedProvidedAPIs = "The `API` entities the component provides, if `resolve_provided_apis` is set, in the order of " +
+		"`spec.providesApis`. APIs that do not exist are left out, with a warning."
+	descriptionResolvedEntityRef = "Entity reference to the entity, e.g. `system:default/audio-playback`."
+	descriptionComponentFallback = "A complete replica of the `Component` as it would exist in backstage. Set this to provide a fallback in case the Backstage instance is not functioning, is down, or is unrealiable."
 )
 
 // Metadata returns the data source type name.
@@ -87,14 +165,43 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 		MarkdownDescription: "Use this data source to get a specific " +
 			"[Component entity](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-component) from Backstage Software Catalog.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
-			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+			"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":  schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"name": schema.StringAttribute{Optional: true, Computed: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
 					regexp.MustCompile(patternEntityName),
 					"must follow Backstage format restrictions",
 				),
 			}},
+			"uid": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityUID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("annotation"), path.MatchRoot("title")),
+				stringvalidator.ConflictsWith(path.MatchRoot("namespace")),
+			}},
+			"title": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitle, Validators: catalogFilterValidators},
+			"annotation": schema.MapAttribute{Optional: true, MarkdownDescription: descriptionEntityAnnotation, ElementType: types.StringType,
+				Validators: []validator.Map{
+					mapvalidator.SizeAtLeast(1),
+					mapvalidator.KeysAre(catalogFilterValidators...),
+					mapvalidator.ValueStringsAre(catalogFilterValidators...),
+				}},
 			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
@@ -102,8 +209,10 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 					"must follow Backstage format restrictions",
 				),
 			}},
-			"api_version": schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
-			"kind":        schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"expected_owner":     schema.StringAttribute{Optional: true, Description: descriptionEntityExpectedOwner},
+			"api_version":        schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionEntityApiVersionRequested},
+			"kind":               schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
 			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
 				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
 				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
@@ -122,6 +231,8 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
 					},
 				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
 			}},
 			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -135,6 +246,8 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 						}},
 				},
 			}},
+			"targets_by_type": schema.MapAttribute{Computed: true, Description: descriptionEntityTargetsByType,
+				ElementType: types.ListType{ElemType: types.StringType}},
 			"spec": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
 				"type":            schema.StringAttribute{Computed: true, Description: descriptionComponentSpecType},
 				"lifecycle":       schema.StringAttribute{Computed: true, Description: descriptionComponentSpecLifecycle},
@@ -145,6 +258,86 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 				"depends_on":      schema.ListAttribute{Computed: true, Description: descriptionComponentSpecDependsOn, ElementType: types.StringType},
 				"system":          schema.StringAttribute{Computed: true, Description: descriptionComponentSpecSystem},
 			}},
+			"gitops": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOps, Attributes: map[string]schema.Attribute{
+				"argocd": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCD, Attributes: map[string]schema.Attribute{
+					"app_name":  schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCDAppName},
+					"project":   schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCDProject},
+					"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCDNamespace},
+				}},
+				"flux": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOpsFlux, Attributes: map[string]schema.Attribute{
+					"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsFluxName},
+					"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsFluxNamespace},
+				}},
+				"helm": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOpsHelm, Attributes: map[string]schema.Attribute{
+					"release_name": schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsHelmReleaseName},
+					"namespace":    schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsHelmNamespace},
+				}},
+			}},
+			"resolve_system": schema.BoolAttribute{Optional: true, Description: descriptionComponentResolveSystem},
+			"resolved_system": schema.SingleNestedAttribute{Computed: true, Description: descriptionComponentResolvedSystem, Attributes: map[string]schema.Attribute{
+				"id":          schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+				"ref":         schema.StringAttribute{Computed: true, Description: descriptionResolvedEntityRef},
+				"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+				"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+				"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+				"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+				"owner":       schema.StringAttribute{Computed: true, Description: descriptionSystemSpecOwner},
+				"domain":      schema.StringAttribute{Computed: true, Description: descriptionSystemSpecDomain},
+			}},
+			"resolved_domain": schema.SingleNestedAttribute{Computed: true, Description: descriptionComponentResolvedDomain, Attributes: map[string]schema.Attribute{
+				"id":          schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+				"ref":         schema.StringAttribute{Computed: true, Description: descriptionResolvedEntityRef},
+				"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+				"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+				"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+				"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+				"owner":       schema.StringAttribute{Computed: true, Description: descriptionDomainSpecOwner},
+			}},
+			"resolve_provided_apis": schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionComponentResolveProvidedAPIs},
+			"resolved_provided_apis": schema.ListNestedAttribute{Computed: true, MarkdownDescription: descriptionComponentResolvedProvidedAPIs,
+				NestedObject: schema.NestedAttributeObject{Attributes: map[string]schema.Attribute{
+					"id":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+					"ref":        schema.StringAttribute{Computed: true, Description: descriptionResolvedEntityRef},
+					"name":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"namespace":  schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"title":      schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+					"type":       schema.StringAttribute{Computed: true, Description: descriptionApiSpecType},
+					"lifecycle":  schema.StringAttribute{Computed: true, Description: descriptionApiSpecLifecycle},
+					"owner":      schema.StringAttribute{Computed: true, Description: descriptionApiSpecOwner},
+					"definition": schema.StringAttribute{Computed: true, Description: descriptionApiSpecDefinition},
+				}}},
+			"parsed_owner": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityParsedOwner, Attributes: map[string]schema.Attribute{
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRef},
+				"kind":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefKind},
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefNamespace},
+				"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefName},
+			}},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"query":           schema.StringAttribute{Optional: true, Description: descriptionEntityQuery, Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"query_result":    schema.StringAttribute{Computed: true, Description: descriptionEntityQueryResult, CustomType: jsontypes.NormalizedType{}},
+			"follow_moves":    schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowMoves},
+			"moved_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMovedTo, Attributes: map[string]schema.Attribute{
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToNamespace},
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToRef},
+			}},
+			"follow_aliases": schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowAliases},
+			"aliased_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityAliasedTo, Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToName},
+				"ref":  schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToRef},
+			}},
+			"request_info": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRequestInfo, Attributes: map[string]schema.Attribute{
+				"request_id":  schema.StringAttribute{Computed: true, Description: descriptionEntityRequestInfoRequestID},
+				"status_code": schema.Int64Attribute{Computed: true, Description: descriptionEntityRequestInfoStatusCode},
+				"headers":     schema.MapAttribute{Computed: true, Description: descriptionEntityRequestInfoHeaders, ElementType: types.StringType},
+			}},
+			"wait_for": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityWaitFor, Attributes: map[string]schema.Attribute{
+				"previous_etag":   schema.StringAttribute{Optional: true, Description: descriptionEntityWaitForPreviousEtag},
+				"timeout_seconds": schema.Int64Attribute{Optional: true, Description: descriptionEntityWaitForTimeoutSeconds},
+			}},
+			"fallback_file": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackFile, Validators: fallbackFileValidators},
+			"fallback_sources": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackSources, ElementType: types.StringType,
+				Validators: fallbackSourcesValidators},
+			"fallback_source": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityFallbackSource},
 			"fallback": schema.SingleNestedAttribute{Optional: true, Description: descriptionComponentFallback, Attributes: map[string]schema.Attribute{
 				"id": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
 				"name": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
@@ -164,15 +357,17 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 				"api_version": schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
 				"kind":        schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
 				"metadata": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
-					"uid":         schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
-					"etag":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
-					"name":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
-					"namespace":   schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
-					"title":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle},
-					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription},
+					"uid":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
+					"etag":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
+					"name":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
+					"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
+					"title":     schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle, Validators: fallbackTitleValidators},
+					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription,
+						Validators: fallbackDescriptionValidators},
 					"labels":      schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
 					"annotations": schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
-					"tags":        schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+					"tags": schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType,
+						Validators: fallbackTagsValidators},
 					"links": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
 						Attributes: map[string]schema.Attribute{
 							"url":   schema.StringAttribute{Optional: true, Description: descriptionEntityLinkURL},
@@ -181,6 +376,8 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 							"type":  schema.StringAttribute{Optional: true, Description: descriptionEntityLinkType},
 						},
 					}},
+					"sensitive_annotations": schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+						ElementType: types.StringType},
 				}},
 				"relations": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -195,9 +392,9 @@ func (d *componentDataSource) Schema(_ context.Context, _ datasource.SchemaReque
 					},
 				}},
 				"spec": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
-					"type":            schema.StringAttribute{Optional: true, Description: descriptionComponentSpecType},
-					"lifecycle":       schema.StringAttribute{Optional: true, Description: descriptionComponentSpecLifecycle},
-					"owner":           schema.StringAttribute{Optional: true, Description: descriptionComponentSpecOwner},
+					"type":            schema.StringAttribute{Optional: true, Description: descriptionComponentSpecType, Validators: fallbackTypeValidators},
+					"lifecycle":       schema.StringAttribute{Optional: true, Description: descriptionComponentSpecLifecycle, Validators: fallbackLifecycleValidators},
+					"owner":           schema.StringAttribute{Optional: true, Description: descriptionComponentSpecOwner, Validators: fallbackOwnerValidators},
 					"subcomponent_of": schema.StringAttribute{Optional: true, Description: descriptionComponentSpecSubcomponentOf},
 					"provides_apis":   schema.ListAttribute{Optional: true, Description: descriptionComponentSpecProvidesAPIs, ElementType: types.StringType},
 					"consumes_apis":   schema.ListAttribute{Optional: true, Description: descriptionComponentSpecConsumesAPIs, ElementType: types.StringType},
@@ -215,7 +412,7 @@ func (d *componentDataSource) Configure(_ context.Context, req datasource.Config
 		return
 	}
 
-	d.client = req.ProviderData.(*backstage.Client)
+	d.providerData = req.ProviderData.(*providerData)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -227,15 +424,58 @@ func (d *componentDataSource) Read(ctx context.Context, req datasource.ReadReque
 		return
 	}
 
-	if state.Namespace.IsNull() {
-		state.Namespace = types.StringValue(backstage.DefaultNamespaceName)
+	requestedApiVersion := state.ApiVersion
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+	lookupResponse, lookupErr := d.resolveUID(ctx, backstage.KindComponent, state.UID, &state.Name, &state.ResolvedNamespace)
+	if state.Annotation != nil {
+		lookupResponse, lookupErr = d.resolveAnnotations(ctx, backstage.KindComponent, state.Annotation, state.Namespace, &state.Name, &state.ResolvedNamespace)
+	}
+	if !state.Title.IsNull() {
+		lookupResponse, lookupErr = d.resolveTitle(ctx, backstage.KindComponent, state.Title, state.Namespace, &state.Name, &state.ResolvedNamespace)
+	}
+
+	if !d.checkAccess(backstage.KindComponent, state.ResolvedNamespace.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	if state.Fallback != nil {
+		d.applyFallbackDefaults(backstage.KindComponent, state.Fallback, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Getting Component kind %s/%s from Backstage API", state.Name.ValueString(), state.Namespace.ValueString()))
-	component, response, err := d.client.Catalog.Components.Get(ctx, state.Name.ValueString(), state.Namespace.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Getting Component kind %s/%s from Backstage API", state.Name.ValueString(), state.ResolvedNamespace.ValueString()))
+	component, response, err := waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ComponentEntityV1alpha1, *http.Response, error) {
+		if lookupErr != nil || lookupResponse != nil {
+			return nil, lookupResponse, lookupErr
+		}
+		return d.client.Catalog.Components.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+	}, func(e *backstage.ComponentEntityV1alpha1) string { return e.Metadata.Etag })
+	if movedTo := d.findMove(ctx, state.FollowMoves, backstage.KindComponent, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); movedTo != nil {
+		state.MovedTo = movedTo
+		state.ResolvedNamespace = movedTo.Namespace
+		component, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ComponentEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Components.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.ComponentEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	if aliasedTo := d.findAlias(ctx, state.FollowAliases, backstage.KindComponent, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); aliasedTo != nil {
+		state.AliasedTo = aliasedTo
+		component, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ComponentEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Components.Get(ctx, aliasedTo.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.ComponentEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	state.RequestInfo = d.requestInfo(ctx, response, &resp.Diagnostics)
+	state.FallbackSource = types.StringNull()
+	if err != nil || response.StatusCode != http.StatusOK {
+		state.FallbackSource = d.resolveFallback(ctx, backstage.KindComponent, state.ResolvedNamespace.ValueString(), state.Name.ValueString(), state.FallbackSources,
+			state.FallbackFile, &state.Fallback, &resp.Diagnostics)
+	}
 	if err != nil {
 		const shortErr = "Error reading Backstage Component kind"
-		longErr := fmt.Sprintf("Could not read Backstage Component kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), err.Error())
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Component kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), err.Error()))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -243,16 +483,18 @@ func (d *componentDataSource) Read(ctx context.Context, req datasource.ReadReque
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if response.StatusCode != http.StatusOK {
+	if err == nil && response.StatusCode != http.StatusOK {
 		const shortErr = "Error reading Backstage Component kind"
-		longErr := fmt.Sprintf("Could not read Backstage Component kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), response.Status)
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Component kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), response.Status))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
 		}
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
-	if (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil {
+	fallback := (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil
+	if fallback {
+		d.metrics.Count("fallbacks_total", 1, map[string]string{"kind": backstage.KindComponent})
 		if state.Fallback.ID.IsNull() {
 			state.Fallback.ID = types.StringValue("123456789")
 		}
@@ -264,7 +506,9 @@ func (d *componentDataSource) Read(ctx context.Context, req datasource.ReadReque
 		}
 		state.ID = state.Fallback.ID
 		state.Name = state.Fallback.Name
-		state.Namespace = state.Fallback.Namespace
+		if !state.Fallback.Namespace.IsNull() {
+			state.ResolvedNamespace = state.Fallback.Namespace
+		}
 		state.ApiVersion = state.Fallback.ApiVersion
 		state.Kind = state.Fallback.Kind
 		state.Metadata = state.Fallback.Metadata
@@ -273,14 +517,18 @@ func (d *componentDataSource) Read(ctx context.Context, req datasource.ReadReque
 	}
 
 	if err == nil && response.StatusCode == http.StatusOK {
+		d.saveLastRead(ctx, backstage.KindComponent, component.Metadata.Namespace, component.Metadata.Name, component)
+		state.QueryResult = d.queryEntity(ctx, state.Query, backstage.KindComponent, component.Metadata.Namespace, component.Metadata.Name, &resp.Diagnostics)
 		state.ID = types.StringValue(component.Metadata.UID)
+		state.ContentHash = entityContentHash(component)
+		state.Status = entityStatus(component.Status)
 		state.ApiVersion = types.StringValue(component.ApiVersion)
 		state.Kind = types.StringValue(component.Kind)
 
 		for _, i := range component.Relations {
 			state.Relations = append(state.Relations, entityRelationModel{
 				Type:      types.StringValue(i.Type),
-				TargetRef: types.StringValue(i.TargetRef),
+				TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
 				Target: &entityRelationTargetModel{
 					Kind:      types.StringValue(i.Target.Kind),
 					Name:      types.StringValue(i.Target.Name),
@@ -289,11 +537,11 @@ func (d *componentDataSource) Read(ctx context.Context, req datasource.ReadReque
 		}
 
 		state.Spec = &componentSpecModel{
-			Type:           types.StringValue(component.Spec.Type),
-			Lifecycle:      types.StringValue(component.Spec.Lifecycle),
-			Owner:          types.StringValue(component.Spec.Owner),
-			SubcomponentOf: types.StringValue(component.Spec.SubcomponentOf),
-			System:         types.StringValue(component.Spec.System),
+			Type:           d.optionalString(component.Spec.Type),
+			Lifecycle:      d.optionalString(component.Spec.Lifecycle),
+			Owner:          d.optionalString(component.Spec.Owner),
+			SubcomponentOf: d.optionalString(component.Spec.SubcomponentOf),
+			System:         d.optionalString(component.Spec.System),
 		}
 
 		for _, i := range component.Spec.ProvidesApis {
@@ -313,8 +561,8 @@ func (d *componentDataSource) Read(ctx context.Context, req datasource.ReadReque
 			Etag:        types.StringValue(component.Metadata.Etag),
 			Name:        types.StringValue(component.Metadata.Name),
 			Namespace:   types.StringValue(component.Metadata.Namespace),
-			Title:       types.StringValue(component.Metadata.Title),
-			Description: types.StringValue(component.Metadata.Description),
+			Title:       d.optionalString(component.Metadata.Title),
+			Description: d.optionalString(component.Metadata.Description),
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		}
@@ -334,16 +582,184 @@ func (d *componentDataSource) Read(ctx context.Context, req datasource.ReadReque
 		for _, v := range component.Metadata.Links {
 			state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
 				URL:   types.StringValue(v.URL),
-				Title: types.StringValue(v.Title),
-				Icon:  types.StringValue(v.Icon),
-				Type:  types.StringValue(v.Type),
+				Title: d.optionalString(v.Title),
+				Icon:  d.optionalString(v.Icon),
+				Type:  d.optionalString(v.Type),
 			})
 		}
 	}
 
+	var owner types.String
+	if state.Spec != nil {
+		owner = state.Spec.Owner
+	}
+	state.ParsedOwner = parseOwnerRef(owner, state.ResolvedNamespace.ValueString())
+	checkExpectedOwner(state.ExpectedOwner, owner, "Component", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.GitOps = d.gitOpsBridge(state.Metadata)
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	state.TargetsByType = relationTargetsByType(ctx, state.Relations, &resp.Diagnostics)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	checkApiVersion(requestedApiVersion, state.ApiVersion, "Component", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Resolving the system would fail the same way reading the component did, so it is left out when falling back.
+	if state.ResolveSystem.ValueBool() && !fallback && state.Spec != nil && state.Spec.System.ValueString() != "" {
+		d.resolveSystem(ctx, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	// Resolving the APIs would fail the same way reading the component did, so they are left out when falling back.
+	if state.ResolveAPIs.ValueBool() && !fallback && state.Spec != nil && len(state.Spec.ProvidesApis) > 0 {
+		d.resolveProvidedAPIs(ctx, &state, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	d.recordRead(ctx, audit.Entry{
+		DataSource: "backstage_component",
+		EntityRef:  fmt.Sprintf("component:%s/%s", state.ResolvedNamespace.ValueString(), state.Name.ValueString()),
+		Fallback:   fallback,
+	})
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// resolveSystem reads the system the component belongs to and the domain of the system, and sets them to the state. Those the access policy
+// of the provider denies reading are left null.
+func (d *componentDataSource) resolveSystem(ctx context.Context, state *componentDataSourceModel, diags *diag.Diagnostics) {
+	_, namespace, name := parseEntityRef(state.Spec.System.ValueString(), backstage.KindSystem, state.ResolvedNamespace.ValueString())
+	if !d.allowsRelated(backstage.KindSystem, namespace, path.Root("resolve_system"), diags) {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting System kind %s/%s of Component kind %s/%s from Backstage API", namespace, name,
+		state.ResolvedNamespace.ValueString(), state.Name.ValueString()))
+	system, response, err := d.client.Catalog.Systems.Get(ctx, name, namespace)
+	if err != nil {
+		diags.AddAttributeError(path.Root("resolve_system"), "Error resolving system of Backstage Component kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage System kind %s/%s: %s", namespace, name, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		diags.AddAttributeError(path.Root("resolve_system"), "Error resolving system of Backstage Component kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage System kind %s/%s: %s", namespace, name, response.Status)))
+		return
+	}
+
+	state.ResolvedSystem = &componentResolvedSystemModel{
+		ID:          types.StringValue(system.Metadata.UID),
+		Ref:         types.StringValue(fmt.Sprintf("system:%s/%s", system.Metadata.Namespace, system.Metadata.Name)),
+		Name:        types.StringValue(system.Metadata.Name),
+		Namespace:   types.StringValue(system.Metadata.Namespace),
+		Title:       d.optionalString(system.Metadata.Title),
+		Description: d.optionalString(system.Metadata.Description),
+		Owner:       d.optionalString(system.Spec.Owner),
+		Domain:      d.optionalString(system.Spec.Domain),
+	}
+
+	if system.Spec.Domain == "" {
+		return
+	}
+
+	_, namespace, name = parseEntityRef(system.Spec.Domain, backstage.KindDomain, system.Metadata.Namespace)
+	if !d.allowsRelated(backstage.KindDomain, namespace, path.Root("resolve_system"), diags) {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting Domain kind %s/%s of System kind %s/%s from Backstage API", namespace, name,
+		system.Metadata.Namespace, system.Metadata.Name))
+	domain, response, err := d.client.Catalog.Domains.Get(ctx, name, namespace)
+	if err != nil {
+		diags.AddAttributeError(path.Root("resolve_system"), "Error resolving domain of Backstage Component kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage Domain kind %s/%s: %s", namespace, name, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		diags.AddAttributeError(path.Root("resolve_system"), "Error resolving domain of Backstage Component kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage Domain kind %s/%s: %s", namespace, name, response.Status)))
+		return
+	}
+
+	state.ResolvedDomain = &componentResolvedDomainModel{
+		ID:          types.StringValue(domain.Metadata.UID),
+		Ref:         types.StringValue(fmt.Sprintf("domain:%s/%s", domain.Metadata.Namespace, domain.Metadata.Name)),
+		Name:        types.StringValue(domain.Metadata.Name),
+		Namespace:   types.StringValue(domain.Metadata.Namespace),
+		Title:       d.optionalString(domain.Metadata.Title),
+		Description: d.optionalString(domain.Metadata.Description),
+		Owner:       d.optionalString(domain.Spec.Owner),
+	}
+}
+
+// resolveProvidedAPIs reads the APIs the component provides, with their definitions, in one request, and sets them to the state in the order
+// of `spec.providesApis`.
+func (d *componentDataSource) resolveProvidedAPIs(ctx context.Context, state *componentDataSourceModel, diags *diag.Diagnostics) {
+	refs := make([]string, 0, len(state.Spec.ProvidesApis))
+	for _, r := range state.Spec.ProvidesApis {
+		kind, namespace, name := parseEntityRef(r.ValueString(), backstage.KindAPI, state.ResolvedNamespace.ValueString())
+		refs = append(refs, canonicalEntityRef(kind, namespace, name))
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting APIs %v of Component kind %s/%s from Backstage API", refs, state.ResolvedNamespace.ValueString(),
+		state.Name.ValueString()))
+	entities, response, err := d.entitiesByRefs(ctx, refs, []string{"kind", "metadata", "spec"})
+	if err != nil {
+		diags.AddAttributeError(path.Root("resolve_provided_apis"), "Error resolving provided APIs of Backstage Component kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage APIs %v: %s", refs, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		diags.AddAttributeError(path.Root("resolve_provided_apis"), "Error resolving provided APIs of Backstage Component kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage APIs %v: %s", refs, response.Status)))
+		return
+	}
+
+	apis := entitiesByRef(d.allowedEntities(entities, diags), func(e backstage.Entity) types.String {
+		return types.StringValue(canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name))
+	})
+
+	state.ResolvedAPIs = []componentResolvedAPIModel{}
+	for _, ref := range refs {
+		api, ok := apis[ref]
+		if !ok {
+			diags.AddAttributeWarning(path.Root("resolve_provided_apis"), "Could not resolve provided API of Backstage Component kind",
+				fmt.Sprintf("Backstage API %s provided by Component kind %s/%s does not exist.", ref, state.ResolvedNamespace.ValueString(),
+					state.Name.ValueString()))
+			continue
+		}
+
+		spec := func(key string) types.String {
+			value, _ := api.Spec[key].(string)
+			return d.optionalString(value)
+		}
+		state.ResolvedAPIs = append(state.ResolvedAPIs, componentResolvedAPIModel{
+			ID:         types.StringValue(api.Metadata.UID),
+			Ref:        types.StringValue(ref),
+			Name:       types.StringValue(api.Metadata.Name),
+			Namespace:  types.StringValue(api.Metadata.Namespace),
+			Title:      d.optionalString(api.Metadata.Title),
+			Type:       spec("type"),
+			Lifecycle:  spec("lifecycle"),
+			Owner:      spec("owner"),
+			Definition: spec("definition"),
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package backstage
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -15,12 +16,17 @@ func TestAccDataSourceComponent(t *testing.T) {
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("data.backstage_component.test", "api_version", "backstage.io/v1alpha1"),
 					resource.TestCheckResourceAttr("data.backstage_component.test", "kind", "Component"),
+					resource.TestCheckResourceAttrSet("data.backstage_component.test", "content_hash"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "request_info.status_code", "200"),
+					resource.TestCheckResourceAttrSet("data.backstage_component.test", "request_info.request_id"),
 					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.annotations.backstage.io/managed-by-location",
 						"url:https://github.com/backstage/backstage/tree/master/packages/catalog-model/examples/components/shuffle-api-component.yaml"),
 					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.description", "Shuffle API"),
 					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.tags.0", "go"),
 					resource.TestCheckResourceAttr("data.backstage_component.test", "relations.0.target_ref", "user:default/guest"),
 					resource.TestCheckResourceAttr("data.backstage_component.test", "spec.system", "audio-playback"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "namespace"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "resolved_namespace", "default"),
 				),
 			},
 		},
@@ -60,3 +66,662 @@ func TestAccDataSourceComponent_WithFallback(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceComponent_WithUnexpectedOwner(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name           = "shuffle-api"
+						expected_owner = "group:default/non-existent-team-a9ab8"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Unexpected owner of Backstage Component kind"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithFallbackDefaults(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "backstage" {
+						fallback_defaults = {
+							Component = {
+								"spec.owner"     = "group:default/team-a"
+								"spec.lifecycle" = "production"
+							}
+						}
+					}
+
+					data "backstage_component" "test" {
+						name = "non_existent_component_a9ab8"
+						fallback = {
+							name = "fallback_component"
+							spec = {
+								lifecycle = "experimental"
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "spec.owner", "group:default/team-a"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "spec.lifecycle", "experimental"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithResolveSystem(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name           = "shuffle-api"
+						resolve_system = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "resolved_system.ref", "system:default/audio-playback"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "resolved_system.domain", "playback"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "resolved_domain.ref", "domain:default/playback"),
+					resource.TestCheckResourceAttrSet("data.backstage_component.test", "resolved_domain.owner"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithResolveProvidedAPIs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name                  = "shuffle-api"
+						resolve_provided_apis = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.backstage_component.test", "resolved_provided_apis.#",
+						"data.backstage_component.test", "spec.provides_apis.#"),
+					resource.TestCheckResourceAttrSet("data.backstage_component.test", "resolved_provided_apis.0.definition"),
+					resource.TestMatchResourceAttr("data.backstage_component.test", "resolved_provided_apis.0.ref", regexp.MustCompile("^api:")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithAnnotationKeys(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name            = "shuffle-api"
+						annotation_keys = ["backstage.io/managed-by-location"]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.annotations.%", "1"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.annotations.backstage.io/managed-by-location",
+						"url:https://github.com/backstage/backstage/tree/master/packages/catalog-model/examples/components/shuffle-api-component.yaml"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithWarmCache(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "backstage" {
+						cache = {
+							type             = "memory"
+							warm_filters     = ["kind=component,spec.type=service"]
+							warm_concurrency = 4
+						}
+					}
+
+					data "backstage_component" "test" {
+						name = "artist-web"
+					}
+				`,
+				Check: resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.name", "artist-web"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithSensitiveAnnotations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "backstage" {
+						sensitive_annotations = ["backstage.io/managed-by-location"]
+					}
+
+					data "backstage_component" "test" {
+						name = "shuffle-api"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "metadata.annotations.backstage.io/managed-by-location"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.sensitive_annotations.backstage.io/managed-by-location",
+						"url:https://github.com/backstage/backstage/tree/master/packages/catalog-model/examples/components/shuffle-api-component.yaml"),
+				),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						sensitive_annotations          = ["backstage.io/managed-by-location"]
+						sensitive_annotations_handling = "strip"
+					}
+
+					data "backstage_component" "test" {
+						name = "shuffle-api"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "metadata.annotations.backstage.io/managed-by-location"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "metadata.sensitive_annotations.backstage.io/managed-by-location"),
+				),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						sensitive_annotations = ["backstage.io/managed-by-location"]
+					}
+
+					data "backstage_component" "test" {
+						name  = "shuffle-api"
+						query = "metadata.annotations.\"backstage.io/managed-by-location\""
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "query_result", "null"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithNormalizedOwner(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name           = "shuffle-api"
+						expected_owner = "user:default/guest"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "parsed_owner.ref", "user:default/guest"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "parsed_owner.kind", "user"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "parsed_owner.namespace", "default"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "parsed_owner.name", "guest"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithFollowMoves(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name         = "shuffle-api"
+						follow_moves = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.namespace", "default"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "moved_to"),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name         = "non_existent_component_a9ab8"
+						follow_moves = true
+					}
+				`,
+				ExpectError: regexp.MustCompile("Error reading Backstage Component kind"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithFollowAliases(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name           = "shuffle-api"
+						follow_aliases = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.name", "shuffle-api"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "aliased_to"),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name           = "non_existent_component_a9ab8"
+						follow_aliases = true
+					}
+				`,
+				ExpectError: regexp.MustCompile("Error reading Backstage Component kind"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithAccessPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "backstage" {
+						access_policy = {
+							denied_kinds = ["component"]
+						}
+					}
+
+					data "backstage_component" "test" {
+						name = "shuffle-api"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Access to Backstage Component kind denied"),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						access_policy = {
+							default            = "deny"
+							allowed_kinds      = ["Component"]
+							allowed_namespaces = ["default"]
+						}
+					}
+
+					data "backstage_component" "test" {
+						name = "shuffle-api"
+					}
+				`,
+				Check: resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.name", "shuffle-api"),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						access_policy = {
+							denied_kinds = ["domain"]
+						}
+					}
+
+					data "backstage_component" "test" {
+						name           = "shuffle-api"
+						resolve_system = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "resolved_system.ref", "system:default/audio-playback"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "resolved_domain"),
+				),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						access_policy = {
+							denied_kinds = ["system"]
+						}
+					}
+
+					data "backstage_component" "test" {
+						name           = "shuffle-api"
+						resolve_system = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "resolved_system"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "resolved_domain"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithGitOps(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					data "backstage_component" "test" {
+						name = "non_existent_component_a9ab8"
+						fallback = {
+							name = "fallback_component"
+							metadata = {
+								name = "fallback_component"
+								annotations = {
+									"argocd/app-name"                   = "shuffle-api"
+									"argocd/project-name"               = "audio"
+									"backstage.io/kubernetes-id"        = "shuffle-api"
+									"backstage.io/kubernetes-namespace" = "audio"
+								}
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "gitops.argocd.app_name", "shuffle-api"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "gitops.argocd.project", "audio"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "gitops.argocd.namespace"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "gitops.flux.name", "shuffle-api"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "gitops.helm.release_name", "shuffle-api"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "gitops.helm.namespace", "audio"),
+				),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						sensitive_annotations = ["argocd/project-name"]
+					}
+
+					data "backstage_component" "test" {
+						name = "non_existent_component_a9ab8"
+						fallback = {
+							name = "fallback_component"
+							metadata = {
+								name = "fallback_component"
+								annotations = {
+									"argocd/app-name"     = "shuffle-api"
+									"argocd/project-name" = "audio"
+								}
+							}
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "gitops.argocd.app_name", "shuffle-api"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "gitops.argocd.project"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithLegacyEmptyStrings(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceComponentConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "spec.subcomponent_of"),
+					resource.TestCheckNoResourceAttr("data.backstage_component.test", "metadata.title"),
+				),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						legacy_empty_strings = true
+					}
+				` + testAccDataSourceComponentConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "spec.subcomponent_of", ""),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "metadata.title", ""),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithQuery(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name  = "artist-web"
+						query = "{owner: spec.owner, types: relations[?type == 'ownedBy'].target.kind}"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "query_result", `{"owner":"team-a","types":["group"]}`),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name  = "artist-web"
+						query = "length(sort_by(relations, &type)) == length(relations)"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "query_result", "true"),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name  = "artist-web"
+						query = "spec.owner =="
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid query of Backstage Component kind"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithInvalidFallbackSpec(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name = "artist-web"
+						fallback = {
+							name = "artist-web"
+							spec = {
+								lifecycle = "retired"
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)Invalid Attribute Value Match.*fallback\.spec\.lifecycle`),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name = "artist-web"
+						fallback = {
+							name = "artist-web"
+							spec = {
+								owner = "group:default/team-a:x"
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("must be an entity reference"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithInvalidFallbackMetadata(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name = "artist-web"
+						fallback = {
+							name = "artist-web"
+							metadata = {
+								title = "  "
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile(`(?s)fallback\.metadata\.title.*must not be blank`),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name = "artist-web"
+						fallback = {
+							name = "artist-web"
+							metadata = {
+								tags = ["Java"]
+							}
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("must follow Backstage format restrictions"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithUID(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "by_name" {
+						name = "artist-web"
+					}
+
+					data "backstage_component" "test" {
+						uid = data.backstage_component.by_name.id
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_component.test", "name", "artist-web"),
+					resource.TestCheckResourceAttr("data.backstage_component.test", "resolved_namespace", "default"),
+					resource.TestCheckResourceAttrPair("data.backstage_component.test", "id", "data.backstage_component.by_name", "id"),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						uid  = "00000000-0000-0000-0000-000000000000"
+						name = "artist-web"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithAnnotation(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						annotation = {
+							"backstage.io/techdocs-ref" = "dir:."
+						}
+						namespace = "default"
+					}
+				`,
+				ExpectError: regexp.MustCompile("exactly one is expected"),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						annotation = {
+							"github.com/project-slug" = "non-existent-org-a9ab8/repo"
+						}
+					}
+				`,
+				ExpectError: regexp.MustCompile("no entity has annotations"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithApiVersion(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name        = "artist-web"
+						api_version = "backstage.io/v1alpha1"
+					}
+				`,
+				Check: resource.TestCheckResourceAttr("data.backstage_component.test", "api_version", "backstage.io/v1alpha1"),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name        = "artist-web"
+						api_version = "backstage.io/v1beta1"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Unexpected apiVersion of Backstage Component kind"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponent_WithTitle(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						title = "Non Existent Component a9ab8"
+					}
+				`,
+				ExpectError: regexp.MustCompile("no entity has title"),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_component" "test" {
+						name  = "artist-web"
+						title = "Artist Web"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
@@ -0,0 +1,197 @@
+package backstage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceComponents(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceComponentsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_components.test", "id",
+						"kind=component,metadata.namespace=default,spec.type=service,spec.lifecycle=experimental"),
+					resource.TestCheckResourceAttrSet("data.backstage_components.test", "components.0.ref"),
+					resource.TestCheckResourceAttrSet("data.backstage_components.test", "refs.#"),
+					resource.TestCheckResourceAttr("data.backstage_components.test", "components.0.type", "service"),
+					resource.TestCheckResourceAttr("data.backstage_components.test", "components.0.lifecycle", "experimental"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceComponentsConfig = `
+data "backstage_components" "test" {
+  namespace = "default"
+  type      = "service"
+  lifecycle = "experimental"
+}
+`
+
+func TestAccDataSourceComponents_WithOwnerAndTags(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						owner = "user:guest"
+						tags  = ["go"]
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_components.test", "id",
+						"kind=component,relations.ownedBy=user:default/guest,metadata.tags=go"),
+					resource.TestCheckResourceAttr("data.backstage_components.test", "components.0.tags.0", "go"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponents_WithOrderBy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						namespace = "default"
+						order_by  = [{ field = "metadata.name", direction = "desc" }]
+					}
+				`,
+				Check: resource.TestCheckResourceAttrSet("data.backstage_components.test", "components.0.name"),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						order_by = [{ field = "metadata.name", direction = "down" }]
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value Match"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponents_WithCountOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "all" {
+						lifecycle = "experimental"
+					}
+
+					data "backstage_components" "test" {
+						lifecycle  = "experimental"
+						count_only = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.backstage_components.test", "total_count", "data.backstage_components.all", "components.#"),
+					resource.TestCheckResourceAttr("data.backstage_components.test", "components.#", "0"),
+					resource.TestCheckResourceAttr("data.backstage_components.test", "refs.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponents_WithProjection(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						name_regex = "^artist-"
+						projection = "{name: metadata.name, kind: kind}"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.backstage_components.test", "projection_results.#", "data.backstage_components.test", "components.#"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_components.test", "projection_results.*", `{"kind":"Component","name":"artist-web"}`),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						projection = "metadata.name["
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid projection of Backstage entities"),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						sensitive_annotations = ["backstage.io/managed-by-location"]
+					}
+
+					data "backstage_components" "test" {
+						name_regex = "^artist-"
+						projection = "metadata.annotations.\"backstage.io/managed-by-location\""
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_components.test", "projection_results.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponents_WithTagsMatchAll(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						tags       = ["java", "data"]
+						tags_match = "all"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_components.test", "id", "kind=component,metadata.tags=java"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_components.test", "components.0.tags.*", "java"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_components.test", "components.0.tags.*", "data"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceComponents_WithNameRegex(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						name_regex = "^artist-"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.backstage_components.test", "refs.*", "component:default/artist-web"),
+					resource.TestMatchResourceAttr("data.backstage_components.test", "components.0.name", regexp.MustCompile("^artist-")),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_components" "test" {
+						name_regex = "^artist-("
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid regular expression"),
+			},
+		},
+	})
+}
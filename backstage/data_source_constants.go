@@ -0,0 +1,121 @@
+package backstage
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &constantsDataSource{}
+	_ datasource.DataSourceWithConfigure = &constantsDataSource{}
+)
+
+// NewConstantsDataSource is a helper function to simplify the provider implementation.
+func NewConstantsDataSource() datasource.DataSource {
+	return &constantsDataSource{}
+}
+
+// constantsDataSource is the data source implementation.
+type constantsDataSource struct {
+	*providerData
+}
+
+type constantsDataSourceModel struct {
+	ID                  types.String   `tfsdk:"id"`
+	Kinds               []types.String `tfsdk:"kinds"`
+	Lifecycles          []types.String `tfsdk:"lifecycles"`
+	RelationTypes       []types.String `tfsdk:"relation_types"`
+	CustomRelationTypes []types.String `tfsdk:"custom_relation_types"`
+	EntityRefPattern    types.String   `tfsdk:"entity_ref_pattern"`
+}
+
+const (
+	descriptionConstantsID                  = "Identifier of the lists, constant for a version of the provider."
+	descriptionConstantsKinds               = "Kinds of entities defined by Backstage, e.g. `Component`, sorted."
+	descriptionConstantsLifecycles          = "Lifecycles of components and APIs defined by Backstage, which lifecycles of fallbacks are validated against."
+	descriptionConstantsRelationTypes       = "Types of relations defined by Backstage, e.g. `ownedBy`, sorted."
+	descriptionConstantsCustomRelationTypes = "Types of relations listed in `custom_relation_types` of the provider, sorted."
+	descriptionConstantsEntityRefPattern    = "Regular expression matching entity references, with optional kind and namespace, which owners of " +
+		"fallbacks are validated against. Usable with the `regex` function of Terraform."
+)
+
+// Metadata returns the data source type name.
+func (d *constantsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_constants"
+}
+
+// Schema defines the schema for the data source.
+func (d *constantsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the canonical lists of kinds, lifecycles and relation types the provider knows, so " +
+			"modules can validate their inputs against the same lists. It does not read from the Backstage instance.",
+		Attributes: map[string]schema.Attribute{
+			"id":                    schema.StringAttribute{Computed: true, Description: descriptionConstantsID},
+			"kinds":                 schema.ListAttribute{Computed: true, MarkdownDescription: descriptionConstantsKinds, ElementType: types.StringType},
+			"lifecycles":            schema.ListAttribute{Computed: true, Description: descriptionConstantsLifecycles, ElementType: types.StringType},
+			"relation_types":        schema.ListAttribute{Computed: true, MarkdownDescription: descriptionConstantsRelationTypes, ElementType: types.StringType},
+			"custom_relation_types": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionConstantsCustomRelationTypes, ElementType: types.StringType},
+			"entity_ref_pattern":    schema.StringAttribute{Computed: true, MarkdownDescription: descriptionConstantsEntityRefPattern},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *constantsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *constantsDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	state := constantsDataSourceModel{
+		ID:                  types.StringValue("constants"),
+		Kinds:               []types.String{},
+		Lifecycles:          []types.String{},
+		RelationTypes:       []types.String{},
+		CustomRelationTypes: []types.String{},
+		EntityRefPattern:    types.StringValue(patternEntityRef),
+	}
+
+	kinds := append([]string{}, wellKnownKinds...)
+	sort.Strings(kinds)
+	for _, k := range kinds {
+		state.Kinds = append(state.Kinds, types.StringValue(k))
+	}
+
+	for _, l := range wellKnownLifecycles {
+		state.Lifecycles = append(state.Lifecycles, types.StringValue(l))
+	}
+
+	for _, t := range sortedKeys(wellKnownRelationTypes) {
+		state.RelationTypes = append(state.RelationTypes, types.StringValue(t))
+	}
+
+	for _, t := range sortedKeys(d.customRelationTypes) {
+		state.CustomRelationTypes = append(state.CustomRelationTypes, types.StringValue(t))
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// sortedKeys returns the keys of the set in ascending order.
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for k := range set {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
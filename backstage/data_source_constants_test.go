@@ -0,0 +1,29 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceConstants(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceConstantsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_constants.test", "kinds.#", "9"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_constants.test", "kinds.*", "Template"),
+					resource.TestCheckResourceAttr("data.backstage_constants.test", "lifecycles.#", "3"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_constants.test", "relation_types.*", "ownedBy"),
+					resource.TestCheckResourceAttrSet("data.backstage_constants.test", "entity_ref_pattern"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceConstantsConfig = `
+data "backstage_constants" "test" {}
+`
@@ -0,0 +1,314 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &dependencyClosureDataSource{}
+	_ datasource.DataSourceWithConfigure = &dependencyClosureDataSource{}
+)
+
+// NewDependencyClosureDataSource is a helper function to simplify the provider implementation.
+func NewDependencyClosureDataSource() datasource.DataSource {
+	return &dependencyClosureDataSource{}
+}
+
+// dependencyClosureDataSource is the data source implementation.
+type dependencyClosureDataSource struct {
+	*providerData
+}
+
+type dependencyClosureDataSourceModel struct {
+	ID            types.String     `tfsdk:"id"`
+	EntityRefs    []types.String   `tfsdk:"entity_refs"`
+	RelationTypes []types.String   `tfsdk:"relation_types"`
+	FailOnCycle   types.Bool       `tfsdk:"fail_on_cycle"`
+	Refs          []types.String   `tfsdk:"refs"`
+	OrderedRefs   []types.String   `tfsdk:"ordered_refs"`
+	Cycles        [][]types.String `tfsdk:"cycles"`
+}
+
+const (
+	descriptionDependencyClosureEntityRefs = "Entity references to the entities to start from, e.g. `component:default/artist-web`. The namespace " +
+		"defaults to `default`, the kind must be set."
+	descriptionDependencyClosureRelationTypes = "Types of the relations to follow from the entities to their dependencies (default: " +
+		"`[\"" + relationDependsOn + "\"]`)."
+	descriptionDependencyClosureFailOnCycle = "Whether to fail, instead of warn, if the dependencies have cycles (default: false)."
+	descriptionDependencyClosureID          = "Sorted canonical entity references to the entities started from, separated by commas."
+	descriptionDependencyClosureRefs        = "Canonical entity references to the entities started from and to all the entities they depend on, " +
+		"directly or transitively. Targets of relations not found in the catalog are left out."
+	descriptionDependencyClosureOrderedRefs = "The references of `refs` sorted topologically: every entity comes after the entities it depends on, " +
+		"so rollouts can be sequenced along the list. Entities not depending on each other are sorted by their references. Entities in a cycle " +
+		"are kept together, sorted by their references."
+	descriptionDependencyClosureCycles = "Cycles in the dependencies, each as the sorted references of the entities depending on each other, " +
+		"sorted by their first reference. Empty if there are no cycles."
+)
+
+// relationDependsOn is the type of the relations of entities to the entities they depend on.
+const relationDependsOn = "dependsOn"
+
+// Metadata returns the data source type name.
+func (d *dependencyClosureDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_dependency_closure"
+}
+
+// Schema defines the schema for the data source.
+func (d *dependencyClosureDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the entities of Backstage Software Catalog that given entities depend on, directly or " +
+			"transitively, in the order to deploy them in. Useful to sequence rollouts of several services by the dependencies declared in the " +
+			"catalog.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionDependencyClosureID},
+			"entity_refs": schema.ListAttribute{Required: true, MarkdownDescription: descriptionDependencyClosureEntityRefs, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(
+					stringvalidator.RegexMatches(regexp.MustCompile(patternEntityRefWithKind), "must be an entity reference with a kind, e.g. `component:artist-web`"),
+				)}},
+			"relation_types": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionDependencyClosureRelationTypes, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(catalogFilterValidators...)}},
+			"fail_on_cycle": schema.BoolAttribute{Optional: true, Description: descriptionDependencyClosureFailOnCycle},
+			"refs":          schema.SetAttribute{Computed: true, Description: descriptionDependencyClosureRefs, ElementType: types.StringType},
+			"ordered_refs":  schema.ListAttribute{Computed: true, MarkdownDescription: descriptionDependencyClosureOrderedRefs, ElementType: types.StringType},
+			"cycles": schema.ListAttribute{Computed: true, Description: descriptionDependencyClosureCycles,
+				ElementType: types.ListType{ElemType: types.StringType}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *dependencyClosureDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *dependencyClosureDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state dependencyClosureDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	relationTypes := map[string]bool{relationDependsOn: true}
+	if state.RelationTypes != nil {
+		relationTypes = map[string]bool{}
+		for _, t := range state.RelationTypes {
+			relationTypes[t.ValueString()] = true
+		}
+	}
+
+	seen := map[string]bool{}
+	for _, r := range state.EntityRefs {
+		seen[canonicalTargetRef(r.ValueString())] = true
+	}
+	roots := sortedKeys(seen)
+
+	// The dependencies are read breadth first, with one request for each level of them.
+	dependencies := map[string]map[string]bool{}
+	for frontier := roots; len(frontier) > 0; {
+		filters := make([]string, 0, len(frontier))
+		for _, r := range frontier {
+			kind, namespace, name := parseEntityRef(r, "", backstage.DefaultNamespaceName)
+			filters = append(filters, fmt.Sprintf("kind=%s,metadata.namespace=%s,metadata.name=%s", kind, namespace, name))
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Getting entities %v from Backstage API", frontier))
+		entities, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+			Filters: filters,
+			Fields:  []string{"kind", "metadata.name", "metadata.namespace", "relations"},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading Backstage entities",
+				d.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", frontier, err.Error())))
+			return
+		}
+
+		if response.StatusCode != http.StatusOK {
+			resp.Diagnostics.AddError("Error reading Backstage entities",
+				d.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", frontier, response.Status)))
+			return
+		}
+
+		next := map[string]bool{}
+		for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+			ref := canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)
+			dependencies[ref] = map[string]bool{}
+			for _, r := range e.Relations {
+				if !relationTypes[r.Type] {
+					continue
+				}
+				target := canonicalTargetRef(r.TargetRef)
+				dependencies[ref][target] = true
+				if !seen[target] {
+					seen[target], next[target] = true, true
+				}
+			}
+		}
+		frontier = sortedKeys(next)
+	}
+
+	for _, r := range roots {
+		if _, ok := dependencies[r]; !ok {
+			resp.Diagnostics.AddAttributeError(path.Root("entity_refs"), "Error reading Backstage entities",
+				fmt.Sprintf("Could not find Backstage entity %s.", r))
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	order, cycles := topologicalOrder(dependencies)
+
+	state.ID = types.StringValue(strings.Join(roots, ","))
+	state.Refs = refsSet(order)
+	state.OrderedRefs = []types.String{}
+	for _, r := range order {
+		state.OrderedRefs = append(state.OrderedRefs, types.StringValue(r))
+	}
+	state.Cycles = [][]types.String{}
+	for _, c := range cycles {
+		cycle := []types.String{}
+		for _, r := range c {
+			cycle = append(cycle, types.StringValue(r))
+		}
+		state.Cycles = append(state.Cycles, cycle)
+	}
+
+	for _, c := range cycles {
+		const shortErr = "Cycle in dependencies of Backstage entities"
+		longErr := fmt.Sprintf("Backstage entities %s depend on each other, so they are kept together in ordered_refs.", strings.Join(c, ", "))
+		if state.FailOnCycle.ValueBool() {
+			resp.Diagnostics.AddError(shortErr, longErr)
+		} else {
+			resp.Diagnostics.AddWarning(shortErr, longErr)
+		}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_dependency_closure", EntityRef: state.ID.ValueString()})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// topologicalOrder returns the entities sorted so that each comes after the entities it depends on, and the cycles in their dependencies.
+// Dependencies on entities that are not keys of dependencies are ignored. The strongly connected components of the dependencies are found
+// with Tarjan's algorithm, which yields them dependencies first; entities not depending on each other are sorted by their references, so
+// the order is stable across reads.
+func topologicalOrder(dependencies map[string]map[string]bool) (order []string, cycles [][]string) {
+	refs := make([]string, 0, len(dependencies))
+	for r := range dependencies {
+		refs = append(refs, r)
+	}
+	sort.Strings(refs)
+
+	index, lowLink, onStack := map[string]int{}, map[string]int{}, map[string]bool{}
+	stack, components := []string{}, [][]string{}
+	var connect func(string)
+	connect = func(r string) {
+		index[r], lowLink[r] = len(index), len(index)
+		stack, onStack[r] = append(stack, r), true
+		for _, t := range sortedKeys(dependencies[r]) {
+			if _, ok := dependencies[t]; !ok {
+				continue
+			}
+			if _, ok := index[t]; !ok {
+				connect(t)
+				lowLink[r] = min(lowLink[r], lowLink[t])
+			} else if onStack[t] {
+				lowLink[r] = min(lowLink[r], index[t])
+			}
+		}
+
+		if lowLink[r] == index[r] {
+			component := []string{}
+			for {
+				t := stack[len(stack)-1]
+				stack, onStack[t] = stack[:len(stack)-1], false
+				component = append(component, t)
+				if t == r {
+					break
+				}
+			}
+			sort.Strings(component)
+			components = append(components, component)
+		}
+	}
+	for _, r := range refs {
+		if _, ok := index[r]; !ok {
+			connect(r)
+		}
+	}
+
+	// Components are emitted once all the components they depend on are, taking the one with the lowest reference first.
+	componentOf := map[string]int{}
+	for i, c := range components {
+		for _, r := range c {
+			componentOf[r] = i
+		}
+	}
+	pending := make([]map[int]bool, len(components))
+	dependents := make([][]int, len(components))
+	for i, c := range components {
+		pending[i] = map[int]bool{}
+		for _, r := range c {
+			for t := range dependencies[r] {
+				if j, ok := componentOf[t]; ok && j != i && !pending[i][j] {
+					pending[i][j] = true
+					dependents[j] = append(dependents[j], i)
+				}
+			}
+		}
+		if len(c) > 1 || dependencies[c[0]][c[0]] {
+			cycles = append(cycles, c)
+		}
+	}
+
+	ready := []int{}
+	for i := range components {
+		if len(pending[i]) == 0 {
+			ready = append(ready, i)
+		}
+	}
+	for len(ready) > 0 {
+		sort.Slice(ready, func(a, b int) bool { return components[ready[a]][0] < components[ready[b]][0] })
+		i := ready[0]
+		ready = ready[1:]
+		order = append(order, components[i]...)
+		for _, j := range dependents[i] {
+			delete(pending[j], i)
+			if len(pending[j]) == 0 {
+				ready = append(ready, j)
+			}
+		}
+	}
+
+	sort.Slice(cycles, func(a, b int) bool { return cycles[a][0] < cycles[b][0] })
+	return order, cycles
+}
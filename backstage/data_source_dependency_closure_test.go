@@ -0,0 +1,43 @@
+package backstage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDependencyClosure(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceDependencyClosureConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.backstage_dependency_closure.test", "refs.*", "resource:default/artists-db"),
+					resource.TestCheckResourceAttr("data.backstage_dependency_closure.test", "ordered_refs.0", "resource:default/artists-db"),
+					resource.TestCheckResourceAttr("data.backstage_dependency_closure.test", "cycles.#", "0"),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_dependency_closure" "test" {
+						entity_refs = ["component:non-existent-component-a9ab8"]
+					}
+				`,
+				ExpectError: regexp.MustCompile("Could not find Backstage entity"),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDependencyClosureConfig = `
+data "backstage_entity_referencers" "test" {
+  entity_ref     = "resource:artists-db"
+  relation_types = ["dependencyOf"]
+}
+
+data "backstage_dependency_closure" "test" {
+  entity_refs = data.backstage_entity_referencers.test.refs
+}
+`
@@ -7,9 +7,12 @@ import (
 	"regexp"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,19 +30,41 @@ func NewDomainDataSource() datasource.DataSource {
 
 // domainDataSource is the data source implementation.
 type domainDataSource struct {
-	client *backstage.Client
+	*providerData
 }
 
 type domainDataSourceModel struct {
-	ID         types.String          `tfsdk:"id"`
-	Name       types.String          `tfsdk:"name"`
-	Namespace  types.String          `tfsdk:"namespace"`
-	ApiVersion types.String          `tfsdk:"api_version"`
-	Kind       types.String          `tfsdk:"kind"`
-	Metadata   *entityMetadataModel  `tfsdk:"metadata"`
-	Relations  []entityRelationModel `tfsdk:"relations"`
-	Spec       *domainSpecModel      `tfsdk:"spec"`
-	Fallback   *domainFallbackModel  `tfsdk:"fallback"`
+	ID                types.String            `tfsdk:"id"`
+	Name              types.String            `tfsdk:"name"`
+	UID               types.String            `tfsdk:"uid"`
+	Title             types.String            `tfsdk:"title"`
+	Namespace         types.String            `tfsdk:"namespace"`
+	ResolvedNamespace types.String            `tfsdk:"resolved_namespace"`
+	ExpectedOwner     types.String            `tfsdk:"expected_owner"`
+	ApiVersion        types.String            `tfsdk:"api_version"`
+	Kind              types.String            `tfsdk:"kind"`
+	ContentHash       types.String            `tfsdk:"content_hash"`
+	Status            *entityStatusModel      `tfsdk:"status"`
+	CatalogURL        types.String            `tfsdk:"catalog_url"`
+	TechDocsURL       types.String            `tfsdk:"techdocs_url"`
+	ParsedOwner       *entityRefModel         `tfsdk:"parsed_owner"`
+	Metadata          *entityMetadataModel    `tfsdk:"metadata"`
+	Relations         []entityRelationModel   `tfsdk:"relations"`
+	TargetsByType     types.Map               `tfsdk:"targets_by_type"`
+	Spec              *domainSpecModel        `tfsdk:"spec"`
+	AnnotationKeys    []types.String          `tfsdk:"annotation_keys"`
+	Query             types.String            `tfsdk:"query"`
+	QueryResult       jsontypes.Normalized    `tfsdk:"query_result"`
+	FollowMoves       types.Bool              `tfsdk:"follow_moves"`
+	MovedTo           *entityMovedToModel     `tfsdk:"moved_to"`
+	FollowAliases     types.Bool              `tfsdk:"follow_aliases"`
+	AliasedTo         *entityAliasedToModel   `tfsdk:"aliased_to"`
+	RequestInfo       *entityRequestInfoModel `tfsdk:"request_info"`
+	WaitFor           *entityWaitForModel     `tfsdk:"wait_for"`
+	Fallback          *domainFallbackModel    `tfsdk:"fallback"`
+	FallbackFile      types.String            `tfsdk:"fallback_file"`
+	FallbackSources   []types.String          `tfsdk:"fallback_sources"`
+	FallbackSource    types.String            `tfsdk:"fallback_source"`
 }
 
 type domainFallbackModel struct {
@@ -73,14 +98,37 @@ func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		MarkdownDescription: "Use this data source to get a specific " +
 			"[Domain entity](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-domain) from Backstage Software Catalog.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
-			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+			"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":  schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"name": schema.StringAttribute{Optional: true, Computed: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
 					regexp.MustCompile(patternEntityName),
 					"must follow Backstage format restrictions",
 				),
 			}},
+			"uid": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityUID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("title")),
+				stringvalidator.ConflictsWith(path.MatchRoot("namespace")),
+			}},
+			"title": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitle, Validators: catalogFilterValidators},
 			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
@@ -88,8 +136,10 @@ func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"must follow Backstage format restrictions",
 				),
 			}},
-			"api_version": schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
-			"kind":        schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"expected_owner":     schema.StringAttribute{Optional: true, Description: descriptionEntityExpectedOwner},
+			"api_version":        schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionEntityApiVersionRequested},
+			"kind":               schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
 			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
 				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
 				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
@@ -108,6 +158,8 @@ func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
 					},
 				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
 			}},
 			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -121,9 +173,43 @@ func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						}},
 				},
 			}},
+			"targets_by_type": schema.MapAttribute{Computed: true, Description: descriptionEntityTargetsByType,
+				ElementType: types.ListType{ElemType: types.StringType}},
 			"spec": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
 				"owner": schema.StringAttribute{Computed: true, Description: descriptionDomainSpecOwner},
 			}},
+			"parsed_owner": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityParsedOwner, Attributes: map[string]schema.Attribute{
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRef},
+				"kind":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefKind},
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefNamespace},
+				"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefName},
+			}},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"query":           schema.StringAttribute{Optional: true, Description: descriptionEntityQuery, Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"query_result":    schema.StringAttribute{Computed: true, Description: descriptionEntityQueryResult, CustomType: jsontypes.NormalizedType{}},
+			"follow_moves":    schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowMoves},
+			"moved_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMovedTo, Attributes: map[string]schema.Attribute{
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToNamespace},
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToRef},
+			}},
+			"follow_aliases": schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowAliases},
+			"aliased_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityAliasedTo, Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToName},
+				"ref":  schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToRef},
+			}},
+			"request_info": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRequestInfo, Attributes: map[string]schema.Attribute{
+				"request_id":  schema.StringAttribute{Computed: true, Description: descriptionEntityRequestInfoRequestID},
+				"status_code": schema.Int64Attribute{Computed: true, Description: descriptionEntityRequestInfoStatusCode},
+				"headers":     schema.MapAttribute{Computed: true, Description: descriptionEntityRequestInfoHeaders, ElementType: types.StringType},
+			}},
+			"wait_for": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityWaitFor, Attributes: map[string]schema.Attribute{
+				"previous_etag":   schema.StringAttribute{Optional: true, Description: descriptionEntityWaitForPreviousEtag},
+				"timeout_seconds": schema.Int64Attribute{Optional: true, Description: descriptionEntityWaitForTimeoutSeconds},
+			}},
+			"fallback_file": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackFile, Validators: fallbackFileValidators},
+			"fallback_sources": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackSources, ElementType: types.StringType,
+				Validators: fallbackSourcesValidators},
+			"fallback_source": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityFallbackSource},
 			"fallback": schema.SingleNestedAttribute{Optional: true, Description: descriptionDomainFallback, Attributes: map[string]schema.Attribute{
 				"id": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
 				"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
@@ -143,15 +229,17 @@ func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				"api_version": schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
 				"kind":        schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
 				"metadata": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
-					"uid":         schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
-					"etag":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
-					"name":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
-					"namespace":   schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
-					"title":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle},
-					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription},
+					"uid":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
+					"etag":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
+					"name":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
+					"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
+					"title":     schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle, Validators: fallbackTitleValidators},
+					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription,
+						Validators: fallbackDescriptionValidators},
 					"labels":      schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
 					"annotations": schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
-					"tags":        schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+					"tags": schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType,
+						Validators: fallbackTagsValidators},
 					"links": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
 						Attributes: map[string]schema.Attribute{
 							"url":   schema.StringAttribute{Optional: true, Description: descriptionEntityLinkURL},
@@ -160,6 +248,8 @@ func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							"type":  schema.StringAttribute{Optional: true, Description: descriptionEntityLinkType},
 						},
 					}},
+					"sensitive_annotations": schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+						ElementType: types.StringType},
 				}},
 				"relations": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -174,7 +264,7 @@ func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					},
 				}},
 				"spec": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
-					"owner": schema.StringAttribute{Optional: true, Description: descriptionDomainSpecOwner},
+					"owner": schema.StringAttribute{Optional: true, Description: descriptionDomainSpecOwner, Validators: fallbackOwnerValidators},
 				}},
 			}},
 		},
@@ -187,7 +277,7 @@ func (d *domainDataSource) Configure(_ context.Context, req datasource.Configure
 		return
 	}
 
-	d.client = req.ProviderData.(*backstage.Client)
+	d.providerData = req.ProviderData.(*providerData)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -199,15 +289,55 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	if state.Namespace.IsNull() {
-		state.Namespace = types.StringValue(backstage.DefaultNamespaceName)
+	requestedApiVersion := state.ApiVersion
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+	lookupResponse, lookupErr := d.resolveUID(ctx, backstage.KindDomain, state.UID, &state.Name, &state.ResolvedNamespace)
+	if !state.Title.IsNull() {
+		lookupResponse, lookupErr = d.resolveTitle(ctx, backstage.KindDomain, state.Title, state.Namespace, &state.Name, &state.ResolvedNamespace)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Getting Domain kind %s/%s from Backstage API", state.Name.ValueString(), state.Namespace.ValueString()))
-	domain, response, err := d.client.Catalog.Domains.Get(ctx, state.Name.ValueString(), state.Namespace.ValueString())
+	if !d.checkAccess(backstage.KindDomain, state.ResolvedNamespace.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	if state.Fallback != nil {
+		d.applyFallbackDefaults(backstage.KindDomain, state.Fallback, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting Domain kind %s/%s from Backstage API", state.Name.ValueString(), state.ResolvedNamespace.ValueString()))
+	domain, response, err := waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.DomainEntityV1alpha1, *http.Response, error) {
+		if lookupErr != nil || lookupResponse != nil {
+			return nil, lookupResponse, lookupErr
+		}
+		return d.client.Catalog.Domains.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+	}, func(e *backstage.DomainEntityV1alpha1) string { return e.Metadata.Etag })
+	if movedTo := d.findMove(ctx, state.FollowMoves, backstage.KindDomain, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); movedTo != nil {
+		state.MovedTo = movedTo
+		state.ResolvedNamespace = movedTo.Namespace
+		domain, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.DomainEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Domains.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.DomainEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	if aliasedTo := d.findAlias(ctx, state.FollowAliases, backstage.KindDomain, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); aliasedTo != nil {
+		state.AliasedTo = aliasedTo
+		domain, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.DomainEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Domains.Get(ctx, aliasedTo.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.DomainEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	state.RequestInfo = d.requestInfo(ctx, response, &resp.Diagnostics)
+	state.FallbackSource = types.StringNull()
+	if err != nil || response.StatusCode != http.StatusOK {
+		state.FallbackSource = d.resolveFallback(ctx, backstage.KindDomain, state.ResolvedNamespace.ValueString(), state.Name.ValueString(), state.FallbackSources,
+			state.FallbackFile, &state.Fallback, &resp.Diagnostics)
+	}
 	if err != nil {
 		const shortErr = "Error reading Backstage Domain kind"
-		longErr := fmt.Sprintf("Could not read Backstage Domain kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), err.Error())
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Domain kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), err.Error()))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -215,9 +345,9 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if response.StatusCode != http.StatusOK {
+	if err == nil && response.StatusCode != http.StatusOK {
 		const shortErr = "Error reading Backstage Domain kind"
-		longErr := fmt.Sprintf("Could not read Backstage Domain kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), response.Status)
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Domain kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), response.Status))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -225,7 +355,9 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil {
+	fallback := (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil
+	if fallback {
+		d.metrics.Count("fallbacks_total", 1, map[string]string{"kind": backstage.KindDomain})
 		if state.Fallback.ID.IsNull() {
 			state.Fallback.ID = types.StringValue("123456789")
 		}
@@ -237,7 +369,9 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		}
 		state.ID = state.Fallback.ID
 		state.Name = state.Fallback.Name
-		state.Namespace = state.Fallback.Namespace
+		if !state.Fallback.Namespace.IsNull() {
+			state.ResolvedNamespace = state.Fallback.Namespace
+		}
 		state.ApiVersion = state.Fallback.ApiVersion
 		state.Kind = state.Fallback.Kind
 		state.Metadata = state.Fallback.Metadata
@@ -246,14 +380,18 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	if err == nil && response.StatusCode == http.StatusOK {
+		d.saveLastRead(ctx, backstage.KindDomain, domain.Metadata.Namespace, domain.Metadata.Name, domain)
+		state.QueryResult = d.queryEntity(ctx, state.Query, backstage.KindDomain, domain.Metadata.Namespace, domain.Metadata.Name, &resp.Diagnostics)
 		state.ID = types.StringValue(domain.Metadata.UID)
+		state.ContentHash = entityContentHash(domain)
+		state.Status = entityStatus(domain.Status)
 		state.ApiVersion = types.StringValue(domain.ApiVersion)
 		state.Kind = types.StringValue(domain.Kind)
 
 		for _, i := range domain.Relations {
 			state.Relations = append(state.Relations, entityRelationModel{
 				Type:      types.StringValue(i.Type),
-				TargetRef: types.StringValue(i.TargetRef),
+				TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
 				Target: &entityRelationTargetModel{
 					Kind:      types.StringValue(i.Target.Kind),
 					Name:      types.StringValue(i.Target.Name),
@@ -262,7 +400,7 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		}
 
 		state.Spec = &domainSpecModel{
-			Owner: types.StringValue(domain.Spec.Owner),
+			Owner: d.optionalString(domain.Spec.Owner),
 		}
 
 		state.Metadata = &entityMetadataModel{
@@ -270,8 +408,8 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			Etag:        types.StringValue(domain.Metadata.Etag),
 			Name:        types.StringValue(domain.Metadata.Name),
 			Namespace:   types.StringValue(domain.Metadata.Namespace),
-			Title:       types.StringValue(domain.Metadata.Title),
-			Description: types.StringValue(domain.Metadata.Description),
+			Title:       d.optionalString(domain.Metadata.Title),
+			Description: d.optionalString(domain.Metadata.Description),
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		}
@@ -291,13 +429,39 @@ func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		for _, v := range domain.Metadata.Links {
 			state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
 				URL:   types.StringValue(v.URL),
-				Title: types.StringValue(v.Title),
-				Icon:  types.StringValue(v.Icon),
-				Type:  types.StringValue(v.Type),
+				Title: d.optionalString(v.Title),
+				Icon:  d.optionalString(v.Icon),
+				Type:  d.optionalString(v.Type),
 			})
 		}
 	}
 
+	var owner types.String
+	if state.Spec != nil {
+		owner = state.Spec.Owner
+	}
+	state.ParsedOwner = parseOwnerRef(owner, state.ResolvedNamespace.ValueString())
+	checkExpectedOwner(state.ExpectedOwner, owner, "Domain", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	state.TargetsByType = relationTargetsByType(ctx, state.Relations, &resp.Diagnostics)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	checkApiVersion(requestedApiVersion, state.ApiVersion, "Domain", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{
+		DataSource: "backstage_domain",
+		EntityRef:  fmt.Sprintf("domain:%s/%s", state.ResolvedNamespace.ValueString(), state.Name.ValueString()),
+		Fallback:   fallback,
+	})
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
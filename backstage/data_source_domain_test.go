@@ -19,6 +19,7 @@ func TestAccDataSourceDomain(t *testing.T) {
 						"url:https://github.com/backstage/backstage/tree/master/packages/catalog-model/examples/domains/"),
 					resource.TestCheckResourceAttr("data.backstage_domain.test", "metadata.description", "Everything related to artists"),
 					resource.TestCheckResourceAttr("data.backstage_domain.test", "relations.0.target_ref", "system:default/artist-engagement-portal"),
+					resource.TestCheckResourceAttr("data.backstage_domain.test", "targets_by_type.ownedBy.0", "group:default/team-a"),
 					resource.TestCheckResourceAttr("data.backstage_domain.test", "spec.owner", "team-a"),
 				),
 			},
@@ -56,7 +57,8 @@ func TestAccDataSourceDomain_WithFallback(t *testing.T) {
 					resource.TestCheckResourceAttr("data.backstage_domain.test", "kind", "Domain"),
 					resource.TestCheckResourceAttr("data.backstage_domain.test", "spec.owner", "team-a"),
 					resource.TestCheckResourceAttr("data.backstage_domain.test", "name", "fallback_domain"),
-					resource.TestCheckResourceAttr("data.backstage_domain.test", "namespace", "fallback_default"),
+					resource.TestCheckResourceAttr("data.backstage_domain.test", "namespace", "default"),
+					resource.TestCheckResourceAttr("data.backstage_domain.test", "resolved_namespace", "fallback_default"),
 					resource.TestCheckNoResourceAttr("data.backstage_domain.test", "metadata"),
 					resource.TestCheckNoResourceAttr("data.backstage_domain.test", "relations"),
 				),
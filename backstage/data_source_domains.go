@@ -0,0 +1,263 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &domainsDataSource{}
+	_ datasource.DataSourceWithConfigure = &domainsDataSource{}
+)
+
+// NewDomainsDataSource is a helper function to simplify the provider implementation.
+func NewDomainsDataSource() datasource.DataSource {
+	return &domainsDataSource{}
+}
+
+// domainsDataSource is the data source implementation.
+type domainsDataSource struct {
+	*providerData
+}
+
+type domainsDataSourceModel struct {
+	ID                types.String                `tfsdk:"id"`
+	Namespace         types.String                `tfsdk:"namespace"`
+	Owner             types.String                `tfsdk:"owner"`
+	Tags              []types.String              `tfsdk:"tags"`
+	TagsMatch         types.String                `tfsdk:"tags_match"`
+	Limit             types.Int64                 `tfsdk:"limit"`
+	Offset            types.Int64                 `tfsdk:"offset"`
+	PageSize          types.Int64                 `tfsdk:"page_size"`
+	OrderBy           []entityOrderModel          `tfsdk:"order_by"`
+	NameRegex         types.String                `tfsdk:"name_regex"`
+	TitleRegex        types.String                `tfsdk:"title_regex"`
+	CountOnly         types.Bool                  `tfsdk:"count_only"`
+	RefsOnly          types.Bool                  `tfsdk:"refs_only"`
+	TotalCount        types.Int64                 `tfsdk:"total_count"`
+	Projection        types.String                `tfsdk:"projection"`
+	ProjectionResults []jsontypes.Normalized      `tfsdk:"projection_results"`
+	Refs              []types.String              `tfsdk:"refs"`
+	Domains           []domainsItemModel          `tfsdk:"domains"`
+	EntitiesByRef     map[string]domainsItemModel `tfsdk:"entities_by_ref"`
+}
+
+type domainsItemModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Ref         types.String   `tfsdk:"ref"`
+	Name        types.String   `tfsdk:"name"`
+	Namespace   types.String   `tfsdk:"namespace"`
+	Title       types.String   `tfsdk:"title"`
+	Description types.String   `tfsdk:"description"`
+	Owner       types.String   `tfsdk:"owner"`
+	Tags        []types.String `tfsdk:"tags"`
+}
+
+const (
+	descriptionDomainsNamespace = "Namespace of the domains. If not set, domains of all namespaces are returned."
+	descriptionDomainsTags      = "Tags of the domains, e.g. `java`. If set, only the domains having any of the tags, or all of them as set in " +
+		"`tags_match`, are returned."
+	descriptionDomainsOwner = "An entity reference to the owner of the domains, e.g. `group:default/team-a`. It is normalized the way " +
+		"Backstage does, so `team-a` matches domains owned by `group:default/team-a`."
+	descriptionDomainsDomains    = "Domains sorted by their entity references."
+	descriptionDomainsRef        = "Entity reference to the domain, e.g. `domain:default/playback`."
+	descriptionDomainsDataSource = "Identifier of the list of domains, the catalog filter used to read them."
+)
+
+// Metadata returns the data source type name.
+func (d *domainsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domains"
+}
+
+// Schema defines the schema for the data source.
+func (d *domainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a list of " +
+			"[Domain entities](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-domain) from Backstage Software " +
+			"Catalog, optionally only the domains of an owner. Useful to bootstrap a set of cloud resources per domain.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionDomainsDataSource},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionDomainsNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"owner": schema.StringAttribute{Optional: true, Description: descriptionDomainsOwner, Validators: catalogFilterValidators},
+			"tags": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionDomainsTags, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(catalogFilterValidators...)}},
+			"tags_match": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTagsMatch, Validators: []validator.String{
+				stringvalidator.OneOf(tagsMatchAny, tagsMatchAll),
+			}},
+			"limit": schema.Int64Attribute{Optional: true, Description: descriptionEntityLimit, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"offset": schema.Int64Attribute{Optional: true, Description: descriptionEntityOffset, Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			}},
+			"page_size": schema.Int64Attribute{Optional: true, Description: descriptionEntityPageSize, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"order_by": schema.ListNestedAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderBy, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{Required: true, MarkdownDescription: descriptionEntityOrderByField, Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					}},
+					"direction": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderByDirection, Validators: []validator.String{
+						stringvalidator.OneOf(orderAscending, orderDescending),
+					}},
+				},
+			}},
+			"name_regex":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityNameRegex},
+			"title_regex": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitleRegex},
+			"count_only":  schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCountOnly},
+			"refs_only":   schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityRefsOnly},
+			"total_count": schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionEntityTotalCount},
+			"projection":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityProjection},
+			"projection_results": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntityProjectionResults,
+				ElementType: jsontypes.NormalizedType{}},
+			"refs": schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"domains": schema.ListNestedAttribute{Computed: true, Description: descriptionDomainsDomains, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+					"ref":         schema.StringAttribute{Computed: true, Description: descriptionDomainsRef},
+					"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+					"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+					"owner":       schema.StringAttribute{Computed: true, Description: descriptionDomainSpecOwner},
+					"tags":        schema.ListAttribute{Computed: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+				},
+			}},
+		},
+	}
+
+	// The map holds the items of the list keyed by their references.
+	resp.Schema.Attributes["entities_by_ref"] = schema.MapNestedAttribute{Computed: true, MarkdownDescription: descriptionEntitiesByRef,
+		NestedObject: resp.Schema.Attributes["domains"].(schema.ListNestedAttribute).NestedObject}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *domainsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *domainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state domainsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileEntityRegexp(state.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	titleRegex := compileEntityRegexp(state.TitleRegex, path.Root("title_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := "kind=domain"
+	if !state.Namespace.IsNull() {
+		filter += ",metadata.namespace=" + state.Namespace.ValueString()
+	}
+	// The owner is matched on the ownedBy relation, which holds the normalized reference, unlike spec.owner.
+	if owner := parseOwnerRef(state.Owner, state.Namespace.ValueString()); owner != nil {
+		filter += ",relations.ownedBy=" + owner.Ref.ValueString()
+	}
+	filter += tagsFilter(state.Tags, state.TagsMatch)
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting domains %s from Backstage API", filter))
+	entities, response, err := d.listEntities(ctx, &backstage.ListEntityOptions{
+		Filters: []string{filter},
+		Fields: projectionFields(state.Projection, countFields(state.CountOnly, []string{"kind", "metadata.uid", "metadata.name",
+			"metadata.namespace", "metadata.title", "metadata.description", "metadata.tags", "spec.owner"})),
+		Order: listEntityOrder(state.OrderBy, orderByName),
+	}, state.Limit, state.Offset, state.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage domains",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage domains: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage domains",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage domains: %s", response.Status)))
+		return
+	}
+
+	state.ID = types.StringValue(filter)
+	state.Domains = []domainsItemModel{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		if !matchesEntityRegexps(e.Metadata, nameRegex, titleRegex) {
+			continue
+		}
+		if !matchesTags(e.Metadata.Tags, state.Tags, state.TagsMatch) {
+			continue
+		}
+
+		domain := domainsItemModel{
+			ID:          types.StringValue(e.Metadata.UID),
+			Ref:         types.StringValue(strings.ToLower(fmt.Sprintf("domain:%s/%s", e.Metadata.Namespace, e.Metadata.Name))),
+			Name:        types.StringValue(e.Metadata.Name),
+			Namespace:   types.StringValue(e.Metadata.Namespace),
+			Title:       d.optionalString(e.Metadata.Title),
+			Description: d.optionalString(e.Metadata.Description),
+			Owner:       specString(e.Spec, "owner"),
+			Tags:        []types.String{},
+		}
+
+		for _, t := range e.Metadata.Tags {
+			domain.Tags = append(domain.Tags, types.StringValue(t))
+		}
+
+		state.Domains = append(state.Domains, domain)
+	}
+
+	// Entities ordered by the catalog as set in order_by keep their order.
+	if len(state.OrderBy) == 0 {
+		sort.Slice(state.Domains, func(i, j int) bool { return state.Domains[i].Ref.ValueString() < state.Domains[j].Ref.ValueString() })
+	}
+
+	refs := make([]string, 0, len(state.Domains))
+	for _, dm := range state.Domains {
+		refs = append(refs, dm.Ref.ValueString())
+	}
+	state.Refs = refsSet(refs)
+	state.TotalCount = types.Int64Value(int64(len(state.Refs)))
+	state.ProjectionResults = d.projectEntities(state.Projection, entities, refs, &resp.Diagnostics)
+	state.EntitiesByRef = entitiesByRef(state.Domains, func(i domainsItemModel) types.String { return i.Ref })
+	if state.RefsOnly.ValueBool() || state.CountOnly.ValueBool() {
+		state.Domains, state.EntitiesByRef = []domainsItemModel{}, map[string]domainsItemModel{}
+	}
+	if state.CountOnly.ValueBool() {
+		state.Refs = []types.String{}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_domains", Filters: []string{filter}})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
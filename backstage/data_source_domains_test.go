@@ -0,0 +1,29 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDomains(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceDomainsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_domains.test", "id", "kind=domain,metadata.namespace=default"),
+					resource.TestCheckResourceAttrSet("data.backstage_domains.test", "domains.0.ref"),
+					resource.TestCheckResourceAttrSet("data.backstage_domains.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceDomainsConfig = `
+data "backstage_domains" "test" {
+  namespace = "default"
+}
+`
@@ -2,59 +2,116 @@ package backstage
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/export"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/selector"
 	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
-	_ datasource.DataSource              = &entityDataSource{}
-	_ datasource.DataSourceWithConfigure = &entityDataSource{}
+	_ datasource.DataSource              = &entitiesDataSource{}
+	_ datasource.DataSourceWithConfigure = &entitiesDataSource{}
 )
 
-// NewEntityDataSource is a helper function to simplify the provider implementation.
-func NewEntityDataSource() datasource.DataSource {
-	return &entityDataSource{}
+// NewEntitiesDataSource is a helper function to simplify the provider implementation.
+func NewEntitiesDataSource() datasource.DataSource {
+	return &entitiesDataSource{}
 }
 
-// entityDataSource is the data source implementation.
-type entityDataSource struct {
-	client *backstage.Client
+// entitiesDataSource is the data source implementation.
+type entitiesDataSource struct {
+	*providerData
 }
 
-type entityDataSourceModel struct {
-	ID       types.String         `tfsdk:"id"`
-	Filters  []string             `tfsdk:"filters"`
-	Entities []entityModel        `tfsdk:"entities"`
-	Fallback *entityFallbackModel `tfsdk:"fallback"`
+type entitiesDataSourceModel struct {
+	ID                types.String                 `tfsdk:"id"`
+	ContentHash       types.String                 `tfsdk:"content_hash"`
+	Filters           []string                     `tfsdk:"filters"`
+	Kinds             []string                     `tfsdk:"kinds"`
+	LabelSelector     types.String                 `tfsdk:"label_selector"`
+	Annotations       map[string]string            `tfsdk:"annotations"`
+	FullTextFilter    *entitiesFullTextFilterModel `tfsdk:"full_text_filter"`
+	OrderBy           []entityOrderModel           `tfsdk:"order_by"`
+	Limit             types.Int64                  `tfsdk:"limit"`
+	Offset            types.Int64                  `tfsdk:"offset"`
+	PageSize          types.Int64                  `tfsdk:"page_size"`
+	Sample            types.Int64                  `tfsdk:"sample"`
+	SampleSeed        types.String                 `tfsdk:"sample_seed"`
+	PageCursor        types.String                 `tfsdk:"page_cursor"`
+	NextPageCursor    types.String                 `tfsdk:"next_page_cursor"`
+	AnnotationKeys    []types.String               `tfsdk:"annotation_keys"`
+	Entities          []entityModel                `tfsdk:"entities"`
+	NameRegex         types.String                 `tfsdk:"name_regex"`
+	TitleRegex        types.String                 `tfsdk:"title_regex"`
+	CountOnly         types.Bool                   `tfsdk:"count_only"`
+	RefsOnly          types.Bool                   `tfsdk:"refs_only"`
+	Refs              []types.String               `tfsdk:"refs"`
+	TotalCount        types.Int64                  `tfsdk:"total_count"`
+	Projection        types.String                 `tfsdk:"projection"`
+	ProjectionResults []jsontypes.Normalized       `tfsdk:"projection_results"`
+	EntitiesByRef     map[string]entityModel       `tfsdk:"entities_by_ref"`
+	ReadAs            types.String                 `tfsdk:"read_as"`
+	FilteredOutRefs   []types.String               `tfsdk:"filtered_out_refs"`
+	OutputFile        types.String                 `tfsdk:"output_file"`
+	OutputPath        types.String                 `tfsdk:"output_path"`
+	DistinctField     types.String                 `tfsdk:"distinct_field"`
+	DistinctValues    []types.String               `tfsdk:"distinct_values"`
+	Fallback          *entityFallbackModel         `tfsdk:"fallback"`
+}
+
+type entitiesFullTextFilterModel struct {
+	Term   types.String   `tfsdk:"term"`
+	Fields []types.String `tfsdk:"fields"`
 }
 
 type entityModel struct {
-	ApiVersion types.String          `tfsdk:"api_version"`
-	Spec       jsontypes.Normalized  `tfsdk:"spec"`
-	Kind       types.String          `tfsdk:"kind"`
-	Metadata   *entityMetadataModel  `tfsdk:"metadata"`
-	Relations  []entityRelationModel `tfsdk:"relations"`
+	ApiVersion  types.String          `tfsdk:"api_version"`
+	Spec        jsontypes.Normalized  `tfsdk:"spec"`
+	Kind        types.String          `tfsdk:"kind"`
+	ContentHash types.String          `tfsdk:"content_hash"`
+	IsOrphan    types.Bool            `tfsdk:"is_orphan"`
+	HasErrors   types.Bool            `tfsdk:"has_errors"`
+	Status      *entityStatusModel    `tfsdk:"status"`
+	Metadata    *entityMetadataModel  `tfsdk:"metadata"`
+	Relations   []entityRelationModel `tfsdk:"relations"`
 }
 
 type entityMetadataModel struct {
-	UID         types.String      `tfsdk:"uid"`
-	Etag        types.String      `tfsdk:"etag"`
-	Name        types.String      `tfsdk:"name"`
-	Namespace   types.String      `tfsdk:"namespace"`
-	Title       types.String      `tfsdk:"title"`
-	Description types.String      `tfsdk:"description"`
-	Annotations map[string]string `tfsdk:"annotations"`
-	Labels      map[string]string `tfsdk:"labels"`
-	Tags        []types.String    `tfsdk:"tags"`
-	Links       []entityLinkModel `tfsdk:"links"`
+	UID                  types.String      `tfsdk:"uid"`
+	Etag                 types.String      `tfsdk:"etag"`
+	Name                 types.String      `tfsdk:"name"`
+	Namespace            types.String      `tfsdk:"namespace"`
+	Title                types.String      `tfsdk:"title"`
+	Description          types.String      `tfsdk:"description"`
+	Annotations          map[string]string `tfsdk:"annotations"`
+	SensitiveAnnotations map[string]string `tfsdk:"sensitive_annotations"`
+	Labels               map[string]string `tfsdk:"labels"`
+	Tags                 []types.String    `tfsdk:"tags"`
+	Links                []entityLinkModel `tfsdk:"links"`
 }
 
 type entityRelationModel struct {
@@ -97,12 +154,14 @@ const (
 	descriptionEntityMetadataEtag = "An opaque string that changes for each update operation to any part of the entity, including metadata. This field can not be " +
 		"set by the user at creation time, and the server will reject an attempt to do so. The field will be populated in read operations.The field can (optionally) be " +
 		"specified when performing update or delete operations, and the server will then reject the operation if it does not match the current stored value."
-	descriptionEntityMetadataTitle       = "A display name of the entity, to be presented in user interfaces instead of the name property, when available."
-	descriptionEntityMetadataDescription = "A short (typically relatively few words) description of the entity."
-	descriptionEntityMetadataLabels      = "Key/Value pairs of identifying information attached to the entity."
-	descriptionEntityMetadataAnnotations = "Key/Value pairs of non-identifying auxiliary information attached to entity."
-	descriptionEntityMetadataTags        = "A list of single-valued strings, to for example classify catalog entities in various ways."
-	descriptionEntityMetadataLinks       = "A list of external hyperlinks related to the entity. Links can provide additional contextual information that may be " +
+	descriptionEntityMetadataTitle                = "A display name of the entity, to be presented in user interfaces instead of the name property, when available."
+	descriptionEntityMetadataDescription          = "A short (typically relatively few words) description of the entity."
+	descriptionEntityMetadataLabels               = "Key/Value pairs of identifying information attached to the entity."
+	descriptionEntityMetadataAnnotations          = "Key/Value pairs of non-identifying auxiliary information attached to entity."
+	descriptionEntityMetadataSensitiveAnnotations = "Annotations whose keys are configured as sensitive in `sensitive_annotations` of the provider. " +
+		"They are moved out of `annotations` so their values are not shown in plans."
+	descriptionEntityMetadataTags  = "A list of single-valued strings, to for example classify catalog entities in various ways."
+	descriptionEntityMetadataLinks = "A list of external hyperlinks related to the entity. Links can provide additional contextual information that may be " +
 		"located outside of Backstage itself. For example, an admin dashboard or external CMS page."
 	descriptionEntityLinkURL                 = "URL in a standard uri format."
 	descriptionEntityLinkTitle               = "A user-friendly display name for the link."
@@ -110,34 +169,163 @@ const (
 	descriptionEntityLinkType                = "An optional value to categorize links into specific groups."
 	descriptionEntityRelations               = "Relations that this entity has with other entities"
 	descriptionEntityRelationType            = "Type of the relation."
-	descriptionEntityRelationTargetRef       = "The entity ref of the target of this relation."
+	descriptionEntityRelationTargetRef       = "The entity ref of the target of this relation. Read from Backstage, it is in canonical lower case form, e.g. `group:default/team-a`."
 	descriptionEntityRelationTarget          = "The entity of the target of this relation."
 	descriptionEntityRelationTargetName      = "Name of the entity."
 	descriptionEntityRelationTargetKind      = "The high level entity type being described."
 	descriptionEntityRelationTargetNamespace = "Namespace that the target entity belongs to."
-	descriptionEntityFallback                = "A complete replica of the `Entity` as it would exist in backstage. Set this to provide a fallback in case the Backstage instance is not functioning, is down, or is unrealiable."
+	descriptionEntitiesPageSize              = "Maximum number of entities to read. If set, or if `page_cursor` is set, only a single page of entities is read, " +
+		"allowing large catalogs to be processed in chunks across multiple Terraform runs."
+	descriptionEntitiesPageCursor = "Cursor of the page of entities to read, as returned in `next_page_cursor` of the previous page. The cursor " +
+		"retains the filters of the first page."
+	descriptionEntitiesNextPageCursor = "Cursor of the next page of entities, when reading a single page. Not set, if there are no more entities to read."
+	descriptionEntitiesContentHash    = "A stable hash of the content of all the entities, changing only when content of any of them changes."
+	descriptionEntityIsOrphan         = "Whether the entity is orphaned, i.e. no location emits it anymore, as marked by the `" + annotationOrphan +
+		"` annotation."
+	descriptionEntityHasErrors = "Whether the status of the entity has items of level `error`, e.g. because it failed to be processed."
+	descriptionEntitiesReadAs  = "Whose view of the catalog to read entities with: `" + readAsService + "` reads them with the token of the " +
+		"provider, `" + readAsIdentity + "` reads them with `identity_token` of the provider, so the catalog only returns entities the user is " +
+		"permitted to see. Defaults to `" + readAsService + "`."
+	descriptionEntitiesFilteredOutRefs = "Refs of entities matching the filters that the catalog returns to the provider, but not to the identity, " +
+		"when `read_as` is `" + readAsIdentity + "` and all the entities are read, with no page, limit or offset set. Empty otherwise."
+	descriptionEntitiesFilters     = "A set of conditions that can be used to filter entities. Required, unless `kinds`, `label_selector` or `annotations` is set."
+	descriptionEntitiesAnnotations = "Annotations the entities must have, in addition to `filters`, e.g. `{ \"pagerduty.com/integration-key\" = \"\" }`. " +
+		"Entities must have an annotation of the same value, or any value, if the value is empty."
+	descriptionEntitiesLabelSelector = "A [Kubernetes style label selector](https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors) " +
+		"the labels of the entities must match, in addition to `filters`, e.g. `env in (staging,production),!canary`. Equality, set and " +
+		"existence requirements are matched by the catalog, negated ones on the entities read, so fewer than `limit` entities may be returned."
+	descriptionEntitiesKinds = "Kinds of the entities to read, e.g. `Component`. The entities of each kind matching `filters` are read " +
+		"concurrently and merged into a single list, ordered by name."
+	descriptionEntitiesFullTextFilter = "Free text the entities must match, in addition to `filters`. Matched by the catalog, so only matching " +
+		"entities are read."
+	descriptionEntitiesFullTextFilterTerm   = "Text to match, case insensitively."
+	descriptionEntitiesFullTextFilterFields = "Dot separated paths of the fields to match the text against, e.g. `metadata.name` or `spec.owner`. " +
+		"If not set, the catalog matches it against its default fields."
+	descriptionEntitiesOutputFile = "Path of a local file to write the entities to as newline delimited JSON, one entity per line, instead of " +
+		"writing them to `entities`, so very large exports are not held in the state. The entities are still read into memory before they " +
+		"are written, as `refs`, `content_hash`, `sample` and `projection` are computed from all of them. `refs` and `content_hash` are " +
+		"still set. Like `entities`, the written entities keep only the annotations of `annotation_keys`, if set, and leave out " +
+		"`sensitive_annotations` of the provider. The file is replaced on each read, and is not written when the fallback is used."
+	descriptionEntitiesSample = "Number of the matching entities to pick pseudo-randomly, e.g. to run smoke tests against a representative " +
+		"slice of the catalog. The pick depends only on `sample_seed` and the references of the entities, so it is stable across runs, and " +
+		"adding or removing other entities does not change which of the remaining ones are picked. The picked entities keep their order."
+	descriptionEntitiesSampleSeed    = "Seed of the pick of `sample`. Changing it picks another sample."
+	descriptionEntitiesDistinctField = "Dot separated path of a field, e.g. `spec.type`, to return the distinct values of across the matching " +
+		"entities in `distinct_values`, instead of the entities. `entities`, `entities_by_ref` and `refs` are empty then. The values are read " +
+		"from the facets of the catalog where possible, without reading the entities, in which case `total_count` is not set."
+	descriptionEntitiesDistinctValues = "Distinct values of `distinct_field` across the matching entities, sorted. Values of list fields, e.g. " +
+		"`metadata.tags`, are distinct one by one. Empty if the data source falls back."
+	descriptionEntitiesOutputPath = "Absolute path of the file the entities were written to, if `output_file` is set."
+	descriptionEntityFallback     = "A complete replica of the `Entity` as it would exist in backstage. Set this to provide a fallback in case the Backstage instance is not functioning, is down, or is unrealiable."
 )
 
 // Metadata returns the data source type name.
-func (d *entityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+func (d *entitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
 	resp.TypeName = req.ProviderTypeName + "_entities"
 }
 
 // Schema defines the schema for the data source.
-func (d *entityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+func (d *entitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		MarkdownDescription: "Use this data source to get a filtered list of " +
 			"[entities](https://backstage.io/docs/features/software-catalog/descriptor-format#overall-shape-of-an-entity) from Backstage Software Catalog. For more " +
 			"information about the way filters are defined and applied, see " +
-			"[Backstage documentation](https://backstage.io/docs/features/software-catalog/software-catalog-api#filtering).",
+			"[Backstage documentation](https://backstage.io/docs/features/software-catalog/software-catalog-api#filtering). Entities are read " +
+			"page by page from the query endpoint of the catalog, so catalogs of any size can be read.",
 		Attributes: map[string]schema.Attribute{
-			"id":      schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
-			"filters": schema.ListAttribute{Required: true, Description: descriptionEntityFilters, ElementType: types.StringType},
+			"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntitiesContentHash},
+			"filters": schema.ListAttribute{Optional: true, Description: descriptionEntitiesFilters, ElementType: types.StringType, Validators: []validator.List{
+				listvalidator.AtLeastOneOf(path.MatchRoot("kinds"), path.MatchRoot("label_selector"), path.MatchRoot("annotations")),
+			}},
+			"kinds": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntitiesKinds, ElementType: types.StringType, Validators: []validator.List{
+				listvalidator.SizeAtLeast(1),
+				listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1)),
+				listvalidator.ConflictsWith(path.MatchRoot("page_size"), path.MatchRoot("page_cursor")),
+			}},
+			"label_selector": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntitiesLabelSelector, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"annotations": schema.MapAttribute{Optional: true, MarkdownDescription: descriptionEntitiesAnnotations, ElementType: types.StringType,
+				Validators: []validator.Map{mapvalidator.SizeAtLeast(1), mapvalidator.KeysAre(stringvalidator.LengthAtLeast(1))}},
+			"full_text_filter": schema.SingleNestedAttribute{Optional: true, MarkdownDescription: descriptionEntitiesFullTextFilter, Attributes: map[string]schema.Attribute{
+				"term": schema.StringAttribute{Required: true, Description: descriptionEntitiesFullTextFilterTerm, Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				}},
+				"fields": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntitiesFullTextFilterFields, ElementType: types.StringType},
+			}},
+			"order_by": schema.ListNestedAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderBy, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{Required: true, MarkdownDescription: descriptionEntityOrderByField, Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					}},
+					"direction": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderByDirection, Validators: []validator.String{
+						stringvalidator.OneOf(orderAscending, orderDescending),
+					}},
+				},
+			}},
+			"limit": schema.Int64Attribute{Optional: true, Description: descriptionEntityLimit, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+				int64validator.ConflictsWith(path.MatchRoot("page_size"), path.MatchRoot("page_cursor")),
+			}},
+			"offset": schema.Int64Attribute{Optional: true, Description: descriptionEntityOffset, Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+				int64validator.ConflictsWith(path.MatchRoot("page_size"), path.MatchRoot("page_cursor"), path.MatchRoot("kinds")),
+			}},
+			"page_size": schema.Int64Attribute{Optional: true, Description: descriptionEntitiesPageSize, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"sample": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionEntitiesSample, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+				int64validator.ConflictsWith(path.MatchRoot("page_size"), path.MatchRoot("page_cursor")),
+			}},
+			"sample_seed":      schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntitiesSampleSeed},
+			"page_cursor":      schema.StringAttribute{Optional: true, Description: descriptionEntitiesPageCursor},
+			"next_page_cursor": schema.StringAttribute{Computed: true, Description: descriptionEntitiesNextPageCursor},
+			"annotation_keys":  schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"name_regex":       schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityNameRegex},
+			"title_regex":      schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitleRegex},
+			"count_only":       schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCountOnly},
+			"refs_only":        schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityRefsOnly},
+			"refs":             schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"total_count":      schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionEntityTotalCount},
+			"projection":       schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityProjection},
+			"projection_results": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntityProjectionResults,
+				ElementType: jsontypes.NormalizedType{}},
+			"read_as": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntitiesReadAs, Validators: []validator.String{
+				stringvalidator.OneOf(readAsService, readAsIdentity),
+			}},
+			"output_file": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntitiesOutputFile, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"output_path": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntitiesOutputPath},
+			"distinct_field": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntitiesDistinctField, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"distinct_values":   schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntitiesDistinctValues, ElementType: types.StringType},
+			"filtered_out_refs": schema.SetAttribute{Computed: true, MarkdownDescription: descriptionEntitiesFilteredOutRefs, ElementType: types.StringType},
 			"entities": schema.ListNestedAttribute{Computed: true, Description: descriptionEntitySpec, NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
-					"api_version": schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
-					"spec":        schema.StringAttribute{Computed: true, Description: descriptionEntitySpecJson, CustomType: jsontypes.NormalizedType{}},
-					"kind":        schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+					"api_version":  schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
+					"spec":         schema.StringAttribute{Computed: true, Description: descriptionEntitySpecJson, CustomType: jsontypes.NormalizedType{}},
+					"kind":         schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+					"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+					"is_orphan":    schema.BoolAttribute{Computed: true, Description: descriptionEntityIsOrphan},
+					"has_errors":   schema.BoolAttribute{Computed: true, Description: descriptionEntityHasErrors},
+					"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+						"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+							Attributes: map[string]schema.Attribute{
+								"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+								"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+								"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+								"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+									"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+									"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+									"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+								}},
+							},
+						}},
+					}},
 					"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
 						"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
 						"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
@@ -156,6 +344,8 @@ func (d *entityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 								"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
 							},
 						}},
+						"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+							ElementType: types.StringType},
 					}},
 					"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 						Attributes: map[string]schema.Attribute{
@@ -176,19 +366,38 @@ func (d *entityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				"filters": schema.ListAttribute{Required: true, Description: descriptionEntityFilters, ElementType: types.StringType},
 				"entities": schema.ListNestedAttribute{Optional: true, Description: descriptionEntitySpec, NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
-						"api_version": schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
-						"spec":        schema.StringAttribute{Optional: true, Description: descriptionEntitySpecJson, CustomType: jsontypes.NormalizedType{}},
-						"kind":        schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
+						"api_version":  schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
+						"spec":         schema.StringAttribute{Optional: true, Description: descriptionEntitySpecJson, CustomType: jsontypes.NormalizedType{}},
+						"kind":         schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
+						"content_hash": schema.StringAttribute{Optional: true, Description: descriptionEntityContentHash},
+						"is_orphan":    schema.BoolAttribute{Optional: true, Description: descriptionEntityIsOrphan},
+						"has_errors":   schema.BoolAttribute{Optional: true, Description: descriptionEntityHasErrors},
+						"status": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+							"items": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+								Attributes: map[string]schema.Attribute{
+									"type":    schema.StringAttribute{Optional: true, Description: descriptionEntityStatusItemType},
+									"level":   schema.StringAttribute{Optional: true, Description: descriptionEntityStatusItemLevel},
+									"message": schema.StringAttribute{Optional: true, Description: descriptionEntityStatusItemMessage},
+									"error": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+										"name":    schema.StringAttribute{Optional: true, Description: descriptionEntityStatusItemErrName},
+										"message": schema.StringAttribute{Optional: true, Description: descriptionEntityStatusItemErrMsg},
+										"code":    schema.StringAttribute{Optional: true, Description: descriptionEntityStatusItemErrCode},
+									}},
+								},
+							}},
+						}},
 						"metadata": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
-							"uid":         schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
-							"etag":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
-							"name":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
-							"namespace":   schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
-							"title":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle},
-							"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription},
+							"uid":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
+							"etag":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
+							"name":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
+							"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
+							"title":     schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle, Validators: fallbackTitleValidators},
+							"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription,
+								Validators: fallbackDescriptionValidators},
 							"labels":      schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
 							"annotations": schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
-							"tags":        schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+							"tags": schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType,
+								Validators: fallbackTagsValidators},
 							"links": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
 								Attributes: map[string]schema.Attribute{
 									"url":   schema.StringAttribute{Optional: true, Description: descriptionEntityLinkURL},
@@ -197,6 +406,8 @@ func (d *entityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 									"type":  schema.StringAttribute{Optional: true, Description: descriptionEntityLinkType},
 								},
 							}},
+							"sensitive_annotations": schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+								ElementType: types.StringType},
 						}},
 						"relations": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 							Attributes: map[string]schema.Attribute{
@@ -215,34 +426,102 @@ func (d *entityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 			}},
 		},
 	}
+
+	// The map holds the entities of the list keyed by their references.
+	resp.Schema.Attributes["entities_by_ref"] = schema.MapNestedAttribute{Computed: true, MarkdownDescription: descriptionEntitiesByRef,
+		NestedObject: resp.Schema.Attributes["entities"].(schema.ListNestedAttribute).NestedObject}
 }
 
 // Configure adds the provider configured client to the data source.
-func (d *entityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+func (d *entitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	d.client = req.ProviderData.(*backstage.Client)
+	d.providerData = req.ProviderData.(*providerData)
 }
 
 // Read refreshes the Terraform state with the latest data.
-func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
-	var state entityDataSourceModel
+func (d *entitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entitiesDataSourceModel
 
 	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Getting entities %v from Backstage API", state.Filters))
-	entities, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
-		Filters: state.Filters,
-		Order:   []backstage.ListEntityOrder{{Field: "metadata.name", Direction: "asc"}},
-	})
+	if _, err := selector.Parse(state.LabelSelector.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("label_selector"), "Invalid label selector of Backstage entities", err.Error())
+		return
+	}
+	nameRegex := compileEntityRegexp(state.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	titleRegex := compileEntityRegexp(state.TitleRegex, path.Root("title_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	readCtx := ctx
+	if state.ReadAs.ValueString() == readAsIdentity {
+		if d.identityToken == "" {
+			resp.Diagnostics.AddAttributeError(path.Root("read_as"), "Missing identity token",
+				fmt.Sprintf("Reading entities as `%s` requires `identity_token` of the provider, or the %s environment variable, to be set.",
+					readAsIdentity, envIdentityToken))
+			return
+		}
+		readCtx = d.withIdentity(ctx)
+	}
+	if !state.DistinctField.IsNull() && d.facetsApply(ctx, state) {
+		values, response, err := d.entityFacet(readCtx, state.DistinctField.ValueString(), state.filters())
+		if err == nil && response.StatusCode == http.StatusOK {
+			state.ID = types.StringValue(fmt.Sprint(state.filters()))
+			state.DistinctValues = []types.String{}
+			for _, v := range values {
+				state.DistinctValues = append(state.DistinctValues, types.StringValue(v))
+			}
+			state.Entities, state.EntitiesByRef, state.Refs = []entityModel{}, map[string]entityModel{}, []types.String{}
+			state.FilteredOutRefs = []types.String{}
+
+			d.recordRead(ctx, audit.Entry{DataSource: "backstage_entities", Filters: state.filters()})
+			resp.Diagnostics.Append(resp.State.Set(ctx, state)...)
+			return
+		}
+		tflog.Debug(ctx, fmt.Sprintf("Could not read facet %s of entities %v, reading the entities instead", state.DistinctField.ValueString(),
+			state.filters()))
+	}
+
+	allEntities := state.PageSize.IsNull() && state.PageCursor.IsNull()
+
+	var entities []backstage.Entity
+	var response *http.Response
+	var err error
+	identityRefs := map[string]bool{}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting entities %v from Backstage API", state.filters()))
+	if allEntities && len(state.Kinds) > 1 {
+		entities, response, err = d.readKinds(readCtx, state)
+	} else if allEntities {
+		entities, response, err = d.readAll(readCtx, state)
+	} else {
+		query := url.Values{}
+		if state.PageCursor.IsNull() {
+			query = state.query()
+		} else {
+			query.Set("cursor", state.PageCursor.ValueString())
+		}
+		if !state.PageSize.IsNull() {
+			query.Set("limit", strconv.FormatInt(state.PageSize.ValueInt64(), 10))
+		}
+
+		var page *entitiesQueryResult
+		page, response, err = d.queryEntities(readCtx, query)
+		if page != nil {
+			entities = page.Items
+			state.NextPageCursor = types.StringPointerValue(page.PageInfo.NextCursor)
+		}
+	}
 	if err != nil {
 		const shortErr = "Error reading Backstage entities"
-		longErr := fmt.Sprintf("Could not read Backstage entities %v: %s", state.Filters, err.Error())
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", state.filters(), err.Error()))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -250,27 +529,45 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if response.StatusCode != http.StatusOK {
+	if err == nil && response.StatusCode != http.StatusOK {
 		const shortErr = "Error reading Backstage entities"
-		longErr := fmt.Sprintf("Could not read Backstage entities %v: %s", state.Filters, response.Status)
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", state.filters(), response.Status))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
 		}
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
-	if (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil {
+	fallback := (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil
+	if fallback {
+		d.metrics.Count("fallbacks_total", 1, nil)
 		if state.Fallback.ID.IsNull() {
 			state.Fallback.ID = types.StringValue("123456789")
 		}
 		state.ID = state.Fallback.ID
 		state.Filters = state.Fallback.Filters
 		state.Entities = state.Fallback.Entities
+		for i, e := range state.Entities {
+			if e.IsOrphan.IsNull() && e.Metadata != nil {
+				state.Entities[i].IsOrphan = types.BoolValue(isOrphan(e.Metadata.Annotations))
+			}
+			if e.HasErrors.IsNull() {
+				state.Entities[i].HasErrors = types.BoolValue(false)
+			}
+		}
 	}
 
 	if err == nil && response.StatusCode == http.StatusOK {
-		state.ID = types.StringValue(fmt.Sprint(state.Filters))
+		state.ID = types.StringValue(fmt.Sprint(state.filters()))
 
+		entities = d.filterEntities(entities, state, nameRegex, titleRegex, &resp.Diagnostics)
+		// Entities the identity sees are compared before sampling, which is not applied to the view of the provider.
+		for _, e := range entities {
+			identityRefs[canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)] = true
+		}
+		if !state.Sample.IsNull() {
+			entities = sampleEntities(entities, int(state.Sample.ValueInt64()), state.SampleSeed.ValueString())
+		}
 		for _, e := range entities {
 			v, err := json.Marshal(e.Spec)
 			if err != nil {
@@ -282,15 +579,19 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			}
 
 			entity := entityModel{
-				ApiVersion: types.StringValue(e.ApiVersion),
-				Kind:       types.StringValue(e.Kind),
-				Spec:       jsontypes.NewNormalizedValue(string(v)),
+				ApiVersion:  types.StringValue(e.ApiVersion),
+				Kind:        types.StringValue(e.Kind),
+				ContentHash: entityContentHash(e),
+				IsOrphan:    types.BoolValue(isOrphan(e.Metadata.Annotations)),
+				HasErrors:   types.BoolValue(hasErrors(e.Status)),
+				Status:      entityStatus(e.Status),
+				Spec:        jsontypes.NewNormalizedValue(string(v)),
 			}
 
 			for _, i := range e.Relations {
 				entity.Relations = append(entity.Relations, entityRelationModel{
 					Type:      types.StringValue(i.Type),
-					TargetRef: types.StringValue(i.TargetRef),
+					TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
 					Target: &entityRelationTargetModel{
 						Kind:      types.StringValue(i.Target.Kind),
 						Name:      types.StringValue(i.Target.Name),
@@ -303,8 +604,8 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 				Etag:        types.StringValue(e.Metadata.Etag),
 				Name:        types.StringValue(e.Metadata.Name),
 				Namespace:   types.StringValue(e.Metadata.Namespace),
-				Title:       types.StringValue(e.Metadata.Title),
-				Description: types.StringValue(e.Metadata.Description),
+				Title:       d.optionalString(e.Metadata.Title),
+				Description: d.optionalString(e.Metadata.Description),
 				Annotations: map[string]string{},
 				Labels:      map[string]string{},
 			}
@@ -324,19 +625,498 @@ func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			for _, v := range e.Metadata.Links {
 				entity.Metadata.Links = append(entity.Metadata.Links, entityLinkModel{
 					URL:   types.StringValue(v.URL),
-					Title: types.StringValue(v.Title),
-					Icon:  types.StringValue(v.Icon),
-					Type:  types.StringValue(v.Type),
+					Title: d.optionalString(v.Title),
+					Icon:  d.optionalString(v.Icon),
+					Type:  d.optionalString(v.Type),
 				})
 			}
 
 			state.Entities = append(state.Entities, entity)
 		}
+
+		hashes := make([]string, 0, len(state.Entities))
+		for _, e := range state.Entities {
+			hashes = append(hashes, e.ContentHash.ValueString())
+		}
+		state.ContentHash = contentHash([]byte(strings.Join(hashes, "\n")))
+	}
+
+	for _, e := range state.Entities {
+		filterAnnotations(e.Metadata, state.AnnotationKeys)
+		d.protectAnnotations(e.Metadata)
+		d.checkRelationTypes(e.Relations, &resp.Diagnostics)
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	refs := make([]string, 0, len(state.Entities))
+	for _, e := range state.Entities {
+		if e.Metadata != nil {
+			refs = append(refs, canonicalEntityRef(e.Kind.ValueString(), e.Metadata.Namespace.ValueString(), e.Metadata.Name.ValueString()))
+		}
+	}
+	state.Refs = refsSet(refs)
+	state.TotalCount = types.Int64Value(int64(len(state.Refs)))
+	// Entities read from a failed response are not projected, like those of the fallback.
+	live := entities
+	if fallback {
+		live = nil
+	}
+	state.ProjectionResults = d.projectEntities(state.Projection, live, refs, &resp.Diagnostics)
+	state.EntitiesByRef = map[string]entityModel{}
+	for _, e := range state.Entities {
+		if e.Metadata != nil {
+			state.EntitiesByRef[canonicalEntityRef(e.Kind.ValueString(), e.Metadata.Namespace.ValueString(), e.Metadata.Name.ValueString())] = e
+		}
+	}
+
+	if state.RefsOnly.ValueBool() || state.CountOnly.ValueBool() {
+		state.Entities, state.EntitiesByRef = []entityModel{}, map[string]entityModel{}
+	}
+	if state.CountOnly.ValueBool() {
+		state.Refs = []types.String{}
+	}
+	if !state.DistinctField.IsNull() {
+		state.DistinctValues = []types.String{}
+		if !fallback {
+			state.DistinctValues = distinctValues(entities, state.DistinctField.ValueString())
+		}
+		state.Entities, state.EntitiesByRef, state.Refs = []entityModel{}, map[string]entityModel{}, []types.String{}
+	}
+
+	if !state.OutputFile.IsNull() && !fallback {
+		exported, err := d.exportedEntities(entities, state.AnnotationKeys)
+		var outputPath string
+		if err == nil {
+			outputPath, err = export.WriteNDJSON(state.OutputFile.ValueString(), exported)
+		}
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("output_file"), "Error writing Backstage entities",
+				fmt.Sprintf("Could not write Backstage entities %v to %s: %s", state.filters(), state.OutputFile.ValueString(), err.Error()))
+			return
+		}
+		state.OutputPath = types.StringValue(outputPath)
+		state.Entities = []entityModel{}
+		state.EntitiesByRef = map[string]entityModel{}
+	}
+
+	state.FilteredOutRefs = []types.String{}
+	if state.ReadAs.ValueString() == readAsIdentity && allEntities && state.Limit.IsNull() && state.Offset.IsNull() && state.Sample.IsNull() &&
+		!fallback {
+		state.FilteredOutRefs = d.filteredOutRefs(ctx, state, identityRefs, nameRegex, titleRegex, &resp.Diagnostics)
 	}
 
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_entities", Filters: state.filters(), Fallback: fallback})
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// exportedEntities returns the entities as JSON to write to output_file: with the annotations of the keys only, if set, like the entities
+// written to the state, and without the sensitive annotations of the provider.
+func (d *entitiesDataSource) exportedEntities(entities []backstage.Entity, keys []types.String) ([]json.RawMessage, error) {
+	exported := make([]json.RawMessage, 0, len(entities))
+	for _, e := range entities {
+		if keys != nil {
+			annotations := make(map[string]string, len(keys))
+			for _, k := range keys {
+				if v, ok := e.Metadata.Annotations[k.ValueString()]; ok {
+					annotations[k.ValueString()] = v
+				}
+			}
+			e.Metadata.Annotations = annotations
+		}
+
+		v, err := d.withoutSensitiveAnnotations(e)
+		if err != nil {
+			return nil, err
+		}
+		exported = append(exported, v)
+	}
+
+	return exported, nil
+}
+
+// filters returns the filters of the entities, each restricted to each of the kinds, if kinds are set, and to each combination of the label
+// values required by the label selector.
+func (m *entitiesDataSourceModel) filters() []string {
+	filters := m.Filters
+	if len(m.Kinds) > 0 {
+		filters = make([]string, 0, len(m.Kinds)*len(m.Filters))
+		for _, k := range m.Kinds {
+			if len(m.Filters) == 0 {
+				filters = append(filters, "kind="+k)
+			}
+			for _, f := range m.Filters {
+				filters = append(filters, f+",kind="+k)
+			}
+		}
+	}
+
+	// The catalog can not negate conditions, so only requirements of values and existence of labels are added. The selector was parsed
+	// when the data source was read.
+	requirements, _ := selector.Parse(m.LabelSelector.ValueString())
+	for _, r := range requirements {
+		var conditions []string
+		switch r.Operator {
+		case selector.In:
+			for _, v := range r.Values {
+				conditions = append(conditions, "metadata.labels."+r.Key+"="+v)
+			}
+		case selector.Exists:
+			conditions = []string{"metadata.labels." + r.Key}
+		default:
+			continue
+		}
+
+		if len(filters) == 0 {
+			filters = conditions
+			continue
+		}
+		combined := make([]string, 0, len(filters)*len(conditions))
+		for _, f := range filters {
+			for _, c := range conditions {
+				combined = append(combined, f+","+c)
+			}
+		}
+		filters = combined
+	}
+
+	// Annotations are added in the order of their keys, so the filters, and so the ID, are stable.
+	keys := make([]string, 0, len(m.Annotations))
+	for k := range m.Annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		condition := "metadata.annotations." + k
+		if v := m.Annotations[k]; v != "" {
+			condition += "=" + v
+		}
+
+		if len(filters) == 0 {
+			filters = []string{condition}
+			continue
+		}
+		combined := make([]string, 0, len(filters))
+		for _, f := range filters {
+			combined = append(combined, f+","+condition)
+		}
+		filters = combined
+	}
+
+	return filters
+}
+
+// matchLabels returns the entities whose labels match the label selector, in their order.
+func matchLabels(entities []backstage.Entity, labelSelector types.String) []backstage.Entity {
+	requirements, _ := selector.Parse(labelSelector.ValueString())
+	if len(requirements) == 0 {
+		return entities
+	}
+
+	matched := make([]backstage.Entity, 0, len(entities))
+	for _, e := range entities {
+		matches := true
+		for _, r := range requirements {
+			matches = matches && r.Matches(e.Metadata.Labels)
+		}
+		if matches {
+			matched = append(matched, e)
+		}
+	}
+
+	return matched
+}
+
+// readFields returns the fields to read the entities with. Their labels are read with their count as well, if a label selector is set, as
+// the selector is matched on the entities read.
+func (m *entitiesDataSourceModel) readFields() []string {
+	fields := countFields(m.CountOnly, nil)
+	if len(fields) > 0 && !m.LabelSelector.IsNull() {
+		fields = append(fields, "metadata.labels")
+	}
+
+	return projectionFields(m.Projection, fields)
+}
+
+// query returns the query of the first page of entities matching the filters, in the order set, by name otherwise.
+func (m *entitiesDataSourceModel) query() url.Values {
+	query := url.Values{}
+	query["filter"] = m.filters()
+	for _, o := range listEntityOrder(m.OrderBy, orderByName) {
+		query.Add("orderField", o.Field+","+o.Direction)
+	}
+	if fields := m.readFields(); len(fields) > 0 {
+		query.Set("fields", strings.Join(fields, ","))
+	}
+	if !m.Offset.IsNull() {
+		query.Set("offset", strconv.FormatInt(m.Offset.ValueInt64(), 10))
+	}
+	if m.FullTextFilter != nil {
+		query.Set("fullTextFilterTerm", m.FullTextFilter.Term.ValueString())
+		if len(m.FullTextFilter.Fields) > 0 {
+			fields := make([]string, 0, len(m.FullTextFilter.Fields))
+			for _, f := range m.FullTextFilter.Fields {
+				fields = append(fields, f.ValueString())
+			}
+			query.Set("fullTextFilterFields", strings.Join(fields, ","))
+		}
+	}
+
+	return query
+}
+
+// filterEntities returns the entities allowed by the access policy of the provider that match the label selector and the regular expressions
+// of names and titles of the state.
+func (d *entitiesDataSource) filterEntities(entities []backstage.Entity, state entitiesDataSourceModel, nameRegex, titleRegex *regexp.Regexp,
+	diags *diag.Diagnostics) []backstage.Entity {
+	entities = matchLabels(d.allowedEntities(entities, diags), state.LabelSelector)
+	matched := make([]backstage.Entity, 0, len(entities))
+	for _, e := range entities {
+		if matchesEntityRegexps(e.Metadata, nameRegex, titleRegex) {
+			matched = append(matched, e)
+		}
+	}
+
+	return matched
+}
+
+// filteredOutRefs returns the refs of entities matching the query and the local filters of the state in the view of the provider that are
+// not among the refs read with the identity. A failure to read the view of the provider is reported as a warning, with no refs returned.
+func (d *entitiesDataSource) filteredOutRefs(ctx context.Context, state entitiesDataSourceModel, identityRefs map[string]bool, nameRegex,
+	titleRegex *regexp.Regexp, diags *diag.Diagnostics) []types.String {
+	query := state.query()
+	query.Set("fields", "kind,metadata.namespace,metadata.name,metadata.title,metadata.labels")
+	entities, response, err := d.queryAllEntities(ctx, query, 0)
+	if err == nil && response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%s", response.Status)
+	}
+	if err != nil {
+		diags.AddWarning("Error reading Backstage entities filtered out",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage entities %v with the token of the provider: %s", state.filters(), err.Error())))
+		return []types.String{}
+	}
+
+	// Entities denied by the access policy were read with the identity as well, the warning about them is not repeated.
+	var ignored diag.Diagnostics
+	filtered := []string{}
+	for _, e := range d.filterEntities(entities, state, nameRegex, titleRegex, &ignored) {
+		if ref := canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name); !identityRefs[ref] {
+			filtered = append(filtered, ref)
+		}
+	}
+
+	return refsSet(filtered)
+}
+
+// readAll reads all entities matching the query of the state. Backstage before 1.7 has no query endpoint, so they are read in a single
+// response from the list endpoint of the catalog instead, unless full text is to be matched.
+func (d *entitiesDataSource) readAll(ctx context.Context, state entitiesDataSourceModel) ([]backstage.Entity, *http.Response, error) {
+	entities, response, err := d.queryAllEntities(ctx, state.query(), int(state.Limit.ValueInt64()))
+	if err == nil && response.StatusCode == http.StatusNotFound && state.FullTextFilter == nil {
+		tflog.Debug(ctx, "Query endpoint of Backstage catalog not found, listing entities instead")
+		return d.listEntities(ctx, &backstage.ListEntityOptions{
+			Filters: state.filters(),
+			Fields:  state.readFields(),
+			Order:   listEntityOrder(state.OrderBy, orderByName),
+		}, state.Limit, state.Offset, types.Int64Null())
+	}
+
+	return entities, response, err
+}
+
+// readKinds reads all entities of each of the kinds of the state concurrently, merged into a single list in the order set, by name otherwise.
+// The first failed read of a kind fails the whole read.
+func (d *entitiesDataSource) readKinds(ctx context.Context, state entitiesDataSourceModel) ([]backstage.Entity, *http.Response, error) {
+	type result struct {
+		entities []backstage.Entity
+		response *http.Response
+		err      error
+	}
+
+	results := make([]result, len(state.Kinds))
+	var wg sync.WaitGroup
+	for i, kind := range state.Kinds {
+		kindState := state
+		kindState.Kinds = []string{kind}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			entities, response, err := d.readAll(ctx, kindState)
+			results[i] = result{entities: entities, response: response, err: err}
+		}()
+	}
+	wg.Wait()
+
+	var response *http.Response
+	entities := []backstage.Entity{}
+	seen := map[string]bool{}
+	for _, r := range results {
+		if r.err != nil || r.response.StatusCode != http.StatusOK {
+			return nil, r.response, r.err
+		}
+
+		response = r.response
+		for _, e := range r.entities {
+			if !seen[e.Metadata.UID] {
+				seen[e.Metadata.UID] = true
+				entities = append(entities, e)
+			}
+		}
+	}
+	sortEntities(entities, listEntityOrder(state.OrderBy, orderByName))
+	if !state.Limit.IsNull() && int64(len(entities)) > state.Limit.ValueInt64() {
+		entities = entities[:state.Limit.ValueInt64()]
+	}
+
+	return entities, response, nil
+}
+
+// sampleEntities returns n of the entities picked by the smallest hashes of their references salted with the seed, in their order. Each entity
+// is ranked independently of the others, so the pick is stable when other entities are added or removed.
+func sampleEntities(entities []backstage.Entity, n int, seed string) []backstage.Entity {
+	if len(entities) <= n {
+		return entities
+	}
+
+	ranks := make([]string, len(entities))
+	for i, e := range entities {
+		sum := sha256.Sum256([]byte(seed + "\x00" + canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)))
+		ranks[i] = hex.EncodeToString(sum[:])
+	}
+	sorted := append([]string{}, ranks...)
+	sort.Strings(sorted)
+
+	picked := make([]backstage.Entity, 0, n)
+	for i, e := range entities {
+		if ranks[i] <= sorted[n-1] && len(picked) < n {
+			picked = append(picked, e)
+		}
+	}
+
+	return picked
+}
+
+// sortEntities sorts the entities the way the catalog orders them: by the values of the fields in order of precedence, compared case
+// insensitively, with entities without a field last.
+func sortEntities(entities []backstage.Entity, order []backstage.ListEntityOrder) {
+	values := make(map[string][]*string, len(entities))
+	for _, e := range entities {
+		var raw interface{}
+		if b, err := json.Marshal(e); err == nil {
+			_ = json.Unmarshal(b, &raw)
+		}
+		for _, o := range order {
+			values[e.Metadata.UID] = append(values[e.Metadata.UID], entityFieldValue(raw, o.Field))
+		}
+	}
+
+	sort.SliceStable(entities, func(i, j int) bool {
+		a, b := values[entities[i].Metadata.UID], values[entities[j].Metadata.UID]
+		for k, o := range order {
+			switch {
+			case a[k] == nil && b[k] == nil:
+				continue
+			case a[k] == nil:
+				return false
+			case b[k] == nil:
+				return true
+			case *a[k] == *b[k]:
+				continue
+			case o.Direction == orderDescending:
+				return *a[k] > *b[k]
+			default:
+				return *a[k] < *b[k]
+			}
+		}
+		return false
+	})
+}
+
+// entityFieldValue returns the lower case value of the field at the dot separated path in the raw entity, or nil if it is not set. Keys of
+// maps may contain dots, e.g. annotation keys, so the rest of the path is looked up as a key first.
+func entityFieldValue(raw interface{}, field string) *string {
+	raw = entityField(raw, field)
+	if raw == nil {
+		return nil
+	}
+	value := strings.ToLower(fmt.Sprint(raw))
+	return &value
+}
+
+// entityField returns the value of the field at the dot separated path in the raw entity, or nil if it is not set.
+func entityField(raw interface{}, field string) interface{} {
+	for field != "" {
+		m, ok := raw.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		if v, ok := m[field]; ok {
+			raw, field = v, ""
+			continue
+		}
+		key, rest, _ := strings.Cut(field, ".")
+		raw, field = m[key], rest
+	}
+
+	return raw
+}
+
+// distinctValues returns the distinct values of the field at the dot separated path in the entities, sorted. Values of list fields are
+// distinct one by one.
+func distinctValues(entities []backstage.Entity, field string) []types.String {
+	seen := map[string]bool{}
+	for _, e := range entities {
+		var raw interface{}
+		if b, err := json.Marshal(e); err == nil {
+			_ = json.Unmarshal(b, &raw)
+		}
+
+		items, ok := entityField(raw, field).([]interface{})
+		if !ok {
+			items = []interface{}{entityField(raw, field)}
+		}
+		for _, i := range items {
+			if i != nil {
+				seen[fmt.Sprint(i)] = true
+			}
+		}
+	}
+
+	values := make([]string, 0, len(seen))
+	for v := range seen {
+		values = append(values, v)
+	}
+	sort.Strings(values)
+
+	result := make([]types.String, 0, len(values))
+	for _, v := range values {
+		result = append(result, types.StringValue(v))
+	}
+
+	return result
+}
+
+// facetsApply reports whether the distinct values of distinct_field can be read from the facets of the catalog: only if the catalog
+// supports them, and all the entities matching the filters would be read, with none of them left out by the provider.
+func (d *entitiesDataSource) facetsApply(ctx context.Context, state entitiesDataSourceModel) bool {
+	requirements, _ := selector.Parse(state.LabelSelector.ValueString())
+	for _, r := range requirements {
+		if r.Operator == selector.NotIn || r.Operator == selector.DoesNotExist {
+			return false
+		}
+	}
+	if d.policy != nil || state.FullTextFilter != nil || !state.NameRegex.IsNull() || !state.TitleRegex.IsNull() || !state.Limit.IsNull() ||
+		!state.Offset.IsNull() || !state.Projection.IsNull() || !state.Sample.IsNull() || !state.PageSize.IsNull() || !state.PageCursor.IsNull() {
+		return false
+	}
+
+	caps, err := d.capabilities(ctx)
+	return err == nil && caps.EntityFacets
+}
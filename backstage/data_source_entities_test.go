@@ -2,12 +2,19 @@ package backstage
 
 import (
 	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
 
+	"github.com/h2non/gock"
 	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/go-cty/cty/function/stdlib"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
 )
 
 func TestAccDataSourceEntities(t *testing.T) {
@@ -22,6 +29,11 @@ func TestAccDataSourceEntities(t *testing.T) {
 						"kind=component,metadata.description=Searcher",
 					})),
 					resource.TestCheckResourceAttr("data.backstage_entities.test", "entities.#", "2"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "refs.#", "2"),
+					resource.TestCheckResourceAttrSet("data.backstage_entities.test", "content_hash"),
+					resource.TestCheckResourceAttrSet("data.backstage_entities.test", "entities.0.content_hash"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "entities.0.is_orphan", "false"),
+					resource.TestCheckResourceAttrSet("data.backstage_entities.test", "entities.0.has_errors"),
 					resource.TestCheckTypeSetElemNestedAttrs("data.backstage_entities.test", "entities.*", map[string]string{
 						"kind":                   "Component",
 						"metadata.name":          "searcher",
@@ -58,3 +70,370 @@ data "backstage_entities" "test" {
   ]
 }
 `
+
+func TestAccDataSourceEntities_WithPageSize(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceEntitiesConfigWithPageSize,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "entities.#", "1"),
+					resource.TestCheckResourceAttrSet("data.backstage_entities.test", "next_page_cursor"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceEntitiesConfigWithPageSize = `
+data "backstage_entities" "test" {
+  filters   = ["kind=component"]
+  page_size = 1
+}
+`
+
+func TestAccDataSourceEntities_WithAccessPolicy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					provider "backstage" {
+						access_policy = {
+							denied_kinds = ["group"]
+						}
+					}
+
+					data "backstage_entities" "test" {
+						filters = ["kind=Group"]
+					}
+				`,
+				Check: resource.TestCheckNoResourceAttr("data.backstage_entities.test", "entities.0.kind"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_ReadAsIdentityWithoutToken(t *testing.T) {
+	t.Setenv("BACKSTAGE_IDENTITY_TOKEN", "")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						filters = ["kind=component"]
+						read_as = "identity"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Missing identity token"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_WithFullTextFilter(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						filters = ["kind=component"]
+						full_text_filter = {
+							term   = "artist"
+							fields = ["metadata.name"]
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.backstage_entities.test", "entities.0.metadata.name"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "full_text_filter.term", "artist"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_WithOutputFile(t *testing.T) {
+	outputFile := filepath.Join(t.TempDir(), "entities.ndjson")
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + fmt.Sprintf(`
+					data "backstage_entities" "test" {
+						filters     = ["kind=component"]
+						output_file = %q
+					}
+				`, outputFile),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "output_path", outputFile),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "entities.#", "0"),
+					resource.TestCheckResourceAttrSet("data.backstage_entities.test", "refs.0"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(`
+					provider "backstage" {
+						sensitive_annotations = ["backstage.io/managed-by-location"]
+					}
+
+					data "backstage_entities" "test" {
+						filters     = ["kind=component"]
+						output_file = %q
+					}
+				`, outputFile),
+				Check: func(*terraform.State) error {
+					b, err := os.ReadFile(outputFile)
+					if err != nil {
+						return err
+					}
+					if strings.Contains(string(b), "backstage.io/managed-by-location") {
+						return fmt.Errorf("sensitive annotation written to %s", outputFile)
+					}
+					return nil
+				},
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_WithKinds(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						kinds   = ["Component", "API", "Resource"]
+						filters = ["metadata.namespace=default"]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.backstage_entities.test", "refs.*", "component:default/artist-web"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_entities.test", "refs.*", "api:default/streetlights"),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						kinds     = ["Component", "API"]
+						page_size = 1
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_WithSample(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						filters     = ["kind=component"]
+						sample      = 2
+						sample_seed = "canary"
+					}
+
+					data "backstage_entities" "again" {
+						filters     = ["kind=component"]
+						sample      = 2
+						sample_seed = "canary"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "entities.#", "2"),
+					resource.TestCheckResourceAttrPair("data.backstage_entities.test", "entities.0.metadata.uid",
+						"data.backstage_entities.again", "entities.0.metadata.uid"),
+					resource.TestCheckResourceAttrPair("data.backstage_entities.test", "entities.1.metadata.uid",
+						"data.backstage_entities.again", "entities.1.metadata.uid"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_WithLabelSelector(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						filters        = ["kind=component"]
+						label_selector = "env in (staging,production),!canary"
+					}
+				`,
+				Check: resource.TestCheckResourceAttr("data.backstage_entities.test", "id",
+					"[kind=component,metadata.labels.env=staging kind=component,metadata.labels.env=production]"),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						label_selector = "env in (staging"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid label selector"),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_CountOnlyWithLabelSelector(t *testing.T) {
+	const baseURL = "http://backstage.test"
+	defer gock.Off()
+	// Labels are only answered if requested, so the negated requirement can be matched on the entities read.
+	result := testAccEntitiesQueryResult("component:artist-web", "component:artist-lookup")
+	result["items"].([]map[string]interface{})[0]["metadata"].(map[string]interface{})["labels"] = map[string]string{"env": "staging"}
+	result["items"].([]map[string]interface{})[1]["metadata"].(map[string]interface{})["labels"] = map[string]string{"env": "staging",
+		"canary": "true"}
+	gock.New(baseURL).Persist().
+		Get("/api/catalog/entities/by-query").
+		MatchParam("fields", `metadata\.labels`).
+		Reply(http.StatusOK).
+		JSON(result)
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "backstage" {
+					  base_url = %q
+					}
+
+					data "backstage_entities" "test" {
+					  filters        = ["kind=component"]
+					  label_selector = "env in (staging),!canary"
+					  count_only     = true
+					}
+				`, baseURL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "total_count", "1"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "refs.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_WithAnnotations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						annotations = {
+							"backstage.io/techdocs-ref" = ""
+							"github.com/project-slug"   = "backstage/backstage"
+						}
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "id",
+						"[metadata.annotations.backstage.io/techdocs-ref,metadata.annotations.github.com/project-slug=backstage/backstage]"),
+					resource.TestCheckResourceAttrSet("data.backstage_entities.test", "entities.0.metadata.annotations.backstage.io/techdocs-ref"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_WithDistinctField(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entities" "test" {
+						filters        = ["kind=component"]
+						distinct_field = "spec.type"
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.backstage_entities.test", "distinct_values.*", "website"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "entities.#", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceEntities_ReadAsIdentityFilteredOut(t *testing.T) {
+	const baseURL = "http://backstage.test"
+	defer gock.Off()
+	// The identity sees a single component, the provider another one and a group, which access_policy denies.
+	gock.New(baseURL).Persist().
+		Get("/api/catalog/entities/by-query").
+		MatchHeader("Authorization", "Bearer identity").
+		Reply(http.StatusOK).
+		JSON(testAccEntitiesQueryResult("component:artist-web"))
+	gock.New(baseURL).Persist().
+		Get("/api/catalog/entities/by-query").
+		MatchHeader("Authorization", "Bearer provider").
+		Reply(http.StatusOK).
+		JSON(testAccEntitiesQueryResult("component:artist-web", "component:artist-lookup", "group:team-a"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccDataSourceEntitiesFilteredOutConfig, baseURL, "false"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "refs.#", "1"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "filtered_out_refs.#", "1"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "filtered_out_refs.0", "component:default/artist-lookup"),
+				),
+			},
+			{
+				Config: fmt.Sprintf(testAccDataSourceEntitiesFilteredOutConfig, baseURL, "true"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "refs.#", "0"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "filtered_out_refs.#", "1"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "filtered_out_refs.0", "component:default/artist-lookup"),
+				),
+			},
+		},
+	})
+}
+
+// testAccEntitiesQueryResult returns a response of the query endpoint of the catalog with entities of the kinds and names in the default
+// namespace, given as `<kind>:<name>`.
+func testAccEntitiesQueryResult(entities ...string) map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(entities))
+	for _, e := range entities {
+		kind, name, _ := strings.Cut(e, ":")
+		items = append(items, map[string]interface{}{
+			"apiVersion": "backstage.io/v1alpha1",
+			"kind":       kind,
+			"metadata":   map[string]interface{}{"name": name, "namespace": "default", "uid": name},
+			"spec":       map[string]interface{}{},
+		})
+	}
+
+	return map[string]interface{}{"items": items, "totalItems": len(items), "pageInfo": map[string]interface{}{}}
+}
+
+const testAccDataSourceEntitiesFilteredOutConfig = `
+provider "backstage" {
+  base_url       = %q
+  api_key        = "provider"
+  identity_token = "identity"
+  access_policy = {
+    denied_kinds = ["group"]
+  }
+}
+
+data "backstage_entities" "test" {
+  filters    = ["metadata.namespace=default"]
+  read_as    = "identity"
+  count_only = %s
+}
+`
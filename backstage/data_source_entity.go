@@ -0,0 +1,269 @@
+package backstage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &entityDataSource{}
+	_ datasource.DataSourceWithConfigure = &entityDataSource{}
+)
+
+// NewEntityDataSource is a helper function to simplify the provider implementation.
+func NewEntityDataSource() datasource.DataSource {
+	return &entityDataSource{}
+}
+
+// entityDataSource is the data source implementation.
+type entityDataSource struct {
+	*providerData
+}
+
+type entityDataSourceModel struct {
+	ID             types.String            `tfsdk:"id"`
+	EntityRef      types.String            `tfsdk:"entity_ref"`
+	Ref            types.String            `tfsdk:"ref"`
+	ApiVersion     types.String            `tfsdk:"api_version"`
+	Kind           types.String            `tfsdk:"kind"`
+	ContentHash    types.String            `tfsdk:"content_hash"`
+	Status         *entityStatusModel      `tfsdk:"status"`
+	CatalogURL     types.String            `tfsdk:"catalog_url"`
+	TechDocsURL    types.String            `tfsdk:"techdocs_url"`
+	Metadata       *entityMetadataModel    `tfsdk:"metadata"`
+	Relations      []entityRelationModel   `tfsdk:"relations"`
+	TargetsByType  types.Map               `tfsdk:"targets_by_type"`
+	Spec           jsontypes.Normalized    `tfsdk:"spec"`
+	AnnotationKeys []types.String          `tfsdk:"annotation_keys"`
+	RequestInfo    *entityRequestInfoModel `tfsdk:"request_info"`
+}
+
+const (
+	descriptionEntityEntityRef = "An entity reference to the entity, e.g. `environment:default/production`. The namespace defaults to the default " +
+		"namespace of the provider, the kind must be set."
+	descriptionEntityRef = "Canonical entity reference to the entity, e.g. `environment:default/production`."
+)
+
+// Metadata returns the data source type name.
+func (d *entityDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity"
+}
+
+// Schema defines the schema for the data source.
+func (d *entityDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a specific entity of any kind from Backstage Software Catalog, including kinds " +
+			"added by plugins or custom processors, e.g. `Environment` or `Cluster`. The spec of the entity is returned as JSON.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"entity_ref": schema.StringAttribute{Required: true, Description: descriptionEntityEntityRef, Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityRefWithKind), "must be an entity reference with a kind, e.g. `environment:production`"),
+			}},
+			"ref":          schema.StringAttribute{Computed: true, Description: descriptionEntityRef},
+			"api_version":  schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionEntityApiVersionRequested},
+			"kind":         schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":  schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
+				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
+				"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+				"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+				"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+				"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+				"labels":      schema.MapAttribute{Computed: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
+				"annotations": schema.MapAttribute{Computed: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
+				"tags":        schema.ListAttribute{Computed: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+				"links": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url":   schema.StringAttribute{Computed: true, Description: descriptionEntityLinkURL},
+						"title": schema.StringAttribute{Computed: true, Description: descriptionEntityLinkTitle},
+						"icon":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkIco},
+						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
+					},
+				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
+			}},
+			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"type":       schema.StringAttribute{Computed: true, Description: descriptionEntityRelationType},
+					"target_ref": schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetRef},
+					"target": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRelationTarget,
+						Attributes: map[string]schema.Attribute{
+							"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetName},
+							"kind":      schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetKind},
+							"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetNamespace},
+						}},
+				},
+			}},
+			"targets_by_type": schema.MapAttribute{Computed: true, Description: descriptionEntityTargetsByType,
+				ElementType: types.ListType{ElemType: types.StringType}},
+			"spec":            schema.StringAttribute{Computed: true, Description: descriptionEntitySpecJson, CustomType: jsontypes.NormalizedType{}},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"request_info": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRequestInfo, Attributes: map[string]schema.Attribute{
+				"request_id":  schema.StringAttribute{Computed: true, Description: descriptionEntityRequestInfoRequestID},
+				"status_code": schema.Int64Attribute{Computed: true, Description: descriptionEntityRequestInfoStatusCode},
+				"headers":     schema.MapAttribute{Computed: true, Description: descriptionEntityRequestInfoHeaders, ElementType: types.StringType},
+			}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *entityDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *entityDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entityDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	requestedApiVersion := state.ApiVersion
+	kind, namespace, name := parseEntityRef(state.EntityRef.ValueString(), "", d.defaultNamespace)
+	ref := canonicalEntityRef(kind, namespace, name)
+
+	if !d.checkAccess(kind, namespace, &resp.Diagnostics) {
+		return
+	}
+
+	// The client has typed services for the built-in kinds only, so the entity is read from the catalog API directly.
+	tflog.Debug(ctx, fmt.Sprintf("Getting entity %s from Backstage API", ref))
+	var entity backstage.Entity
+	response, err := d.doJSON(ctx, http.MethodGet, fmt.Sprintf(pathEntityByName, url.PathEscape(strings.ToLower(kind)), url.PathEscape(namespace),
+		url.PathEscape(name)), nil, &entity)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage entity",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage entity %s: %s", ref, err.Error())))
+		return
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		resp.Diagnostics.AddAttributeError(path.Root("entity_ref"), "Backstage entity not found",
+			d.withRequestID(fmt.Sprintf("Backstage entity %s does not exist.", ref)))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage entity",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage entity %s: %s", ref, response.Status)))
+		return
+	}
+
+	spec, err := json.Marshal(entity.Spec)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing Backstage entity spec",
+			fmt.Sprintf("Could not parse spec of Backstage entity %s: %s", ref, err.Error()))
+		return
+	}
+
+	state.RequestInfo = d.requestInfo(ctx, response, &resp.Diagnostics)
+	state.ID = types.StringValue(entity.Metadata.UID)
+	state.Ref = types.StringValue(canonicalEntityRef(entity.Kind, entity.Metadata.Namespace, entity.Metadata.Name))
+	state.ApiVersion = types.StringValue(entity.ApiVersion)
+	state.Kind = types.StringValue(entity.Kind)
+	state.ContentHash = entityContentHash(entity)
+	state.Status = entityStatus(entity.Status)
+	state.Spec = jsontypes.NewNormalizedValue(string(spec))
+
+	for _, i := range entity.Relations {
+		state.Relations = append(state.Relations, entityRelationModel{
+			Type:      types.StringValue(i.Type),
+			TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
+			Target: &entityRelationTargetModel{
+				Kind:      types.StringValue(i.Target.Kind),
+				Name:      types.StringValue(i.Target.Name),
+				Namespace: types.StringValue(i.Target.Namespace)},
+		})
+	}
+
+	state.Metadata = &entityMetadataModel{
+		UID:         types.StringValue(entity.Metadata.UID),
+		Etag:        types.StringValue(entity.Metadata.Etag),
+		Name:        types.StringValue(entity.Metadata.Name),
+		Namespace:   types.StringValue(entity.Metadata.Namespace),
+		Title:       d.optionalString(entity.Metadata.Title),
+		Description: d.optionalString(entity.Metadata.Description),
+		Annotations: map[string]string{},
+		Labels:      map[string]string{},
+	}
+
+	for k, v := range entity.Metadata.Labels {
+		state.Metadata.Labels[k] = v
+	}
+
+	for k, v := range entity.Metadata.Annotations {
+		state.Metadata.Annotations[k] = v
+	}
+
+	for _, v := range entity.Metadata.Tags {
+		state.Metadata.Tags = append(state.Metadata.Tags, types.StringValue(v))
+	}
+
+	for _, v := range entity.Metadata.Links {
+		state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
+			URL:   types.StringValue(v.URL),
+			Title: d.optionalString(v.Title),
+			Icon:  d.optionalString(v.Icon),
+			Type:  d.optionalString(v.Type),
+		})
+	}
+
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	state.TargetsByType = relationTargetsByType(ctx, state.Relations, &resp.Diagnostics)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	checkApiVersion(requestedApiVersion, state.ApiVersion, state.Kind.ValueString(), name, namespace, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_entity", EntityRef: ref})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,222 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &entityErrorsDataSource{}
+	_ datasource.DataSourceWithConfigure = &entityErrorsDataSource{}
+)
+
+// NewEntityErrorsDataSource is a helper function to simplify the provider implementation.
+func NewEntityErrorsDataSource() datasource.DataSource {
+	return &entityErrorsDataSource{}
+}
+
+// entityErrorsDataSource is the data source implementation.
+type entityErrorsDataSource struct {
+	*providerData
+}
+
+type entityErrorsDataSourceModel struct {
+	ID                types.String            `tfsdk:"id"`
+	Kind              types.String            `tfsdk:"kind"`
+	Name              types.String            `tfsdk:"name"`
+	Namespace         types.String            `tfsdk:"namespace"`
+	ResolvedNamespace types.String            `tfsdk:"resolved_namespace"`
+	HasErrors         types.Bool              `tfsdk:"has_errors"`
+	Items             []entityStatusItemModel `tfsdk:"items"`
+}
+
+type entityStatusModel struct {
+	Items []entityStatusItemModel `tfsdk:"items"`
+}
+
+type entityStatusItemModel struct {
+	Type    types.String                `tfsdk:"type"`
+	Level   types.String                `tfsdk:"level"`
+	Message types.String                `tfsdk:"message"`
+	Error   *entityStatusItemErrorModel `tfsdk:"error"`
+}
+
+type entityStatusItemErrorModel struct {
+	Name    types.String `tfsdk:"name"`
+	Message types.String `tfsdk:"message"`
+	Code    types.String `tfsdk:"code"`
+}
+
+const (
+	entityStatusLevelError = "error"
+
+	descriptionEntityErrorsKind      = "Kind of the entity, e.g. `Component`."
+	descriptionEntityErrorsHasErrors = "Whether any status item of the entity has `error` level."
+	descriptionEntityStatus          = "Status of the entity, as claimed by the processors of the catalog. Warnings and errors of ingesting " +
+		"its descriptor are listed in `items`."
+	descriptionEntityStatusItems        = "Status items attached to the entity, including errors that occurred while processing its descriptor."
+	descriptionEntityStatusItemType     = "The item type."
+	descriptionEntityStatusItemLevel    = "The status level / severity of the status item: `info`, `warning` or `error`."
+	descriptionEntityStatusItemMessage  = "A brief message describing the status, intended for human consumption."
+	descriptionEntityStatusItemError    = "Serialized error object related to the status, if any."
+	descriptionEntityStatusItemErrName  = "The type name of the error."
+	descriptionEntityStatusItemErrMsg   = "The message of the error."
+	descriptionEntityStatusItemErrCode  = "An error code associated with the error."
+	descriptionEntityErrorsDataSourceID = "A globally unique ID of the entity."
+)
+
+// Metadata returns the data source type name.
+func (d *entityErrorsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_errors"
+}
+
+// Schema defines the schema for the data source.
+func (d *entityErrorsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the status items of an entity from Backstage Software Catalog, including the errors that " +
+			"occurred while ingesting and processing its descriptor. These are the errors Backstage shows in the UI when ingestion fails, so " +
+			"pipelines can use `has_errors` to block on broken descriptors.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityErrorsDataSourceID},
+			"kind": schema.StringAttribute{Required: true, Description: descriptionEntityErrorsKind, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"has_errors":         schema.BoolAttribute{Computed: true, Description: descriptionEntityErrorsHasErrors},
+			"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+					"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+					"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+					"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+						"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+						"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+					}},
+				},
+			}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *entityErrorsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *entityErrorsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entityErrorsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+
+	if !d.checkAccess(state.Kind.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting status of %s kind %s/%s from Backstage API", state.Kind.ValueString(), state.Name.ValueString(),
+		state.ResolvedNamespace.ValueString()))
+	entities, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: []string{fmt.Sprintf("kind=%s,metadata.namespace=%s,metadata.name=%s", state.Kind.ValueString(), state.ResolvedNamespace.ValueString(),
+			state.Name.ValueString())},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage entity status", d.withRequestID(fmt.Sprintf("Could not read status of Backstage %s kind %s/%s: %s",
+			state.Kind.ValueString(), state.ResolvedNamespace.ValueString(), state.Name.ValueString(), err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage entity status", d.withRequestID(fmt.Sprintf("Could not read status of Backstage %s kind %s/%s: %s",
+			state.Kind.ValueString(), state.ResolvedNamespace.ValueString(), state.Name.ValueString(), response.Status)))
+		return
+	}
+
+	if len(entities) == 0 {
+		resp.Diagnostics.AddError("Backstage entity not found", d.withRequestID(fmt.Sprintf("Backstage %s kind %s/%s does not exist.",
+			state.Kind.ValueString(), state.ResolvedNamespace.ValueString(), state.Name.ValueString())))
+		return
+	}
+
+	entity := entities[0]
+	state.ID = types.StringValue(entity.Metadata.UID)
+	state.Items = flattenEntityStatus(entity.Status)
+	state.HasErrors = types.BoolValue(false)
+	for _, i := range state.Items {
+		if i.Level.ValueString() == entityStatusLevelError {
+			state.HasErrors = types.BoolValue(true)
+		}
+	}
+
+	d.recordRead(ctx, audit.Entry{
+		DataSource: "backstage_entity_errors",
+		EntityRef:  fmt.Sprintf("%s:%s/%s", state.Kind.ValueString(), state.ResolvedNamespace.ValueString(), state.Name.ValueString()),
+	})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// entityStatus converts status of the entity to the data model of the `status` attribute of entity data sources.
+func entityStatus(status *backstage.EntityStatus) *entityStatusModel {
+	return &entityStatusModel{Items: flattenEntityStatus(status)}
+}
+
+// flattenEntityStatus converts status of the entity to its data model.
+func flattenEntityStatus(status *backstage.EntityStatus) []entityStatusItemModel {
+	if status == nil {
+		return nil
+	}
+
+	items := make([]entityStatusItemModel, 0, len(status.Items))
+	for _, i := range status.Items {
+		item := entityStatusItemModel{
+			Type:    types.StringValue(i.Type),
+			Level:   types.StringValue(i.Level),
+			Message: types.StringValue(i.Message),
+		}
+
+		if i.Error != nil {
+			item.Error = &entityStatusItemErrorModel{
+				Name:    types.StringValue(i.Error.Name),
+				Message: types.StringValue(i.Error.Message),
+				Code:    types.StringPointerValue(i.Error.Code),
+			}
+		}
+
+		items = append(items, item)
+	}
+
+	return items
+}
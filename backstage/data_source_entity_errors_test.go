@@ -0,0 +1,45 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEntityErrors(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceEntityErrorsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.backstage_entity_errors.test", "id"),
+					resource.TestCheckResourceAttr("data.backstage_entity_errors.test", "has_errors", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceEntityErrorsConfig = `
+data "backstage_entity_errors" "test" {
+  kind = "System"
+  name = "artist-engagement-portal"
+}
+`
+
+func TestAccDataSourceEntityErrors_MatchesEntityStatus(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceEntityErrorsConfig + `
+					data "backstage_system" "test" {
+						name = "artist-engagement-portal"
+					}
+				`,
+				Check: resource.TestCheckResourceAttrPair("data.backstage_system.test", "status.items.#", "data.backstage_entity_errors.test", "items.#"),
+			},
+		},
+	})
+}
@@ -0,0 +1,196 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &entityReferencersDataSource{}
+	_ datasource.DataSourceWithConfigure = &entityReferencersDataSource{}
+)
+
+// NewEntityReferencersDataSource is a helper function to simplify the provider implementation.
+func NewEntityReferencersDataSource() datasource.DataSource {
+	return &entityReferencersDataSource{}
+}
+
+// entityReferencersDataSource is the data source implementation.
+type entityReferencersDataSource struct {
+	*providerData
+}
+
+type entityReferencersDataSourceModel struct {
+	ID            types.String                `tfsdk:"id"`
+	EntityRef     types.String                `tfsdk:"entity_ref"`
+	RelationTypes []types.String              `tfsdk:"relation_types"`
+	Referencers   []entityReferencerItemModel `tfsdk:"referencers"`
+	Refs          []types.String              `tfsdk:"refs"`
+}
+
+type entityReferencerItemModel struct {
+	Ref           types.String   `tfsdk:"ref"`
+	Kind          types.String   `tfsdk:"kind"`
+	Namespace     types.String   `tfsdk:"namespace"`
+	Name          types.String   `tfsdk:"name"`
+	RelationTypes []types.String `tfsdk:"relation_types"`
+}
+
+const (
+	descriptionEntityReferencersEntityRef = "An entity reference to the referenced entity, e.g. `resource:default/artists-db`. The namespace defaults " +
+		"to `default`, the kind must be set."
+	descriptionEntityReferencersRelationTypes = "Types of the relations to look for, e.g. `dependsOn`. If not set, relations of the well-known types " +
+		"and of the `custom_relation_types` of the provider are looked for."
+	descriptionEntityReferencersReferencers = "Entities having a relation to the referenced entity, sorted by their entity references."
+	descriptionEntityReferencersRef         = "Canonical entity reference to the referencing entity, e.g. `component:default/artist-web`."
+	descriptionEntityReferencersKind        = "Kind of the referencing entity."
+	descriptionEntityReferencersItemTypes   = "Sorted types of the relations of the referencing entity to the referenced entity."
+	descriptionEntityReferencersDataSource  = "Canonical entity reference to the referenced entity."
+)
+
+// Metadata returns the data source type name.
+func (d *entityReferencersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_referencers"
+}
+
+// Schema defines the schema for the data source.
+func (d *entityReferencersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the entities of Backstage Software Catalog having a relation to a given entity, e.g. the " +
+			"components depending on a resource or consuming an API. Useful to assess the impact of decommissioning the entity.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityReferencersDataSource},
+			"entity_ref": schema.StringAttribute{Required: true, Description: descriptionEntityReferencersEntityRef, Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityRefWithKind), "must be an entity reference with a kind, e.g. `resource:artists-db`"),
+			}},
+			"relation_types": schema.ListAttribute{Optional: true, Description: descriptionEntityReferencersRelationTypes, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(catalogFilterValidators...)}},
+			"refs": schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"referencers": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityReferencersReferencers,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"ref":            schema.StringAttribute{Computed: true, Description: descriptionEntityReferencersRef},
+						"kind":           schema.StringAttribute{Computed: true, Description: descriptionEntityReferencersKind},
+						"namespace":      schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+						"name":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+						"relation_types": schema.ListAttribute{Computed: true, Description: descriptionEntityReferencersItemTypes, ElementType: types.StringType},
+					},
+				}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *entityReferencersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *entityReferencersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state entityReferencersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	target := canonicalTargetRef(state.EntityRef.ValueString())
+
+	relationTypes := map[string]bool{}
+	if state.RelationTypes != nil {
+		for _, t := range state.RelationTypes {
+			relationTypes[t.ValueString()] = true
+		}
+	} else {
+		for t := range wellKnownRelationTypes {
+			relationTypes[t] = true
+		}
+		for t := range d.customRelationTypes {
+			relationTypes[t] = true
+		}
+	}
+
+	// Filters are sent as separate filter parameters, which the catalog matches when any of them matches.
+	filters := make([]string, 0, len(relationTypes))
+	for t := range relationTypes {
+		filters = append(filters, fmt.Sprintf("relations.%s=%s", t, target))
+	}
+	sort.Strings(filters)
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting entities referencing %s from Backstage API", target))
+	entities, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: filters,
+		Fields:  []string{"kind", "metadata.name", "metadata.namespace", "relations"},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage entities",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage entities referencing %s: %s", target, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage entities",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage entities referencing %s: %s", target, response.Status)))
+		return
+	}
+
+	state.ID = types.StringValue(target)
+	state.Referencers = []entityReferencerItemModel{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		referencer := entityReferencerItemModel{
+			Ref:           types.StringValue(canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)),
+			Kind:          types.StringValue(e.Kind),
+			Namespace:     types.StringValue(e.Metadata.Namespace),
+			Name:          types.StringValue(e.Metadata.Name),
+			RelationTypes: []types.String{},
+		}
+
+		matched := []string{}
+		for _, r := range e.Relations {
+			if relationTypes[r.Type] && canonicalTargetRef(r.TargetRef) == target {
+				matched = append(matched, r.Type)
+			}
+		}
+		sort.Strings(matched)
+		for _, t := range matched {
+			referencer.RelationTypes = append(referencer.RelationTypes, types.StringValue(t))
+		}
+
+		state.Referencers = append(state.Referencers, referencer)
+	}
+
+	sort.Slice(state.Referencers, func(i, j int) bool {
+		return state.Referencers[i].Ref.ValueString() < state.Referencers[j].Ref.ValueString()
+	})
+
+	refs := make([]string, 0, len(state.Referencers))
+	for _, r := range state.Referencers {
+		refs = append(refs, r.Ref.ValueString())
+	}
+	state.Refs = refsSet(refs)
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_entity_referencers", EntityRef: target, Filters: filters})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEntityReferencers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceEntityReferencersConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entity_referencers.test", "id", "resource:default/artists-db"),
+					resource.TestCheckResourceAttrSet("data.backstage_entity_referencers.test", "referencers.0.ref"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_entity_referencers.test", "referencers.0.relation_types.*", "dependencyOf"),
+					resource.TestCheckResourceAttrSet("data.backstage_entity_referencers.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceEntityReferencersConfig = `
+data "backstage_entity_referencers" "test" {
+  entity_ref     = "resource:artists-db"
+  relation_types = ["dependencyOf"]
+}
+`
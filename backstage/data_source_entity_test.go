@@ -0,0 +1,41 @@
+package backstage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceEntity(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceEntityConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entity.test", "ref", "component:default/artist-web"),
+					resource.TestCheckResourceAttr("data.backstage_entity.test", "request_info.status_code", "200"),
+					resource.TestCheckResourceAttr("data.backstage_entity.test", "kind", "Component"),
+					resource.TestCheckResourceAttrSet("data.backstage_entity.test", "id"),
+					resource.TestCheckResourceAttrSet("data.backstage_entity.test", "spec"),
+					resource.TestCheckResourceAttrSet("data.backstage_entity.test", "relations.#"),
+				),
+			},
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_entity" "test" {
+						entity_ref = "component:non_existent_component_a9ab8"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Backstage entity not found"),
+			},
+		},
+	})
+}
+
+const testAccDataSourceEntityConfig = `
+data "backstage_entity" "test" {
+  entity_ref = "Component:artist-web"
+}
+`
@@ -5,11 +5,17 @@ import (
 	"fmt"
 	"net/http"
 	"regexp"
+	"sort"
+	"strings"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,19 +33,42 @@ func NewGroupDataSource() datasource.DataSource {
 
 // groupDataSource is the data source implementation.
 type groupDataSource struct {
-	client *backstage.Client
+	*providerData
 }
 
 type groupDataSourceModel struct {
-	ID         types.String          `tfsdk:"id"`
-	Name       types.String          `tfsdk:"name"`
-	Namespace  types.String          `tfsdk:"namespace"`
-	ApiVersion types.String          `tfsdk:"api_version"`
-	Kind       types.String          `tfsdk:"kind"`
-	Metadata   *entityMetadataModel  `tfsdk:"metadata"`
-	Relations  []entityRelationModel `tfsdk:"relations"`
-	Spec       *groupSpecModel       `tfsdk:"spec"`
-	Fallback   *groupFallbackModel   `tfsdk:"fallback"`
+	ID                  types.String            `tfsdk:"id"`
+	Name                types.String            `tfsdk:"name"`
+	UID                 types.String            `tfsdk:"uid"`
+	Title               types.String            `tfsdk:"title"`
+	Namespace           types.String            `tfsdk:"namespace"`
+	ResolvedNamespace   types.String            `tfsdk:"resolved_namespace"`
+	ApiVersion          types.String            `tfsdk:"api_version"`
+	Kind                types.String            `tfsdk:"kind"`
+	ContentHash         types.String            `tfsdk:"content_hash"`
+	Status              *entityStatusModel      `tfsdk:"status"`
+	CatalogURL          types.String            `tfsdk:"catalog_url"`
+	TechDocsURL         types.String            `tfsdk:"techdocs_url"`
+	Metadata            *entityMetadataModel    `tfsdk:"metadata"`
+	Relations           []entityRelationModel   `tfsdk:"relations"`
+	TargetsByType       types.Map               `tfsdk:"targets_by_type"`
+	Spec                *groupSpecModel         `tfsdk:"spec"`
+	FlattenMemberGroups types.Bool              `tfsdk:"flatten_member_groups"`
+	MemberUsers         []types.String          `tfsdk:"member_users"`
+	MemberGroups        []types.String          `tfsdk:"member_groups"`
+	AnnotationKeys      []types.String          `tfsdk:"annotation_keys"`
+	Query               types.String            `tfsdk:"query"`
+	QueryResult         jsontypes.Normalized    `tfsdk:"query_result"`
+	FollowMoves         types.Bool              `tfsdk:"follow_moves"`
+	MovedTo             *entityMovedToModel     `tfsdk:"moved_to"`
+	FollowAliases       types.Bool              `tfsdk:"follow_aliases"`
+	AliasedTo           *entityAliasedToModel   `tfsdk:"aliased_to"`
+	RequestInfo         *entityRequestInfoModel `tfsdk:"request_info"`
+	WaitFor             *entityWaitForModel     `tfsdk:"wait_for"`
+	Fallback            *groupFallbackModel     `tfsdk:"fallback"`
+	FallbackFile        types.String            `tfsdk:"fallback_file"`
+	FallbackSources     []types.String          `tfsdk:"fallback_sources"`
+	FallbackSource      types.String            `tfsdk:"fallback_source"`
 }
 
 type groupSpecModel struct {
@@ -76,7 +105,13 @@ const (
 	descriptionGroupSpecParent             = "Parent is the immediate parent group in the hierarchy, if any."
 	descriptionGroupSpecChildren           = "Children contains immediate child groups of this group in the hierarchy (whose parent field points to this group)."
 	descriptionGroupSpecMembers            = "Members contains the users that are members of this group."
-	descriptionGroupFallback               = "A complete replica of the `Group` as it would exist in backstage. Set this to provide a fallback in case the Backstage instance is not functioning, is down, or is unrealiable."
+	descriptionGroupFlattenMemberGroups    = "Whether to set `member_users` and `member_groups`, so modules can either treat the nested groups as members " +
+		"or recurse into them themselves (default: false)."
+	descriptionGroupMemberUsers = "Canonical entity references to the users that are direct members of the group, sorted. Null unless " +
+		"`flatten_member_groups` is set."
+	descriptionGroupMemberGroups = "Canonical entity references to the groups nested in the group at any depth, sorted. Null unless " +
+		"`flatten_member_groups` is set. If the data source falls back, only the children in the relations of `fallback` are returned."
+	descriptionGroupFallback = "A complete replica of the `Group` as it would exist in backstage. Set this to provide a fallback in case the Backstage instance is not functioning, is down, or is unrealiable."
 )
 
 // Metadata returns the data source type name.
@@ -90,14 +125,37 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 		MarkdownDescription: "Use this data source to get a specific " +
 			"[Group entity](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-group) from Backstage Software Catalog.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
-			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+			"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":  schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"name": schema.StringAttribute{Optional: true, Computed: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
 					regexp.MustCompile(patternEntityName),
 					"must follow Backstage format restrictions",
 				),
 			}},
+			"uid": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityUID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("title")),
+				stringvalidator.ConflictsWith(path.MatchRoot("namespace")),
+			}},
+			"title": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitle, Validators: catalogFilterValidators},
 			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
@@ -105,8 +163,9 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"must follow Backstage format restrictions",
 				),
 			}},
-			"api_version": schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
-			"kind":        schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"api_version":        schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionEntityApiVersionRequested},
+			"kind":               schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
 			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
 				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
 				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
@@ -125,6 +184,8 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
 					},
 				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
 			}},
 			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -138,6 +199,8 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 						}},
 				},
 			}},
+			"targets_by_type": schema.MapAttribute{Computed: true, Description: descriptionEntityTargetsByType,
+				ElementType: types.ListType{ElemType: types.StringType}},
 			"spec": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
 				"type":     schema.StringAttribute{Computed: true, Description: descriptionGroupType},
 				"parent":   schema.StringAttribute{Computed: true, Description: descriptionGroupSpecParent},
@@ -149,6 +212,35 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 					"picture":      schema.StringAttribute{Computed: true, Description: descriptionGroupSpecProfilePicture},
 				}},
 			}},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"query":           schema.StringAttribute{Optional: true, Description: descriptionEntityQuery, Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"query_result":    schema.StringAttribute{Computed: true, Description: descriptionEntityQueryResult, CustomType: jsontypes.NormalizedType{}},
+			"follow_moves":    schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowMoves},
+			"moved_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMovedTo, Attributes: map[string]schema.Attribute{
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToNamespace},
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToRef},
+			}},
+			"follow_aliases": schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowAliases},
+			"aliased_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityAliasedTo, Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToName},
+				"ref":  schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToRef},
+			}},
+			"request_info": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRequestInfo, Attributes: map[string]schema.Attribute{
+				"request_id":  schema.StringAttribute{Computed: true, Description: descriptionEntityRequestInfoRequestID},
+				"status_code": schema.Int64Attribute{Computed: true, Description: descriptionEntityRequestInfoStatusCode},
+				"headers":     schema.MapAttribute{Computed: true, Description: descriptionEntityRequestInfoHeaders, ElementType: types.StringType},
+			}},
+			"wait_for": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityWaitFor, Attributes: map[string]schema.Attribute{
+				"previous_etag":   schema.StringAttribute{Optional: true, Description: descriptionEntityWaitForPreviousEtag},
+				"timeout_seconds": schema.Int64Attribute{Optional: true, Description: descriptionEntityWaitForTimeoutSeconds},
+			}},
+			"flatten_member_groups": schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionGroupFlattenMemberGroups},
+			"member_users":          schema.ListAttribute{Computed: true, MarkdownDescription: descriptionGroupMemberUsers, ElementType: types.StringType},
+			"member_groups":         schema.ListAttribute{Computed: true, MarkdownDescription: descriptionGroupMemberGroups, ElementType: types.StringType},
+			"fallback_file":         schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackFile, Validators: fallbackFileValidators},
+			"fallback_sources": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackSources, ElementType: types.StringType,
+				Validators: fallbackSourcesValidators},
+			"fallback_source": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityFallbackSource},
 			"fallback": schema.SingleNestedAttribute{Optional: true, Description: descriptionGroupFallback, Attributes: map[string]schema.Attribute{
 				"id": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
 				"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
@@ -168,15 +260,17 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 				"api_version": schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
 				"kind":        schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
 				"metadata": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
-					"uid":         schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
-					"etag":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
-					"name":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
-					"namespace":   schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
-					"title":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle},
-					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription},
+					"uid":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
+					"etag":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
+					"name":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
+					"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
+					"title":     schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle, Validators: fallbackTitleValidators},
+					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription,
+						Validators: fallbackDescriptionValidators},
 					"labels":      schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
 					"annotations": schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
-					"tags":        schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+					"tags": schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType,
+						Validators: fallbackTagsValidators},
 					"links": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
 						Attributes: map[string]schema.Attribute{
 							"url":   schema.StringAttribute{Optional: true, Description: descriptionEntityLinkURL},
@@ -185,6 +279,8 @@ func (d *groupDataSource) Schema(_ context.Context, _ datasource.SchemaRequest,
 							"type":  schema.StringAttribute{Optional: true, Description: descriptionEntityLinkType},
 						},
 					}},
+					"sensitive_annotations": schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+						ElementType: types.StringType},
 				}},
 				"relations": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -220,7 +316,7 @@ func (d *groupDataSource) Configure(_ context.Context, req datasource.ConfigureR
 		return
 	}
 
-	d.client = req.ProviderData.(*backstage.Client)
+	d.providerData = req.ProviderData.(*providerData)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -232,15 +328,55 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		return
 	}
 
-	if state.Namespace.IsNull() {
-		state.Namespace = types.StringValue(backstage.DefaultNamespaceName)
+	requestedApiVersion := state.ApiVersion
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+	lookupResponse, lookupErr := d.resolveUID(ctx, backstage.KindGroup, state.UID, &state.Name, &state.ResolvedNamespace)
+	if !state.Title.IsNull() {
+		lookupResponse, lookupErr = d.resolveTitle(ctx, backstage.KindGroup, state.Title, state.Namespace, &state.Name, &state.ResolvedNamespace)
+	}
+
+	if !d.checkAccess(backstage.KindGroup, state.ResolvedNamespace.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	if state.Fallback != nil {
+		d.applyFallbackDefaults(backstage.KindGroup, state.Fallback, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Getting Group kind %s/%s from Backstage API", state.Name.ValueString(), state.Namespace.ValueString()))
-	group, response, err := d.client.Catalog.Groups.Get(ctx, state.Name.ValueString(), state.Namespace.ValueString())
+	tflog.Debug(ctx, fmt.Sprintf("Getting Group kind %s/%s from Backstage API", state.Name.ValueString(), state.ResolvedNamespace.ValueString()))
+	group, response, err := waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.GroupEntityV1alpha1, *http.Response, error) {
+		if lookupErr != nil || lookupResponse != nil {
+			return nil, lookupResponse, lookupErr
+		}
+		return d.client.Catalog.Groups.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+	}, func(e *backstage.GroupEntityV1alpha1) string { return e.Metadata.Etag })
+	if movedTo := d.findMove(ctx, state.FollowMoves, backstage.KindGroup, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); movedTo != nil {
+		state.MovedTo = movedTo
+		state.ResolvedNamespace = movedTo.Namespace
+		group, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.GroupEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Groups.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.GroupEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	if aliasedTo := d.findAlias(ctx, state.FollowAliases, backstage.KindGroup, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); aliasedTo != nil {
+		state.AliasedTo = aliasedTo
+		group, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.GroupEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Groups.Get(ctx, aliasedTo.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.GroupEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	state.RequestInfo = d.requestInfo(ctx, response, &resp.Diagnostics)
+	state.FallbackSource = types.StringNull()
+	if err != nil || response.StatusCode != http.StatusOK {
+		state.FallbackSource = d.resolveFallback(ctx, backstage.KindGroup, state.ResolvedNamespace.ValueString(), state.Name.ValueString(), state.FallbackSources,
+			state.FallbackFile, &state.Fallback, &resp.Diagnostics)
+	}
 	if err != nil {
 		const shortErr = "Error reading Backstage Group kind"
-		longErr := fmt.Sprintf("Could not read Backstage Group kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), err.Error())
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Group kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), err.Error()))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -248,16 +384,18 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if response.StatusCode != http.StatusOK {
+	if err == nil && response.StatusCode != http.StatusOK {
 		const shortErr = "Error reading Backstage Group kind"
-		longErr := fmt.Sprintf("Could not read Backstage Group kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), response.Status)
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Group kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), response.Status))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
 		}
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
-	if (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil {
+	fallback := (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil
+	if fallback {
+		d.metrics.Count("fallbacks_total", 1, map[string]string{"kind": backstage.KindGroup})
 		if state.Fallback.ID.IsNull() {
 			state.Fallback.ID = types.StringValue("123456789")
 		}
@@ -269,7 +407,9 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		}
 		state.ID = state.Fallback.ID
 		state.Name = state.Fallback.Name
-		state.Namespace = state.Fallback.Namespace
+		if !state.Fallback.Namespace.IsNull() {
+			state.ResolvedNamespace = state.Fallback.Namespace
+		}
 		state.ApiVersion = state.Fallback.ApiVersion
 		state.Kind = state.Fallback.Kind
 		state.Metadata = state.Fallback.Metadata
@@ -278,15 +418,18 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 	}
 
 	if err == nil && response.StatusCode == http.StatusOK {
-
+		d.saveLastRead(ctx, backstage.KindGroup, group.Metadata.Namespace, group.Metadata.Name, group)
+		state.QueryResult = d.queryEntity(ctx, state.Query, backstage.KindGroup, group.Metadata.Namespace, group.Metadata.Name, &resp.Diagnostics)
 		state.ID = types.StringValue(group.Metadata.UID)
+		state.ContentHash = entityContentHash(group)
+		state.Status = entityStatus(group.Status)
 		state.ApiVersion = types.StringValue(group.ApiVersion)
 		state.Kind = types.StringValue(group.Kind)
 
 		for _, i := range group.Relations {
 			state.Relations = append(state.Relations, entityRelationModel{
 				Type:      types.StringValue(i.Type),
-				TargetRef: types.StringValue(i.TargetRef),
+				TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
 				Target: &entityRelationTargetModel{
 					Kind:      types.StringValue(i.Target.Kind),
 					Name:      types.StringValue(i.Target.Name),
@@ -295,12 +438,12 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		}
 
 		state.Spec = &groupSpecModel{
-			Type:   types.StringValue(group.Spec.Type),
-			Parent: types.StringValue(group.Spec.Parent),
+			Type:   d.optionalString(group.Spec.Type),
+			Parent: d.optionalString(group.Spec.Parent),
 			Profile: &groupSpecProfileModel{
-				DisplayName: types.StringValue(group.Spec.Profile.DisplayName),
-				Email:       types.StringValue(group.Spec.Profile.Email),
-				Picture:     types.StringValue(group.Spec.Profile.Picture),
+				DisplayName: d.optionalString(group.Spec.Profile.DisplayName),
+				Email:       d.optionalString(group.Spec.Profile.Email),
+				Picture:     d.optionalString(group.Spec.Profile.Picture),
 			},
 		}
 
@@ -317,8 +460,8 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 			Etag:        types.StringValue(group.Metadata.Etag),
 			Name:        types.StringValue(group.Metadata.Name),
 			Namespace:   types.StringValue(group.Metadata.Namespace),
-			Title:       types.StringValue(group.Metadata.Title),
-			Description: types.StringValue(group.Metadata.Description),
+			Title:       d.optionalString(group.Metadata.Title),
+			Description: d.optionalString(group.Metadata.Description),
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		}
@@ -338,16 +481,101 @@ func (d *groupDataSource) Read(ctx context.Context, req datasource.ReadRequest,
 		for _, v := range group.Metadata.Links {
 			state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
 				URL:   types.StringValue(v.URL),
-				Title: types.StringValue(v.Title),
-				Icon:  types.StringValue(v.Icon),
-				Type:  types.StringValue(v.Type),
+				Title: d.optionalString(v.Title),
+				Icon:  d.optionalString(v.Icon),
+				Type:  d.optionalString(v.Type),
 			})
 		}
 	}
 
+	if state.FlattenMemberGroups.ValueBool() {
+		d.flattenMembers(ctx, &state, fallback, &resp.Diagnostics)
+	}
+
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	state.TargetsByType = relationTargetsByType(ctx, state.Relations, &resp.Diagnostics)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	checkApiVersion(requestedApiVersion, state.ApiVersion, "Group", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{
+		DataSource: "backstage_group",
+		EntityRef:  fmt.Sprintf("group:%s/%s", state.ResolvedNamespace.ValueString(), state.Name.ValueString()),
+		Fallback:   fallback,
+	})
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
 }
+
+// flattenMembers sets the direct user members and the nested groups of the group. The nested groups are found by following the childOf
+// relations of all groups of the catalog, read in a single request whatever the depth of the hierarchy.
+func (d *groupDataSource) flattenMembers(ctx context.Context, state *groupDataSourceModel, fallback bool, diags *diag.Diagnostics) {
+	users, children := []string{}, []string{}
+	for _, r := range state.Relations {
+		ref := canonicalTargetRef(r.TargetRef.ValueString())
+		switch {
+		case r.Type.ValueString() == relationHasMember && strings.HasPrefix(ref, strings.ToLower(backstage.KindUser)+":"):
+			users = append(users, ref)
+		case r.Type.ValueString() == relationParentOf:
+			children = append(children, ref)
+		}
+	}
+	sort.Strings(users)
+	state.MemberUsers = refsSet(users)
+
+	if fallback {
+		sort.Strings(children)
+		state.MemberGroups = refsSet(children)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting nested groups of group %s/%s from Backstage API", state.ResolvedNamespace.ValueString(),
+		state.Name.ValueString()))
+	groups, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: []string{"kind=group"},
+		Fields:  []string{"kind", "metadata.name", "metadata.namespace", "relations"},
+	})
+	if err == nil && response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%s", response.Status)
+	}
+	if err != nil {
+		diags.AddError("Error reading Backstage groups",
+			d.withRequestID(fmt.Sprintf("Could not read the nested groups of the group: %s", err.Error())))
+		return
+	}
+
+	childrenOf := map[string][]string{}
+	for _, g := range groups {
+		for _, r := range g.Relations {
+			if r.Type == relationChildOf {
+				parent := canonicalTargetRef(r.TargetRef)
+				childrenOf[parent] = append(childrenOf[parent], canonicalEntityRef(backstage.KindGroup, g.Metadata.Namespace, g.Metadata.Name))
+			}
+		}
+	}
+
+	// Groups already found are not followed again, which also stops on cycles in the hierarchy.
+	ref := canonicalEntityRef(backstage.KindGroup, state.ResolvedNamespace.ValueString(), state.Name.ValueString())
+	found, queue, nested := map[string]bool{ref: true}, []string{ref}, []string{}
+	for len(queue) > 0 {
+		parent := queue[0]
+		queue = queue[1:]
+		for _, c := range childrenOf[parent] {
+			if !found[c] {
+				found[c] = true
+				nested = append(nested, c)
+				queue = append(queue, c)
+			}
+		}
+	}
+	sort.Strings(nested)
+	state.MemberGroups = refsSet(nested)
+}
@@ -76,3 +76,23 @@ func TestAccDataSourceGroup_WithFallback(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceGroup_WithFlattenMemberGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_group" "test" {
+						name                  = "backstage"
+						flatten_member_groups = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr("data.backstage_group.test", "member_groups.*", "group:default/team-a"),
+					resource.TestCheckResourceAttrSet("data.backstage_group.test", "member_users.#"),
+				),
+			},
+		},
+	})
+}
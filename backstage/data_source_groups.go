@@ -0,0 +1,312 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &groupsDataSource{}
+	_ datasource.DataSourceWithConfigure = &groupsDataSource{}
+)
+
+// NewGroupsDataSource is a helper function to simplify the provider implementation.
+func NewGroupsDataSource() datasource.DataSource {
+	return &groupsDataSource{}
+}
+
+// groupsDataSource is the data source implementation.
+type groupsDataSource struct {
+	*providerData
+}
+
+type groupsDataSourceModel struct {
+	ID                types.String               `tfsdk:"id"`
+	Namespace         types.String               `tfsdk:"namespace"`
+	Member            types.String               `tfsdk:"member"`
+	DirectOnly        types.Bool                 `tfsdk:"direct_only"`
+	Type              types.String               `tfsdk:"type"`
+	Parent            types.String               `tfsdk:"parent"`
+	Groups            []groupsItemModel          `tfsdk:"groups"`
+	EntitiesByRef     map[string]groupsItemModel `tfsdk:"entities_by_ref"`
+	NameRegex         types.String               `tfsdk:"name_regex"`
+	TitleRegex        types.String               `tfsdk:"title_regex"`
+	CountOnly         types.Bool                 `tfsdk:"count_only"`
+	RefsOnly          types.Bool                 `tfsdk:"refs_only"`
+	TotalCount        types.Int64                `tfsdk:"total_count"`
+	Projection        types.String               `tfsdk:"projection"`
+	ProjectionResults []jsontypes.Normalized     `tfsdk:"projection_results"`
+	Refs              []types.String             `tfsdk:"refs"`
+}
+
+type groupsItemModel struct {
+	ID          types.String `tfsdk:"id"`
+	Ref         types.String `tfsdk:"ref"`
+	Name        types.String `tfsdk:"name"`
+	Namespace   types.String `tfsdk:"namespace"`
+	Title       types.String `tfsdk:"title"`
+	Description types.String `tfsdk:"description"`
+	Type        types.String `tfsdk:"type"`
+	Parent      types.String `tfsdk:"parent"`
+	Direct      types.Bool   `tfsdk:"direct"`
+}
+
+const (
+	relationChildOf   = "childOf"
+	relationParentOf  = "parentOf"
+	relationHasMember = "hasMember"
+
+	descriptionGroupsNamespace = "Namespace of the groups. If not set, groups of all namespaces are returned."
+	descriptionGroupsMember    = "An entity reference to a user, e.g. `user:default/guest`. If set, only the groups the user is a member of, directly " +
+		"or through a child group, are returned. The kind and namespace default to `user` and `default`."
+	descriptionGroupsDirectOnly = "Whether to return only the groups the `member` is a direct member of, leaving out their ancestor groups " +
+		"(default: false)."
+	descriptionGroupsType         = "Type of the groups, e.g. `team` or `business-unit`. If not set, groups of all types are returned."
+	descriptionGroupsParentFilter = "An entity reference to the parent group of the groups, e.g. `group:default/infrastructure`. If set, only " +
+		"the direct children of the group are returned. The kind and namespace default to `group` and `namespace` of the data source, or " +
+		"`default` if it is not set."
+	descriptionGroupsGroups       = "Groups sorted by their entity references."
+	descriptionGroupsRef          = "Entity reference to the group, e.g. `group:default/team-a`."
+	descriptionGroupsParent       = "Entity reference to the parent group, if any."
+	descriptionGroupsDirect       = "Whether the `member` is a direct member of the group. Always false, if `member` is not set."
+	descriptionGroupsDataSourceID = "Identifier of the list of groups."
+)
+
+// Metadata returns the data source type name.
+func (d *groupsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_groups"
+}
+
+// Schema defines the schema for the data source.
+func (d *groupsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a list of " +
+			"[Group entities](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-group) from Backstage Software Catalog, " +
+			"optionally only the groups of a type or parent, or the groups a user is a member of, directly or transitively. Useful to compute " +
+			"effective permissions of a user during provisioning, or to provision resources per team from the organization model.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionGroupsDataSourceID},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionGroupsNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"member": schema.StringAttribute{Optional: true, Description: descriptionGroupsMember, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"direct_only": schema.BoolAttribute{Optional: true, Description: descriptionGroupsDirectOnly},
+			"type":        schema.StringAttribute{Optional: true, Description: descriptionGroupsType, Validators: catalogFilterValidators},
+			"parent": schema.StringAttribute{Optional: true, Description: descriptionGroupsParentFilter, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"name_regex":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityNameRegex},
+			"title_regex": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitleRegex},
+			"count_only":  schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCountOnly},
+			"refs_only":   schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityRefsOnly},
+			"total_count": schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionEntityTotalCount},
+			"projection":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityProjection},
+			"projection_results": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntityProjectionResults,
+				ElementType: jsontypes.NormalizedType{}},
+			"refs": schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"groups": schema.ListNestedAttribute{Computed: true, Description: descriptionGroupsGroups, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+					"ref":         schema.StringAttribute{Computed: true, Description: descriptionGroupsRef},
+					"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+					"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+					"type":        schema.StringAttribute{Computed: true, Description: descriptionGroupType},
+					"parent":      schema.StringAttribute{Computed: true, Description: descriptionGroupsParent},
+					"direct":      schema.BoolAttribute{Computed: true, Description: descriptionGroupsDirect},
+				},
+			}},
+		},
+	}
+
+	// The map holds the items of the list keyed by their references.
+	resp.Schema.Attributes["entities_by_ref"] = schema.MapNestedAttribute{Computed: true, MarkdownDescription: descriptionEntitiesByRef,
+		NestedObject: resp.Schema.Attributes["groups"].(schema.ListNestedAttribute).NestedObject}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *groupsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *groupsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state groupsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileEntityRegexp(state.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	titleRegex := compileEntityRegexp(state.TitleRegex, path.Root("title_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := "kind=group"
+	if !state.Namespace.IsNull() {
+		filter += ",metadata.namespace=" + state.Namespace.ValueString()
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting groups %s from Backstage API", filter))
+	entities, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: []string{filter},
+		Fields: projectionFields(state.Projection, []string{"kind", "metadata.uid", "metadata.name", "metadata.namespace", "metadata.title",
+			"metadata.description", "spec.type", "relations"}),
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage groups", d.withRequestID(fmt.Sprintf("Could not read Backstage groups: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage groups", d.withRequestID(fmt.Sprintf("Could not read Backstage groups: %s", response.Status)))
+		return
+	}
+
+	groups := make(map[string]groupsItemModel, len(entities))
+	members := map[string][]string{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		if !matchesEntityRegexps(e.Metadata, nameRegex, titleRegex) {
+			continue
+		}
+		ref := strings.ToLower(fmt.Sprintf("group:%s/%s", e.Metadata.Namespace, e.Metadata.Name))
+		group := groupsItemModel{
+			ID:          types.StringValue(e.Metadata.UID),
+			Ref:         types.StringValue(ref),
+			Name:        types.StringValue(e.Metadata.Name),
+			Namespace:   types.StringValue(e.Metadata.Namespace),
+			Title:       d.optionalString(e.Metadata.Title),
+			Description: d.optionalString(e.Metadata.Description),
+			Type:        specString(e.Spec, "type"),
+			Direct:      types.BoolValue(false),
+		}
+
+		for _, r := range e.Relations {
+			switch r.Type {
+			case relationChildOf:
+				group.Parent = types.StringValue(strings.ToLower(r.TargetRef))
+			case relationHasMember:
+				members[ref] = append(members[ref], strings.ToLower(r.TargetRef))
+			}
+		}
+
+		groups[ref] = group
+	}
+
+	state.ID = types.StringValue(filter)
+	if !state.Member.IsNull() {
+		kind, namespace, name := parseEntityRef(state.Member.ValueString(), "user", backstage.DefaultNamespaceName)
+		member := strings.ToLower(fmt.Sprintf("%s:%s/%s", kind, namespace, name))
+		groups = memberGroups(groups, members, member, state.DirectOnly.ValueBool())
+		state.ID = types.StringValue(filter + ",member=" + member)
+	}
+
+	// The type and parent are matched after the groups of the member are found, as their ancestors may be of other types and parents.
+	if !state.Type.IsNull() {
+		for ref, group := range groups {
+			if group.Type.ValueString() != state.Type.ValueString() {
+				delete(groups, ref)
+			}
+		}
+		state.ID = types.StringValue(state.ID.ValueString() + ",type=" + state.Type.ValueString())
+	}
+	if !state.Parent.IsNull() {
+		kind, namespace, name := parseEntityRef(state.Parent.ValueString(), "group", state.Namespace.ValueString())
+		parent := canonicalEntityRef(kind, namespace, name)
+		for ref, group := range groups {
+			if group.Parent.ValueString() != parent {
+				delete(groups, ref)
+			}
+		}
+		state.ID = types.StringValue(state.ID.ValueString() + ",parent=" + parent)
+	}
+
+	refs := make([]string, 0, len(groups))
+	for ref := range groups {
+		refs = append(refs, ref)
+	}
+	sort.Strings(refs)
+
+	state.Groups = []groupsItemModel{}
+	for _, ref := range refs {
+		state.Groups = append(state.Groups, groups[ref])
+	}
+
+	state.Refs = refsSet(refs)
+	state.TotalCount = types.Int64Value(int64(len(state.Refs)))
+	state.ProjectionResults = d.projectEntities(state.Projection, entities, refs, &resp.Diagnostics)
+	state.EntitiesByRef = entitiesByRef(state.Groups, func(i groupsItemModel) types.String { return i.Ref })
+	if state.RefsOnly.ValueBool() || state.CountOnly.ValueBool() {
+		state.Groups, state.EntitiesByRef = []groupsItemModel{}, map[string]groupsItemModel{}
+	}
+	if state.CountOnly.ValueBool() {
+		state.Refs = []types.String{}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_groups", EntityRef: state.Member.ValueString(), Filters: []string{filter}})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// memberGroups returns the groups having the member, marked as direct, and unless directOnly is set, their ancestors. Groups are keyed by their
+// lower case entity references, as are their members.
+func memberGroups(groups map[string]groupsItemModel, members map[string][]string, member string, directOnly bool) map[string]groupsItemModel {
+	result := map[string]groupsItemModel{}
+	for ref, m := range members {
+		for _, r := range m {
+			if r == member {
+				group := groups[ref]
+				group.Direct = types.BoolValue(true)
+				result[ref] = group
+			}
+		}
+	}
+
+	if directOnly {
+		return result
+	}
+
+	for ref := range result {
+		// Parents are followed until a group already in the result is reached, which also stops on cycles.
+		for parent := groups[ref].Parent.ValueString(); parent != ""; parent = groups[parent].Parent.ValueString() {
+			if _, ok := result[parent]; ok {
+				break
+			}
+			if _, ok := groups[parent]; !ok {
+				break
+			}
+			result[parent] = groups[parent]
+		}
+	}
+
+	return result
+}
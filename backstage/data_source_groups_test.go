@@ -0,0 +1,80 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceGroups(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceGroupsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_groups.test", "id", "kind=group,metadata.namespace=default"),
+					resource.TestCheckResourceAttrSet("data.backstage_groups.test", "groups.#"),
+					resource.TestCheckResourceAttrSet("data.backstage_groups.test", "groups.0.ref"),
+					resource.TestCheckResourceAttrSet("data.backstage_groups.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceGroupsConfig = `
+data "backstage_groups" "test" {
+  namespace = "default"
+}
+`
+
+func TestAccDataSourceGroups_WithType(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_groups" "test" {
+						namespace = "default"
+						type      = "team"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_groups.test", "id", "kind=group,metadata.namespace=default,type=team"),
+					resource.TestCheckResourceAttr("data.backstage_groups.test", "groups.0.type", "team"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceGroups_WithMember(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_groups" "direct" {
+						member      = "janelle.dawe"
+						direct_only = true
+					}
+
+					data "backstage_groups" "transitive" {
+						member = "user:default/janelle.dawe"
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_groups.direct", "groups.#", "1"),
+					resource.TestCheckResourceAttr("data.backstage_groups.direct", "groups.0.ref", "group:default/team-a"),
+					resource.TestCheckResourceAttr("data.backstage_groups.direct", "groups.0.direct", "true"),
+					resource.TestCheckResourceAttr("data.backstage_groups.transitive", "id", "kind=group,member=user:default/janelle.dawe"),
+					resource.TestCheckResourceAttr("data.backstage_groups.transitive", "groups.0.ref", "group:default/acme-corp"),
+					resource.TestCheckResourceAttr("data.backstage_groups.transitive", "groups.1.ref", "group:default/backstage"),
+					resource.TestCheckResourceAttr("data.backstage_groups.transitive", "groups.1.direct", "false"),
+					resource.TestCheckResourceAttr("data.backstage_groups.transitive", "groups.1.parent", "group:default/infrastructure"),
+				),
+			},
+		},
+	})
+}
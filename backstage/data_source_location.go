@@ -7,9 +7,12 @@ import (
 	"regexp"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,19 +30,39 @@ func NewLocationDataSource() datasource.DataSource {
 
 // locationDataSource is the data source implementation.
 type locationDataSource struct {
-	client *backstage.Client
+	*providerData
 }
 
 type locationDataSourceModel struct {
-	ID         types.String           `tfsdk:"id"`
-	Name       types.String           `tfsdk:"name"`
-	Namespace  types.String           `tfsdk:"namespace"`
-	ApiVersion types.String           `tfsdk:"api_version"`
-	Kind       types.String           `tfsdk:"kind"`
-	Metadata   *entityMetadataModel   `tfsdk:"metadata"`
-	Relations  []entityRelationModel  `tfsdk:"relations"`
-	Spec       *locationSpecModel     `tfsdk:"spec"`
-	Fallback   *locationFallbackModel `tfsdk:"fallback"`
+	ID                types.String            `tfsdk:"id"`
+	Name              types.String            `tfsdk:"name"`
+	UID               types.String            `tfsdk:"uid"`
+	Title             types.String            `tfsdk:"title"`
+	Namespace         types.String            `tfsdk:"namespace"`
+	ResolvedNamespace types.String            `tfsdk:"resolved_namespace"`
+	ApiVersion        types.String            `tfsdk:"api_version"`
+	Kind              types.String            `tfsdk:"kind"`
+	ContentHash       types.String            `tfsdk:"content_hash"`
+	Status            *entityStatusModel      `tfsdk:"status"`
+	CatalogURL        types.String            `tfsdk:"catalog_url"`
+	TechDocsURL       types.String            `tfsdk:"techdocs_url"`
+	Metadata          *entityMetadataModel    `tfsdk:"metadata"`
+	Relations         []entityRelationModel   `tfsdk:"relations"`
+	TargetsByType     types.Map               `tfsdk:"targets_by_type"`
+	Spec              *locationSpecModel      `tfsdk:"spec"`
+	AnnotationKeys    []types.String          `tfsdk:"annotation_keys"`
+	Query             types.String            `tfsdk:"query"`
+	QueryResult       jsontypes.Normalized    `tfsdk:"query_result"`
+	FollowMoves       types.Bool              `tfsdk:"follow_moves"`
+	MovedTo           *entityMovedToModel     `tfsdk:"moved_to"`
+	FollowAliases     types.Bool              `tfsdk:"follow_aliases"`
+	AliasedTo         *entityAliasedToModel   `tfsdk:"aliased_to"`
+	RequestInfo       *entityRequestInfoModel `tfsdk:"request_info"`
+	WaitFor           *entityWaitForModel     `tfsdk:"wait_for"`
+	Fallback          *locationFallbackModel  `tfsdk:"fallback"`
+	FallbackFile      types.String            `tfsdk:"fallback_file"`
+	FallbackSources   []types.String          `tfsdk:"fallback_sources"`
+	FallbackSource    types.String            `tfsdk:"fallback_source"`
 }
 
 type locationSpecModel struct {
@@ -82,14 +105,37 @@ func (d *locationDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 		MarkdownDescription: "Use this data source to get a specific " +
 			"[Location entity](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-location) from Backstage Software Catalog.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
-			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+			"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":  schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"name": schema.StringAttribute{Optional: true, Computed: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
 					regexp.MustCompile(patternEntityName),
 					"must follow Backstage format restrictions",
 				),
 			}},
+			"uid": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityUID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("title")),
+				stringvalidator.ConflictsWith(path.MatchRoot("namespace")),
+			}},
+			"title": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitle, Validators: catalogFilterValidators},
 			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
@@ -97,8 +143,9 @@ func (d *locationDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 					"must follow Backstage format restrictions",
 				),
 			}},
-			"api_version": schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
-			"kind":        schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"api_version":        schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionEntityApiVersionRequested},
+			"kind":               schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
 			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
 				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
 				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
@@ -117,6 +164,8 @@ func (d *locationDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
 					},
 				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
 			}},
 			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -130,12 +179,40 @@ func (d *locationDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 						}},
 				},
 			}},
+			"targets_by_type": schema.MapAttribute{Computed: true, Description: descriptionEntityTargetsByType,
+				ElementType: types.ListType{ElemType: types.StringType}},
 			"spec": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
 				"type":     schema.StringAttribute{Computed: true, Description: descriptionLocationSpecType},
 				"target":   schema.StringAttribute{Computed: true, Description: descriptionLocationSpecTarget},
 				"targets":  schema.ListAttribute{Computed: true, Description: descriptionLocationSpecTargets, ElementType: types.StringType},
 				"presence": schema.StringAttribute{Computed: true, Description: descriptionLocationSpecPresence},
 			}},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"query":           schema.StringAttribute{Optional: true, Description: descriptionEntityQuery, Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"query_result":    schema.StringAttribute{Computed: true, Description: descriptionEntityQueryResult, CustomType: jsontypes.NormalizedType{}},
+			"follow_moves":    schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowMoves},
+			"moved_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMovedTo, Attributes: map[string]schema.Attribute{
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToNamespace},
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToRef},
+			}},
+			"follow_aliases": schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowAliases},
+			"aliased_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityAliasedTo, Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToName},
+				"ref":  schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToRef},
+			}},
+			"request_info": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRequestInfo, Attributes: map[string]schema.Attribute{
+				"request_id":  schema.StringAttribute{Computed: true, Description: descriptionEntityRequestInfoRequestID},
+				"status_code": schema.Int64Attribute{Computed: true, Description: descriptionEntityRequestInfoStatusCode},
+				"headers":     schema.MapAttribute{Computed: true, Description: descriptionEntityRequestInfoHeaders, ElementType: types.StringType},
+			}},
+			"wait_for": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityWaitFor, Attributes: map[string]schema.Attribute{
+				"previous_etag":   schema.StringAttribute{Optional: true, Description: descriptionEntityWaitForPreviousEtag},
+				"timeout_seconds": schema.Int64Attribute{Optional: true, Description: descriptionEntityWaitForTimeoutSeconds},
+			}},
+			"fallback_file": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackFile, Validators: fallbackFileValidators},
+			"fallback_sources": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackSources, ElementType: types.StringType,
+				Validators: fallbackSourcesValidators},
+			"fallback_source": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityFallbackSource},
 			"fallback": schema.SingleNestedAttribute{Optional: true, Description: descriptionLocationFallback, Attributes: map[string]schema.Attribute{
 				"id": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
 				"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
@@ -155,15 +232,17 @@ func (d *locationDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 				"api_version": schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
 				"kind":        schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
 				"metadata": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
-					"uid":         schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
-					"etag":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
-					"name":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
-					"namespace":   schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
-					"title":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle},
-					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription},
+					"uid":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
+					"etag":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
+					"name":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
+					"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
+					"title":     schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle, Validators: fallbackTitleValidators},
+					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription,
+						Validators: fallbackDescriptionValidators},
 					"labels":      schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
 					"annotations": schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
-					"tags":        schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+					"tags": schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType,
+						Validators: fallbackTagsValidators},
 					"links": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
 						Attributes: map[string]schema.Attribute{
 							"url":   schema.StringAttribute{Optional: true, Description: descriptionEntityLinkURL},
@@ -172,6 +251,8 @@ func (d *locationDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 							"type":  schema.StringAttribute{Optional: true, Description: descriptionEntityLinkType},
 						},
 					}},
+					"sensitive_annotations": schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+						ElementType: types.StringType},
 				}},
 				"relations": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -202,7 +283,7 @@ func (d *locationDataSource) Configure(_ context.Context, req datasource.Configu
 		return
 	}
 
-	d.client = req.ProviderData.(*backstage.Client)
+	d.providerData = req.ProviderData.(*providerData)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -214,15 +295,55 @@ func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	if state.Namespace.IsNull() {
-		state.Namespace = types.StringValue(backstage.DefaultNamespaceName)
+	requestedApiVersion := state.ApiVersion
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+	lookupResponse, lookupErr := d.resolveUID(ctx, backstage.KindLocation, state.UID, &state.Name, &state.ResolvedNamespace)
+	if !state.Title.IsNull() {
+		lookupResponse, lookupErr = d.resolveTitle(ctx, backstage.KindLocation, state.Title, state.Namespace, &state.Name, &state.ResolvedNamespace)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Getting Location kind %s/%s from Backstage API", state.Name.ValueString(), state.Namespace.ValueString()))
-	location, response, err := d.client.Catalog.Locations.Get(ctx, state.Name.ValueString(), state.Namespace.ValueString())
+	if !d.checkAccess(backstage.KindLocation, state.ResolvedNamespace.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	if state.Fallback != nil {
+		d.applyFallbackDefaults(backstage.KindLocation, state.Fallback, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting Location kind %s/%s from Backstage API", state.Name.ValueString(), state.ResolvedNamespace.ValueString()))
+	location, response, err := waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.LocationEntityV1alpha1, *http.Response, error) {
+		if lookupErr != nil || lookupResponse != nil {
+			return nil, lookupResponse, lookupErr
+		}
+		return d.client.Catalog.Locations.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+	}, func(e *backstage.LocationEntityV1alpha1) string { return e.Metadata.Etag })
+	if movedTo := d.findMove(ctx, state.FollowMoves, backstage.KindLocation, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); movedTo != nil {
+		state.MovedTo = movedTo
+		state.ResolvedNamespace = movedTo.Namespace
+		location, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.LocationEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Locations.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.LocationEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	if aliasedTo := d.findAlias(ctx, state.FollowAliases, backstage.KindLocation, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); aliasedTo != nil {
+		state.AliasedTo = aliasedTo
+		location, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.LocationEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Locations.Get(ctx, aliasedTo.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.LocationEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	state.RequestInfo = d.requestInfo(ctx, response, &resp.Diagnostics)
+	state.FallbackSource = types.StringNull()
+	if err != nil || response.StatusCode != http.StatusOK {
+		state.FallbackSource = d.resolveFallback(ctx, backstage.KindLocation, state.ResolvedNamespace.ValueString(), state.Name.ValueString(), state.FallbackSources,
+			state.FallbackFile, &state.Fallback, &resp.Diagnostics)
+	}
 	if err != nil {
 		const shortErr = "Error reading Backstage Location kind"
-		longErr := fmt.Sprintf("Could not read Backstage Location kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), err.Error())
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Location kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), err.Error()))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -230,16 +351,18 @@ func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadReques
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if response.StatusCode != http.StatusOK {
+	if err == nil && response.StatusCode != http.StatusOK {
 		const shortErr = "Error reading Backstage Location kind"
-		longErr := fmt.Sprintf("Could not read Backstage Location kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), response.Status)
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Location kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), response.Status))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
 		}
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
-	if (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil {
+	fallback := (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil
+	if fallback {
+		d.metrics.Count("fallbacks_total", 1, map[string]string{"kind": backstage.KindLocation})
 		if state.Fallback.ID.IsNull() {
 			state.Fallback.ID = types.StringValue("123456789")
 		}
@@ -251,7 +374,9 @@ func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadReques
 		}
 		state.ID = state.Fallback.ID
 		state.Name = state.Fallback.Name
-		state.Namespace = state.Fallback.Namespace
+		if !state.Fallback.Namespace.IsNull() {
+			state.ResolvedNamespace = state.Fallback.Namespace
+		}
 		state.ApiVersion = state.Fallback.ApiVersion
 		state.Kind = state.Fallback.Kind
 		state.Metadata = state.Fallback.Metadata
@@ -260,14 +385,18 @@ func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadReques
 	}
 
 	if err == nil && response.StatusCode == http.StatusOK {
+		d.saveLastRead(ctx, backstage.KindLocation, location.Metadata.Namespace, location.Metadata.Name, location)
+		state.QueryResult = d.queryEntity(ctx, state.Query, backstage.KindLocation, location.Metadata.Namespace, location.Metadata.Name, &resp.Diagnostics)
 		state.ID = types.StringValue(location.Metadata.UID)
+		state.ContentHash = entityContentHash(location)
+		state.Status = entityStatus(location.Status)
 		state.ApiVersion = types.StringValue(location.ApiVersion)
 		state.Kind = types.StringValue(location.Kind)
 
 		for _, i := range location.Relations {
 			state.Relations = append(state.Relations, entityRelationModel{
 				Type:      types.StringValue(i.Type),
-				TargetRef: types.StringValue(i.TargetRef),
+				TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
 				Target: &entityRelationTargetModel{
 					Kind:      types.StringValue(i.Target.Kind),
 					Name:      types.StringValue(i.Target.Name),
@@ -276,9 +405,9 @@ func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadReques
 		}
 
 		state.Spec = &locationSpecModel{
-			Type:     types.StringValue(location.Spec.Type),
-			Target:   types.StringValue(location.Spec.Target),
-			Presence: types.StringValue(location.Spec.Presence),
+			Type:     d.optionalString(location.Spec.Type),
+			Target:   d.optionalString(location.Spec.Target),
+			Presence: d.optionalString(location.Spec.Presence),
 		}
 
 		for _, i := range location.Spec.Targets {
@@ -290,8 +419,8 @@ func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadReques
 			Etag:        types.StringValue(location.Metadata.Etag),
 			Name:        types.StringValue(location.Metadata.Name),
 			Namespace:   types.StringValue(location.Metadata.Namespace),
-			Title:       types.StringValue(location.Metadata.Title),
-			Description: types.StringValue(location.Metadata.Description),
+			Title:       d.optionalString(location.Metadata.Title),
+			Description: d.optionalString(location.Metadata.Description),
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		}
@@ -311,13 +440,29 @@ func (d *locationDataSource) Read(ctx context.Context, req datasource.ReadReques
 		for _, v := range location.Metadata.Links {
 			state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
 				URL:   types.StringValue(v.URL),
-				Title: types.StringValue(v.Title),
-				Icon:  types.StringValue(v.Icon),
-				Type:  types.StringValue(v.Type),
+				Title: d.optionalString(v.Title),
+				Icon:  d.optionalString(v.Icon),
+				Type:  d.optionalString(v.Type),
 			})
 		}
 	}
 
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	state.TargetsByType = relationTargetsByType(ctx, state.Relations, &resp.Diagnostics)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	checkApiVersion(requestedApiVersion, state.ApiVersion, "Location", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{
+		DataSource: "backstage_location",
+		EntityRef:  fmt.Sprintf("location:%s/%s", state.ResolvedNamespace.ValueString(), state.Name.ValueString()),
+		Fallback:   fallback,
+	})
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
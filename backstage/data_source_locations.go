@@ -0,0 +1,169 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &locationsDataSource{}
+	_ datasource.DataSourceWithConfigure = &locationsDataSource{}
+)
+
+// NewLocationsDataSource is a helper function to simplify the provider implementation.
+func NewLocationsDataSource() datasource.DataSource {
+	return &locationsDataSource{}
+}
+
+// locationsDataSource is the data source implementation.
+type locationsDataSource struct {
+	*providerData
+}
+
+type locationsDataSourceModel struct {
+	ID           types.String         `tfsdk:"id"`
+	Type         types.String         `tfsdk:"type"`
+	TargetPrefix types.String         `tfsdk:"target_prefix"`
+	Locations    []locationsItemModel `tfsdk:"locations"`
+	Targets      []types.String       `tfsdk:"targets"`
+}
+
+type locationsItemModel struct {
+	ID     types.String `tfsdk:"id"`
+	Type   types.String `tfsdk:"type"`
+	Target types.String `tfsdk:"target"`
+}
+
+const (
+	descriptionLocationsType         = "Type of the locations, e.g. `url` or `file`. If not set, locations of all types are returned."
+	descriptionLocationsTargetPrefix = "Prefix of the targets of the locations, e.g. `https://github.com/acme/`. If not set, locations of all " +
+		"targets are returned."
+	descriptionLocationsLocations    = "Locations registered in the catalog, sorted by their targets."
+	descriptionLocationsLocationType = "Type of the location, e.g. `url`."
+	descriptionLocationsTarget       = "Target of the location, e.g. an URL of a descriptor file."
+	descriptionLocationsTargets      = "Targets of the locations as a set, to compare them with the targets of the `backstage_location` resources " +
+		"of the configuration, e.g. with `setsubtract`."
+	descriptionLocationsDataSource = "Identifier of the list of locations, the filters used to read them."
+)
+
+// Metadata returns the data source type name.
+func (d *locationsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_locations"
+}
+
+// Schema defines the schema for the data source.
+func (d *locationsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a list of the locations registered in Backstage Software Catalog, optionally only the " +
+			"locations of a type or under a target prefix. Useful to find registrations not managed by `backstage_location` resources.",
+		Attributes: map[string]schema.Attribute{
+			"id":   schema.StringAttribute{Computed: true, Description: descriptionLocationsDataSource},
+			"type": schema.StringAttribute{Optional: true, Description: descriptionLocationsType, Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"target_prefix": schema.StringAttribute{Optional: true, Description: descriptionLocationsTargetPrefix, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"targets": schema.SetAttribute{Computed: true, Description: descriptionLocationsTargets, ElementType: types.StringType},
+			"locations": schema.ListNestedAttribute{Computed: true, Description: descriptionLocationsLocations, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":     schema.StringAttribute{Computed: true, Description: descriptionLocationID},
+					"type":   schema.StringAttribute{Computed: true, Description: descriptionLocationsLocationType},
+					"target": schema.StringAttribute{Computed: true, Description: descriptionLocationsTarget},
+				},
+			}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *locationsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *locationsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state locationsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The locations endpoint does not support filters, so they are applied to the returned locations.
+	filters := []string{}
+	if !state.Type.IsNull() {
+		filters = append(filters, "type="+state.Type.ValueString())
+	}
+	if !state.TargetPrefix.IsNull() {
+		filters = append(filters, "target_prefix="+state.TargetPrefix.ValueString())
+	}
+
+	tflog.Debug(ctx, "Getting locations from Backstage API")
+	locations, response, err := d.client.Catalog.Locations.List(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage locations",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage locations: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage locations",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage locations: %s", response.Status)))
+		return
+	}
+
+	state.ID = types.StringValue(strings.Join(filters, ","))
+	state.Locations = []locationsItemModel{}
+	for _, l := range locations {
+		if l.Data == nil {
+			continue
+		}
+		if !state.Type.IsNull() && l.Data.Type != state.Type.ValueString() {
+			continue
+		}
+		if !state.TargetPrefix.IsNull() && !strings.HasPrefix(l.Data.Target, state.TargetPrefix.ValueString()) {
+			continue
+		}
+
+		state.Locations = append(state.Locations, locationsItemModel{
+			ID:     types.StringValue(l.Data.ID),
+			Type:   types.StringValue(l.Data.Type),
+			Target: types.StringValue(l.Data.Target),
+		})
+	}
+
+	sort.Slice(state.Locations, func(i, j int) bool {
+		return state.Locations[i].Target.ValueString() < state.Locations[j].Target.ValueString()
+	})
+
+	state.Targets = []types.String{}
+	seen := map[string]bool{}
+	for _, l := range state.Locations {
+		if target := l.Target.ValueString(); !seen[target] {
+			seen[target] = true
+			state.Targets = append(state.Targets, l.Target)
+		}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_locations", Filters: filters})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceLocations(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceLocationsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_locations.test", "id", "type=url,target_prefix=https://github.com/"),
+					resource.TestCheckResourceAttr("data.backstage_locations.test", "locations.0.type", "url"),
+					resource.TestCheckResourceAttrSet("data.backstage_locations.test", "locations.0.id"),
+					resource.TestCheckResourceAttrSet("data.backstage_locations.test", "targets.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceLocationsConfig = `
+data "backstage_locations" "test" {
+  type          = "url"
+  target_prefix = "https://github.com/"
+}
+`
@@ -0,0 +1,104 @@
+package backstage
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var (
+	_ datasource.DataSource              = &providerInfoDataSource{}
+	_ datasource.DataSourceWithConfigure = &providerInfoDataSource{}
+)
+
+// NewProviderInfoDataSource is a helper function to simplify the provider implementation.
+func NewProviderInfoDataSource() datasource.DataSource {
+	return &providerInfoDataSource{}
+}
+
+// providerInfoDataSource is the data source implementation.
+type providerInfoDataSource struct {
+	*providerData
+}
+
+type providerInfoDataSourceModel struct {
+	ID           types.String                   `tfsdk:"id"`
+	Version      types.String                   `tfsdk:"version"`
+	Deprecations []providerInfoDeprecationModel `tfsdk:"deprecations"`
+}
+
+type providerInfoDeprecationModel struct {
+	Address types.String `tfsdk:"address"`
+	Message types.String `tfsdk:"message"`
+	InUse   types.Bool   `tfsdk:"in_use"`
+}
+
+const (
+	descriptionProviderInfoID           = "Identifier of the information, constant for a version of the provider."
+	descriptionProviderInfoVersion      = "Version of the provider, e.g. `1.2.0`."
+	descriptionProviderInfoDeprecations = "Active deprecations of attributes of the provider, its data sources and resources, sorted by address."
+	descriptionProviderInfoAddress      = "The deprecated attribute, prefixed with the type name of its data source or resource, or with " +
+		"`provider`, e.g. `provider.legacy_empty_strings`."
+	descriptionProviderInfoMessage = "What to migrate to. Terraform shows it as warning when the attribute is configured."
+	descriptionProviderInfoInUse   = "Whether the configuration of the provider sets the attribute. Always false for attributes of data sources " +
+		"and resources, whose uses Terraform warns about when planning them."
+)
+
+// Metadata returns the data source type name.
+func (d *providerInfoDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_provider_info"
+}
+
+// Schema defines the schema for the data source.
+func (d *providerInfoDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the version of the provider and its active deprecations, so configurations needing " +
+			"migration can be inventoried before upgrading to a major version. It does not read from the Backstage instance.",
+		Attributes: map[string]schema.Attribute{
+			"id":      schema.StringAttribute{Computed: true, Description: descriptionProviderInfoID},
+			"version": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionProviderInfoVersion},
+			"deprecations": schema.ListNestedAttribute{Computed: true, Description: descriptionProviderInfoDeprecations,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"address": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionProviderInfoAddress},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionProviderInfoMessage},
+						"in_use":  schema.BoolAttribute{Computed: true, Description: descriptionProviderInfoInUse},
+					},
+				}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *providerInfoDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *providerInfoDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	state := providerInfoDataSourceModel{
+		ID:           types.StringValue("provider_info:" + d.version),
+		Version:      types.StringValue(d.version),
+		Deprecations: []providerInfoDeprecationModel{},
+	}
+
+	for _, dep := range deprecations {
+		state.Deprecations = append(state.Deprecations, providerInfoDeprecationModel{
+			Address: types.StringValue(dep.address),
+			Message: types.StringValue(dep.message),
+			InUse:   types.BoolValue(d.deprecationsInUse[dep.address]),
+		})
+	}
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,37 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceProviderInfo(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceProviderInfoConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_provider_info.test", "version", "test"),
+					resource.TestCheckResourceAttr("data.backstage_provider_info.test", "deprecations.0.address", "provider.legacy_empty_strings"),
+					resource.TestCheckResourceAttr("data.backstage_provider_info.test", "deprecations.0.in_use", "false"),
+				),
+			},
+			{
+				Config: `
+					provider "backstage" {
+						legacy_empty_strings = true
+					}
+				` + testAccDataSourceProviderInfoConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_provider_info.test", "deprecations.0.in_use", "true"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceProviderInfoConfig = `
+data "backstage_provider_info" "test" {}
+`
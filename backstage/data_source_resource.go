@@ -7,9 +7,12 @@ import (
 	"regexp"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
@@ -27,19 +30,42 @@ func NewResourceDataSource() datasource.DataSource {
 
 // resourceDataSource is the data source implementation.
 type resourceDataSource struct {
-	client *backstage.Client
+	*providerData
 }
 
 type resourceDataSourceModel struct {
-	ID         types.String           `tfsdk:"id"`
-	Name       types.String           `tfsdk:"name"`
-	Namespace  types.String           `tfsdk:"namespace"`
-	ApiVersion types.String           `tfsdk:"api_version"`
-	Kind       types.String           `tfsdk:"kind"`
-	Metadata   *entityMetadataModel   `tfsdk:"metadata"`
-	Relations  []entityRelationModel  `tfsdk:"relations"`
-	Spec       *resourceSpecModel     `tfsdk:"spec"`
-	Fallback   *resourceFallbackModel `tfsdk:"fallback"`
+	ID                types.String            `tfsdk:"id"`
+	Name              types.String            `tfsdk:"name"`
+	UID               types.String            `tfsdk:"uid"`
+	Title             types.String            `tfsdk:"title"`
+	Namespace         types.String            `tfsdk:"namespace"`
+	ResolvedNamespace types.String            `tfsdk:"resolved_namespace"`
+	ExpectedOwner     types.String            `tfsdk:"expected_owner"`
+	ApiVersion        types.String            `tfsdk:"api_version"`
+	Kind              types.String            `tfsdk:"kind"`
+	ContentHash       types.String            `tfsdk:"content_hash"`
+	Status            *entityStatusModel      `tfsdk:"status"`
+	CatalogURL        types.String            `tfsdk:"catalog_url"`
+	TechDocsURL       types.String            `tfsdk:"techdocs_url"`
+	ParsedOwner       *entityRefModel         `tfsdk:"parsed_owner"`
+	Metadata          *entityMetadataModel    `tfsdk:"metadata"`
+	Relations         []entityRelationModel   `tfsdk:"relations"`
+	TargetsByType     types.Map               `tfsdk:"targets_by_type"`
+	Spec              *resourceSpecModel      `tfsdk:"spec"`
+	GitOps            *entityGitOpsModel      `tfsdk:"gitops"`
+	AnnotationKeys    []types.String          `tfsdk:"annotation_keys"`
+	Query             types.String            `tfsdk:"query"`
+	QueryResult       jsontypes.Normalized    `tfsdk:"query_result"`
+	FollowMoves       types.Bool              `tfsdk:"follow_moves"`
+	MovedTo           *entityMovedToModel     `tfsdk:"moved_to"`
+	FollowAliases     types.Bool              `tfsdk:"follow_aliases"`
+	AliasedTo         *entityAliasedToModel   `tfsdk:"aliased_to"`
+	RequestInfo       *entityRequestInfoModel `tfsdk:"request_info"`
+	WaitFor           *entityWaitForModel     `tfsdk:"wait_for"`
+	Fallback          *resourceFallbackModel  `tfsdk:"fallback"`
+	FallbackFile      types.String            `tfsdk:"fallback_file"`
+	FallbackSources   []types.String          `tfsdk:"fallback_sources"`
+	FallbackSource    types.String            `tfsdk:"fallback_source"`
 }
 
 type resourceSpecModel struct {
@@ -79,14 +105,37 @@ func (d *resourceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 		MarkdownDescription: "Use this data source to get a specific " +
 			"[Resource entity](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-resource) from Backstage Software Catalog.",
 		Attributes: map[string]schema.Attribute{
-			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
-			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+			"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":  schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"name": schema.StringAttribute{Optional: true, Computed: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
 					regexp.MustCompile(patternEntityName),
 					"must follow Backstage format restrictions",
 				),
 			}},
+			"uid": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityUID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ExactlyOneOf(path.MatchRoot("name"), path.MatchRoot("title")),
+				stringvalidator.ConflictsWith(path.MatchRoot("namespace")),
+			}},
+			"title": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitle, Validators: catalogFilterValidators},
 			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(
@@ -94,8 +143,10 @@ func (d *resourceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 					"must follow Backstage format restrictions",
 				),
 			}},
-			"api_version": schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
-			"kind":        schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"expected_owner":     schema.StringAttribute{Optional: true, Description: descriptionEntityExpectedOwner},
+			"api_version":        schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionEntityApiVersionRequested},
+			"kind":               schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
 			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
 				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
 				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
@@ -114,6 +165,8 @@ func (d *resourceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
 					},
 				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
 			}},
 			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 				Attributes: map[string]schema.Attribute{
@@ -127,12 +180,61 @@ func (d *resourceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 						}},
 				},
 			}},
+			"targets_by_type": schema.MapAttribute{Computed: true, Description: descriptionEntityTargetsByType,
+				ElementType: types.ListType{ElemType: types.StringType}},
 			"spec": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
 				"type":       schema.StringAttribute{Computed: true, Description: descriptionResourceSpecType},
 				"owner":      schema.StringAttribute{Computed: true, Description: descriptionResourceSpecOwner},
 				"depends_on": schema.ListAttribute{Computed: true, Description: descriptionResourceSpecDependsOn, ElementType: types.StringType},
 				"system":     schema.StringAttribute{Computed: true, Description: descriptionResourceSpecSystem},
 			}},
+			"gitops": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOps, Attributes: map[string]schema.Attribute{
+				"argocd": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCD, Attributes: map[string]schema.Attribute{
+					"app_name":  schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCDAppName},
+					"project":   schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCDProject},
+					"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsArgoCDNamespace},
+				}},
+				"flux": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOpsFlux, Attributes: map[string]schema.Attribute{
+					"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsFluxName},
+					"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsFluxNamespace},
+				}},
+				"helm": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityGitOpsHelm, Attributes: map[string]schema.Attribute{
+					"release_name": schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsHelmReleaseName},
+					"namespace":    schema.StringAttribute{Computed: true, Description: descriptionEntityGitOpsHelmNamespace},
+				}},
+			}},
+			"parsed_owner": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityParsedOwner, Attributes: map[string]schema.Attribute{
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRef},
+				"kind":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefKind},
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefNamespace},
+				"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefName},
+			}},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+			"query":           schema.StringAttribute{Optional: true, Description: descriptionEntityQuery, Validators: []validator.String{stringvalidator.LengthAtLeast(1)}},
+			"query_result":    schema.StringAttribute{Computed: true, Description: descriptionEntityQueryResult, CustomType: jsontypes.NormalizedType{}},
+			"follow_moves":    schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowMoves},
+			"moved_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMovedTo, Attributes: map[string]schema.Attribute{
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToNamespace},
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityMovedToRef},
+			}},
+			"follow_aliases": schema.BoolAttribute{Optional: true, Description: descriptionEntityFollowAliases},
+			"aliased_to": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityAliasedTo, Attributes: map[string]schema.Attribute{
+				"name": schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToName},
+				"ref":  schema.StringAttribute{Computed: true, Description: descriptionEntityAliasedToRef},
+			}},
+			"request_info": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRequestInfo, Attributes: map[string]schema.Attribute{
+				"request_id":  schema.StringAttribute{Computed: true, Description: descriptionEntityRequestInfoRequestID},
+				"status_code": schema.Int64Attribute{Computed: true, Description: descriptionEntityRequestInfoStatusCode},
+				"headers":     schema.MapAttribute{Computed: true, Description: descriptionEntityRequestInfoHeaders, ElementType: types.StringType},
+			}},
+			"wait_for": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityWaitFor, Attributes: map[string]schema.Attribute{
+				"previous_etag":   schema.StringAttribute{Optional: true, Description: descriptionEntityWaitForPreviousEtag},
+				"timeout_seconds": schema.Int64Attribute{Optional: true, Description: descriptionEntityWaitForTimeoutSeconds},
+			}},
+			"fallback_file": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackFile, Validators: fallbackFileValidators},
+			"fallback_sources": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntityFallbackSources, ElementType: types.StringType,
+				Validators: fallbackSourcesValidators},
+			"fallback_source": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityFallbackSource},
 			"fallback": schema.SingleNestedAttribute{Optional: true, Description: descriptionResourceFallback, Attributes: map[string]schema.Attribute{
 				"id": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
 				"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
@@ -152,15 +254,17 @@ func (d *resourceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 				"api_version": schema.StringAttribute{Optional: true, Description: descriptionEntityApiVersion},
 				"kind":        schema.StringAttribute{Optional: true, Description: descriptionEntityKind},
 				"metadata": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
-					"uid":         schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
-					"etag":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
-					"name":        schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
-					"namespace":   schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
-					"title":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle},
-					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription},
+					"uid":       schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataUID},
+					"etag":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataEtag},
+					"name":      schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataName},
+					"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace},
+					"title":     schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataTitle, Validators: fallbackTitleValidators},
+					"description": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataDescription,
+						Validators: fallbackDescriptionValidators},
 					"labels":      schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
 					"annotations": schema.MapAttribute{Optional: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
-					"tags":        schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+					"tags": schema.ListAttribute{Optional: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType,
+						Validators: fallbackTagsValidators},
 					"links": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
 						Attributes: map[string]schema.Attribute{
 							"url":   schema.StringAttribute{Optional: true, Description: descriptionEntityLinkURL},
@@ -169,6 +273,8 @@ func (d *resourceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 							"type":  schema.StringAttribute{Optional: true, Description: descriptionEntityLinkType},
 						},
 					}},
+					"sensitive_annotations": schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+						ElementType: types.StringType},
 				}},
 				"relations": schema.ListNestedAttribute{Optional: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
 					Attributes: map[string]schema.Attribute{
@@ -184,7 +290,7 @@ func (d *resourceDataSource) Schema(_ context.Context, _ datasource.SchemaReques
 				}},
 				"spec": schema.SingleNestedAttribute{Optional: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
 					"type":       schema.StringAttribute{Optional: true, Description: descriptionResourceSpecType},
-					"owner":      schema.StringAttribute{Optional: true, Description: descriptionResourceSpecOwner},
+					"owner":      schema.StringAttribute{Optional: true, Description: descriptionResourceSpecOwner, Validators: fallbackOwnerValidators},
 					"depends_on": schema.ListAttribute{Optional: true, Description: descriptionResourceSpecDependsOn, ElementType: types.StringType},
 					"system":     schema.StringAttribute{Optional: true, Description: descriptionResourceSpecSystem},
 				}},
@@ -199,7 +305,7 @@ func (d *resourceDataSource) Configure(_ context.Context, req datasource.Configu
 		return
 	}
 
-	d.client = req.ProviderData.(*backstage.Client)
+	d.providerData = req.ProviderData.(*providerData)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -211,15 +317,55 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		return
 	}
 
-	if state.Namespace.IsNull() {
-		state.Namespace = types.StringValue(backstage.DefaultNamespaceName)
+	requestedApiVersion := state.ApiVersion
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+	lookupResponse, lookupErr := d.resolveUID(ctx, backstage.KindResource, state.UID, &state.Name, &state.ResolvedNamespace)
+	if !state.Title.IsNull() {
+		lookupResponse, lookupErr = d.resolveTitle(ctx, backstage.KindResource, state.Title, state.Namespace, &state.Name, &state.ResolvedNamespace)
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Getting Resource kind %s/%s from Backstage API", state.Name.ValueString(), state.Namespace.ValueString()))
-	resource, response, err := d.client.Catalog.Resources.Get(ctx, state.Name.ValueString(), state.Namespace.ValueString())
+	if !d.checkAccess(backstage.KindResource, state.ResolvedNamespace.ValueString(), &resp.Diagnostics) {
+		return
+	}
+
+	if state.Fallback != nil {
+		d.applyFallbackDefaults(backstage.KindResource, state.Fallback, &resp.Diagnostics)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting Resource kind %s/%s from Backstage API", state.Name.ValueString(), state.ResolvedNamespace.ValueString()))
+	resource, response, err := waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ResourceEntityV1alpha1, *http.Response, error) {
+		if lookupErr != nil || lookupResponse != nil {
+			return nil, lookupResponse, lookupErr
+		}
+		return d.client.Catalog.Resources.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+	}, func(e *backstage.ResourceEntityV1alpha1) string { return e.Metadata.Etag })
+	if movedTo := d.findMove(ctx, state.FollowMoves, backstage.KindResource, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); movedTo != nil {
+		state.MovedTo = movedTo
+		state.ResolvedNamespace = movedTo.Namespace
+		resource, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ResourceEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Resources.Get(ctx, state.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.ResourceEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	if aliasedTo := d.findAlias(ctx, state.FollowAliases, backstage.KindResource, state.Name.ValueString(), state.ResolvedNamespace.ValueString(), response, err,
+		&resp.Diagnostics); aliasedTo != nil {
+		state.AliasedTo = aliasedTo
+		resource, response, err = waitForEntity(ctx, state.WaitFor, &resp.Diagnostics, func(ctx context.Context) (*backstage.ResourceEntityV1alpha1, *http.Response, error) {
+			return d.client.Catalog.Resources.Get(ctx, aliasedTo.Name.ValueString(), state.ResolvedNamespace.ValueString())
+		}, func(e *backstage.ResourceEntityV1alpha1) string { return e.Metadata.Etag })
+	}
+	state.RequestInfo = d.requestInfo(ctx, response, &resp.Diagnostics)
+	state.FallbackSource = types.StringNull()
+	if err != nil || response.StatusCode != http.StatusOK {
+		state.FallbackSource = d.resolveFallback(ctx, backstage.KindResource, state.ResolvedNamespace.ValueString(), state.Name.ValueString(), state.FallbackSources,
+			state.FallbackFile, &state.Fallback, &resp.Diagnostics)
+	}
 	if err != nil {
 		const shortErr = "Error reading Backstage Resource kind"
-		longErr := fmt.Sprintf("Could not read Backstage Resource kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), err.Error())
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Resource kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), err.Error()))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -227,9 +373,9 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if response.StatusCode != http.StatusOK {
+	if err == nil && response.StatusCode != http.StatusOK {
 		const shortErr = "Error reading Backstage Resource kind"
-		longErr := fmt.Sprintf("Could not read Backstage Resource kind %s/%s: %s", state.Namespace.ValueString(), state.Name.ValueString(), response.Status)
+		longErr := d.withRequestID(fmt.Sprintf("Could not read Backstage Resource kind %s/%s: %s", state.ResolvedNamespace.ValueString(), state.Name.ValueString(), response.Status))
 		if state.Fallback == nil {
 			resp.Diagnostics.AddError(shortErr, longErr)
 			return
@@ -237,7 +383,9 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		resp.Diagnostics.AddWarning(shortErr, longErr)
 	}
 
-	if (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil {
+	fallback := (err != nil || response.StatusCode != http.StatusOK) && state.Fallback != nil
+	if fallback {
+		d.metrics.Count("fallbacks_total", 1, map[string]string{"kind": backstage.KindResource})
 		if state.Fallback.ID.IsNull() {
 			state.Fallback.ID = types.StringValue("123456789")
 		}
@@ -249,7 +397,9 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		}
 		state.ID = state.Fallback.ID
 		state.Name = state.Fallback.Name
-		state.Namespace = state.Fallback.Namespace
+		if !state.Fallback.Namespace.IsNull() {
+			state.ResolvedNamespace = state.Fallback.Namespace
+		}
 		state.ApiVersion = state.Fallback.ApiVersion
 		state.Kind = state.Fallback.Kind
 		state.Metadata = state.Fallback.Metadata
@@ -257,14 +407,18 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		state.Spec = state.Fallback.Spec
 	}
 	if err == nil && response.StatusCode == http.StatusOK {
+		d.saveLastRead(ctx, backstage.KindResource, resource.Metadata.Namespace, resource.Metadata.Name, resource)
+		state.QueryResult = d.queryEntity(ctx, state.Query, backstage.KindResource, resource.Metadata.Namespace, resource.Metadata.Name, &resp.Diagnostics)
 		state.ID = types.StringValue(resource.Metadata.UID)
+		state.ContentHash = entityContentHash(resource)
+		state.Status = entityStatus(resource.Status)
 		state.ApiVersion = types.StringValue(resource.ApiVersion)
 		state.Kind = types.StringValue(resource.Kind)
 
 		for _, i := range resource.Relations {
 			state.Relations = append(state.Relations, entityRelationModel{
 				Type:      types.StringValue(i.Type),
-				TargetRef: types.StringValue(i.TargetRef),
+				TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
 				Target: &entityRelationTargetModel{
 					Kind:      types.StringValue(i.Target.Kind),
 					Name:      types.StringValue(i.Target.Name),
@@ -273,9 +427,9 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		}
 
 		state.Spec = &resourceSpecModel{
-			Type:   types.StringValue(resource.Spec.Type),
-			Owner:  types.StringValue(resource.Spec.Owner),
-			System: types.StringValue(resource.Spec.System),
+			Type:   d.optionalString(resource.Spec.Type),
+			Owner:  d.optionalString(resource.Spec.Owner),
+			System: d.optionalString(resource.Spec.System),
 		}
 
 		for _, i := range resource.Spec.DependsOn {
@@ -287,8 +441,8 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 			Etag:        types.StringValue(resource.Metadata.Etag),
 			Name:        types.StringValue(resource.Metadata.Name),
 			Namespace:   types.StringValue(resource.Metadata.Namespace),
-			Title:       types.StringValue(resource.Metadata.Title),
-			Description: types.StringValue(resource.Metadata.Description),
+			Title:       d.optionalString(resource.Metadata.Title),
+			Description: d.optionalString(resource.Metadata.Description),
 			Annotations: map[string]string{},
 			Labels:      map[string]string{},
 		}
@@ -308,13 +462,40 @@ func (d *resourceDataSource) Read(ctx context.Context, req datasource.ReadReques
 		for _, v := range resource.Metadata.Links {
 			state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
 				URL:   types.StringValue(v.URL),
-				Title: types.StringValue(v.Title),
-				Icon:  types.StringValue(v.Icon),
-				Type:  types.StringValue(v.Type),
+				Title: d.optionalString(v.Title),
+				Icon:  d.optionalString(v.Icon),
+				Type:  d.optionalString(v.Type),
 			})
 		}
 	}
 
+	var owner types.String
+	if state.Spec != nil {
+		owner = state.Spec.Owner
+	}
+	state.ParsedOwner = parseOwnerRef(owner, state.ResolvedNamespace.ValueString())
+	checkExpectedOwner(state.ExpectedOwner, owner, "Resource", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.GitOps = d.gitOpsBridge(state.Metadata)
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	state.TargetsByType = relationTargetsByType(ctx, state.Relations, &resp.Diagnostics)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	checkApiVersion(requestedApiVersion, state.ApiVersion, "Resource", state.Name.ValueString(), state.ResolvedNamespace.ValueString(), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{
+		DataSource: "backstage_resource",
+		EntityRef:  fmt.Sprintf("resource:%s/%s", state.ResolvedNamespace.ValueString(), state.Name.ValueString()),
+		Fallback:   fallback,
+	})
+
 	diags := resp.State.Set(ctx, state)
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
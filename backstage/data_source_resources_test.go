@@ -0,0 +1,31 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceResources(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceResourcesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_resources.test", "id", "kind=resource,metadata.namespace=default,spec.type=database"),
+					resource.TestCheckResourceAttr("data.backstage_resources.test", "resources.0.type", "database"),
+					resource.TestCheckResourceAttrSet("data.backstage_resources.test", "resources.0.ref"),
+					resource.TestCheckResourceAttrSet("data.backstage_resources.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceResourcesConfig = `
+data "backstage_resources" "test" {
+  namespace = "default"
+  type      = "database"
+}
+`
@@ -0,0 +1,244 @@
+package backstage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/scaffolder"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &scaffolderDryRunDataSource{}
+	_ datasource.DataSourceWithConfigure = &scaffolderDryRunDataSource{}
+)
+
+// NewScaffolderDryRunDataSource is a helper function to simplify the provider implementation.
+func NewScaffolderDryRunDataSource() datasource.DataSource {
+	return &scaffolderDryRunDataSource{}
+}
+
+// scaffolderDryRunDataSource is the data source implementation.
+type scaffolderDryRunDataSource struct {
+	*providerData
+}
+
+type scaffolderDryRunDataSourceModel struct {
+	ID          types.String                  `tfsdk:"id"`
+	TemplateRef types.String                  `tfsdk:"template_ref"`
+	Parameters  jsontypes.Normalized          `tfsdk:"parameters"`
+	Secrets     map[string]string             `tfsdk:"secrets"`
+	Entities    []scaffolderDryRunEntityModel `tfsdk:"entities"`
+	Refs        []types.String                `tfsdk:"refs"`
+	Output      jsontypes.Normalized          `tfsdk:"output"`
+	Log         []types.String                `tfsdk:"log"`
+}
+
+type scaffolderDryRunEntityModel struct {
+	Ref       types.String `tfsdk:"ref"`
+	Kind      types.String `tfsdk:"kind"`
+	Namespace types.String `tfsdk:"namespace"`
+	Name      types.String `tfsdk:"name"`
+	Path      types.String `tfsdk:"path"`
+}
+
+// scaffolderDryRunRequest is the request body of the dry-run endpoint of the scaffolder v2 API.
+type scaffolderDryRunRequest struct {
+	Template          backstage.Entity        `json:"template"`
+	Values            interface{}             `json:"values"`
+	Secrets           map[string]string       `json:"secrets,omitempty"`
+	DirectoryContents []scaffolder.DryRunFile `json:"directoryContents"`
+}
+
+const (
+	pathScaffolderDryRun = "/api/scaffolder/v2/dry-run"
+
+	descriptionScaffolderDryRunTemplateRef = "An entity reference to the template, e.g. `template:default/react-ssr-template`. The kind and namespace " +
+		"default to `template` and `default`."
+	descriptionScaffolderDryRunParameters = "Values of the parameters of the template, as JSON."
+	descriptionScaffolderDryRunSecrets    = "Secrets passed to the template, available to its steps as `${{ secrets.<name> }}`."
+	descriptionScaffolderDryRunEntities   = "Entities the template would register in the catalog, read from the descriptor files the " +
+		"`catalog:register` steps of the template point to, sorted by their references."
+	descriptionScaffolderDryRunEntityRef  = "Entity reference to the entity, e.g. `component:default/my-service`."
+	descriptionScaffolderDryRunEntityKind = "Kind of the entity, as written in its descriptor."
+	descriptionScaffolderDryRunEntityPath = "Path of the descriptor file of the entity in the workspace of the template."
+	descriptionScaffolderDryRunOutput     = "Output of the template, as JSON."
+	descriptionScaffolderDryRunLog        = "Messages logged by the steps of the template during the dry-run."
+	descriptionScaffolderDryRunID         = "Entity reference to the template the dry-run was performed for."
+)
+
+// Metadata returns the data source type name.
+func (d *scaffolderDryRunDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scaffolder_dry_run"
+}
+
+// Schema defines the schema for the data source.
+func (d *scaffolderDryRunDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to simulate a run of a [Software Template](https://backstage.io/docs/features/software-templates/) " +
+			"with the dry-run endpoint of the scaffolder, and get the entities the template would register in the catalog. Useful to create the " +
+			"infrastructure supporting them in the same plan. Requires Backstage 1.4 or later, no side effects of the template are performed.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionScaffolderDryRunID},
+			"template_ref": schema.StringAttribute{Required: true, Description: descriptionScaffolderDryRunTemplateRef, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"parameters": schema.StringAttribute{Optional: true, Description: descriptionScaffolderDryRunParameters, CustomType: jsontypes.NormalizedType{}},
+			"secrets":    schema.MapAttribute{Optional: true, Sensitive: true, Description: descriptionScaffolderDryRunSecrets, ElementType: types.StringType},
+			"refs":       schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"entities": schema.ListNestedAttribute{Computed: true, Description: descriptionScaffolderDryRunEntities, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"ref":       schema.StringAttribute{Computed: true, Description: descriptionScaffolderDryRunEntityRef},
+					"kind":      schema.StringAttribute{Computed: true, Description: descriptionScaffolderDryRunEntityKind},
+					"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"path":      schema.StringAttribute{Computed: true, Description: descriptionScaffolderDryRunEntityPath},
+				},
+			}},
+			"output": schema.StringAttribute{Computed: true, Description: descriptionScaffolderDryRunOutput, CustomType: jsontypes.NormalizedType{}},
+			"log":    schema.ListAttribute{Computed: true, Description: descriptionScaffolderDryRunLog, ElementType: types.StringType},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *scaffolderDryRunDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *scaffolderDryRunDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state scaffolderDryRunDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	_, namespace, name := parseEntityRef(state.TemplateRef.ValueString(), "template", backstage.DefaultNamespaceName)
+	ref := fmt.Sprintf("template:%s/%s", namespace, name)
+	if !d.checkAccess(kindTemplate, namespace, &resp.Diagnostics) {
+		return
+	}
+
+	if caps, err := d.capabilities(ctx); err == nil && !caps.ScaffolderV2 {
+		resp.Diagnostics.AddError("Backstage scaffolder dry-run not supported",
+			fmt.Sprintf("Backstage instance %s does not support dry-runs of templates, which requires Backstage 1.4 or later.", d.baseURL))
+		return
+	}
+
+	var values interface{} = map[string]interface{}{}
+	if !state.Parameters.IsNull() {
+		if err := json.Unmarshal([]byte(state.Parameters.ValueString()), &values); err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("parameters"), "Invalid parameters of Backstage template",
+				fmt.Sprintf("Could not parse parameters of Backstage template %s: %s", ref, err.Error()))
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting Template kind %s/%s from Backstage API", namespace, name))
+	templates, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: []string{fmt.Sprintf("kind=template,metadata.namespace=%s,metadata.name=%s", namespace, name)},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage Template kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage Template kind %s/%s: %s", namespace, name, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage Template kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage Template kind %s/%s: %s", namespace, name, response.Status)))
+		return
+	}
+
+	if len(templates) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("template_ref"), "Backstage Template kind not found",
+			d.withRequestID(fmt.Sprintf("Backstage Template kind %s/%s does not exist.", namespace, name)))
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Performing dry-run of Template kind %s/%s", namespace, name))
+	var result scaffolder.DryRunResult
+	response, err = d.doJSON(ctx, http.MethodPost, pathScaffolderDryRun, scaffolderDryRunRequest{
+		Template:          templates[0],
+		Values:            values,
+		Secrets:           state.Secrets,
+		DirectoryContents: []scaffolder.DryRunFile{},
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddError("Error performing dry-run of Backstage template",
+			d.withRequestID(fmt.Sprintf("Could not perform dry-run of Backstage Template kind %s/%s: %s", namespace, name, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error performing dry-run of Backstage template",
+			d.withRequestID(fmt.Sprintf("Could not perform dry-run of Backstage Template kind %s/%s: %s", namespace, name, response.Status)))
+		return
+	}
+
+	entities, err := scaffolder.RegisteredEntities(&result)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading entities of Backstage template",
+			fmt.Sprintf("Could not read entities registered by Backstage Template kind %s/%s: %s", namespace, name, err.Error()))
+		return
+	}
+
+	state.ID = types.StringValue(ref)
+	state.Entities = []scaffolderDryRunEntityModel{}
+	for _, e := range entities {
+		state.Entities = append(state.Entities, scaffolderDryRunEntityModel{
+			Ref:       types.StringValue(e.Ref),
+			Kind:      types.StringValue(e.Kind),
+			Namespace: types.StringValue(e.Namespace),
+			Name:      types.StringValue(e.Name),
+			Path:      types.StringValue(e.Path),
+		})
+	}
+
+	output, err := json.Marshal(result.Output)
+	if err != nil {
+		resp.Diagnostics.AddError("Error parsing output of Backstage template",
+			fmt.Sprintf("Could not parse output of Backstage Template kind %s/%s: %s", namespace, name, err.Error()))
+		return
+	}
+	state.Output = jsontypes.NewNormalizedValue(string(output))
+
+	state.Log = []types.String{}
+	for _, l := range result.Log {
+		if message := strings.TrimSpace(l.Body.Message); message != "" {
+			state.Log = append(state.Log, types.StringValue(message))
+		}
+	}
+
+	refs := make([]string, 0, len(state.Entities))
+	for _, e := range state.Entities {
+		refs = append(refs, e.Ref.ValueString())
+	}
+	state.Refs = refsSet(refs)
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_scaffolder_dry_run", EntityRef: ref})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
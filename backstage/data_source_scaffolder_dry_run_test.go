@@ -0,0 +1,34 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceScaffolderDryRun(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceScaffolderDryRunConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_scaffolder_dry_run.test", "id", "template:default/react-ssr-template"),
+					resource.TestCheckResourceAttrSet("data.backstage_scaffolder_dry_run.test", "entities.#"),
+					resource.TestCheckResourceAttrSet("data.backstage_scaffolder_dry_run.test", "refs.#"),
+					resource.TestCheckResourceAttrSet("data.backstage_scaffolder_dry_run.test", "output"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceScaffolderDryRunConfig = `
+data "backstage_scaffolder_dry_run" "test" {
+  template_ref = "react-ssr-template"
+  parameters   = jsonencode({
+    component_id = "my-service"
+    owner        = "group:default/guests"
+  })
+}
+`
@@ -0,0 +1,240 @@
+package backstage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &scaffolderTaskDataSource{}
+	_ datasource.DataSourceWithConfigure = &scaffolderTaskDataSource{}
+)
+
+// NewScaffolderTaskDataSource is a helper function to simplify the provider implementation.
+func NewScaffolderTaskDataSource() datasource.DataSource {
+	return &scaffolderTaskDataSource{}
+}
+
+// scaffolderTaskDataSource is the data source implementation.
+type scaffolderTaskDataSource struct {
+	*providerData
+}
+
+type scaffolderTaskDataSourceModel struct {
+	ID              types.String              `tfsdk:"id"`
+	TemplateRef     types.String              `tfsdk:"template_ref"`
+	Status          types.String              `tfsdk:"status"`
+	CreatedAt       types.String              `tfsdk:"created_at"`
+	CreatedBy       types.String              `tfsdk:"created_by"`
+	LastHeartbeatAt types.String              `tfsdk:"last_heartbeat_at"`
+	Parameters      jsontypes.Normalized      `tfsdk:"parameters"`
+	Steps           []scaffolderTaskStepModel `tfsdk:"steps"`
+	Output          jsontypes.Normalized      `tfsdk:"output"`
+}
+
+type scaffolderTaskStepModel struct {
+	ID     types.String `tfsdk:"id"`
+	Name   types.String `tfsdk:"name"`
+	Action types.String `tfsdk:"action"`
+	Status types.String `tfsdk:"status"`
+}
+
+// scaffolderTaskEvent is an event of a task of the scaffolder v2 API.
+type scaffolderTaskEvent struct {
+	ID        json.Number `json:"id"`
+	TaskID    string      `json:"taskId"`
+	Type      string      `json:"type"`
+	CreatedAt string      `json:"createdAt"`
+	Body      struct {
+		Message string      `json:"message"`
+		StepID  string      `json:"stepId"`
+		Status  string      `json:"status"`
+		Output  interface{} `json:"output"`
+	} `json:"body"`
+}
+
+const (
+	scaffolderTaskEventCompletion = "completion"
+
+	descriptionScaffolderTaskDataSourceID   = "Identifier of the task, e.g. as shown in the URL of its page in the Backstage UI."
+	descriptionScaffolderTaskDataTemplate   = "Entity reference to the template the task runs."
+	descriptionScaffolderTaskCreatedBy      = "Entity reference to the user that created the task."
+	descriptionScaffolderTaskLastHeartbeat  = "Timestamp the worker running the task last reported it as alive."
+	descriptionScaffolderTaskDataParameters = "Values of the parameters the template is run with, as JSON."
+	descriptionScaffolderTaskSteps          = "Steps of the template, in the order they are run."
+	descriptionScaffolderTaskStepID         = "Identifier of the step."
+	descriptionScaffolderTaskStepName       = "Name of the step."
+	descriptionScaffolderTaskStepAction     = "Action the step performs, e.g. `publish:github`."
+	descriptionScaffolderTaskStepStatus     = "Status of the step: `processing`, `completed`, `failed`, `skipped` or `cancelled`, or null if it " +
+		"has not started."
+	descriptionScaffolderTaskOutput = "Output of the template, as JSON, or null if the task did not complete."
+)
+
+// Metadata returns the data source type name.
+func (d *scaffolderTaskDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scaffolder_task"
+}
+
+// Schema defines the schema for the data source.
+func (d *scaffolderTaskDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get an existing task of the scaffolder of Backstage, running a " +
+			"[Software Template](https://backstage.io/docs/features/software-templates/), with the status of its steps and its output. " +
+			"Useful to make resources depend on a template started elsewhere having completed, e.g. with a `postcondition` on `status`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Required: true, Description: descriptionScaffolderTaskDataSourceID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"template_ref":      schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskDataTemplate},
+			"status":            schema.StringAttribute{Computed: true, MarkdownDescription: descriptionScaffolderTaskStatus},
+			"created_at":        schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskCreatedAt},
+			"created_by":        schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskCreatedBy},
+			"last_heartbeat_at": schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskLastHeartbeat},
+			"parameters": schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskDataParameters,
+				CustomType: jsontypes.NormalizedType{}},
+			"steps": schema.ListNestedAttribute{Computed: true, Description: descriptionScaffolderTaskSteps, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":     schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskStepID},
+					"name":   schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskStepName},
+					"action": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionScaffolderTaskStepAction},
+					"status": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionScaffolderTaskStepStatus},
+				},
+			}},
+			"output": schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskOutput, CustomType: jsontypes.NormalizedType{}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *scaffolderTaskDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *scaffolderTaskDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state scaffolderTaskDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.ID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Getting scaffolder task %s from Backstage API", id))
+	task, response, err := d.getTask(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage scaffolder task",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage scaffolder task %s: %s", id, err.Error())))
+		return
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		resp.Diagnostics.AddAttributeError(path.Root("id"), "Backstage scaffolder task not found",
+			d.withRequestID(fmt.Sprintf("Backstage scaffolder task %s does not exist.", id)))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage scaffolder task",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage scaffolder task %s: %s", id, backstageError(response))))
+		return
+	}
+
+	events, response, err := d.getTaskEvents(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading events of Backstage scaffolder task",
+			d.withRequestID(fmt.Sprintf("Could not read events of Backstage scaffolder task %s: %s", id, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading events of Backstage scaffolder task",
+			d.withRequestID(fmt.Sprintf("Could not read events of Backstage scaffolder task %s: %s", id, backstageError(response))))
+		return
+	}
+
+	state.TemplateRef = d.optionalString(task.Spec.TemplateInfo.EntityRef)
+	state.Status = types.StringValue(task.Status)
+	state.CreatedAt = d.optionalString(task.CreatedAt)
+	state.CreatedBy = d.optionalString(task.CreatedBy)
+	state.LastHeartbeatAt = d.optionalString(task.LastHeartbeatAt)
+
+	state.Parameters = jsontypes.NewNormalizedNull()
+	if task.Spec.Parameters != nil {
+		parameters, err := json.Marshal(task.Spec.Parameters)
+		if err != nil {
+			resp.Diagnostics.AddError("Error parsing parameters of Backstage scaffolder task",
+				fmt.Sprintf("Could not parse parameters of Backstage scaffolder task %s: %s", id, err.Error()))
+			return
+		}
+		state.Parameters = jsontypes.NewNormalizedValue(string(parameters))
+	}
+
+	// Statuses of steps are only reported by the events of the task, the latest one of each step wins.
+	statuses := map[string]string{}
+	state.Output = jsontypes.NewNormalizedNull()
+	for _, e := range events {
+		if e.Body.StepID != "" && e.Body.Status != "" {
+			statuses[e.Body.StepID] = e.Body.Status
+		}
+		if e.Type == scaffolderTaskEventCompletion && e.Body.Output != nil {
+			output, err := json.Marshal(e.Body.Output)
+			if err != nil {
+				resp.Diagnostics.AddError("Error parsing output of Backstage scaffolder task",
+					fmt.Sprintf("Could not parse output of Backstage scaffolder task %s: %s", id, err.Error()))
+				return
+			}
+			state.Output = jsontypes.NewNormalizedValue(string(output))
+		}
+	}
+
+	state.Steps = []scaffolderTaskStepModel{}
+	for _, s := range task.Spec.Steps {
+		step := scaffolderTaskStepModel{
+			ID:     types.StringValue(s.ID),
+			Name:   d.optionalString(s.Name),
+			Action: types.StringValue(s.Action),
+			Status: types.StringNull(),
+		}
+		if status, ok := statuses[s.ID]; ok {
+			step.Status = types.StringValue(status)
+		}
+		state.Steps = append(state.Steps, step)
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_scaffolder_task", EntityRef: task.Spec.TemplateInfo.EntityRef})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// getTaskEvents reads the events of the task from the scaffolder, in the order they occurred.
+func (p *providerData) getTaskEvents(ctx context.Context, id string) ([]scaffolderTaskEvent, *http.Response, error) {
+	var events []scaffolderTaskEvent
+	response, err := p.doJSON(ctx, http.MethodGet, pathScaffolderTasks+"/"+url.PathEscape(id)+"/events", nil, &events)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return nil, response, err
+	}
+
+	return events, response, nil
+}
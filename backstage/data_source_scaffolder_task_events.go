@@ -0,0 +1,193 @@
+package backstage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &scaffolderTaskEventsDataSource{}
+	_ datasource.DataSourceWithConfigure = &scaffolderTaskEventsDataSource{}
+)
+
+// NewScaffolderTaskEventsDataSource is a helper function to simplify the provider implementation.
+func NewScaffolderTaskEventsDataSource() datasource.DataSource {
+	return &scaffolderTaskEventsDataSource{}
+}
+
+// scaffolderTaskEventsDataSource is the data source implementation.
+type scaffolderTaskEventsDataSource struct {
+	*providerData
+}
+
+type scaffolderTaskEventsDataSourceModel struct {
+	ID       types.String               `tfsdk:"id"`
+	TaskID   types.String               `tfsdk:"task_id"`
+	StepID   types.String               `tfsdk:"step_id"`
+	Events   []scaffolderTaskEventModel `tfsdk:"events"`
+	Log      []types.String             `tfsdk:"log"`
+	Failed   types.Bool                 `tfsdk:"failed"`
+	Failures []types.String             `tfsdk:"failures"`
+}
+
+type scaffolderTaskEventModel struct {
+	ID        types.String         `tfsdk:"id"`
+	Type      types.String         `tfsdk:"type"`
+	CreatedAt types.String         `tfsdk:"created_at"`
+	StepID    types.String         `tfsdk:"step_id"`
+	Status    types.String         `tfsdk:"status"`
+	Message   types.String         `tfsdk:"message"`
+	Output    jsontypes.Normalized `tfsdk:"output"`
+}
+
+const (
+	scaffolderTaskStepStatusFailed = "failed"
+
+	descriptionScaffolderTaskEventsID       = "Identifier of the task the events were read for."
+	descriptionScaffolderTaskEventsTaskID   = "Identifier of the task to read the events of."
+	descriptionScaffolderTaskEventsStepID   = "Identifier of a step of the template to read the events of, instead of all events of the task."
+	descriptionScaffolderTaskEvents         = "Events of the task, in the order they occurred."
+	descriptionScaffolderTaskEventID        = "Identifier of the event."
+	descriptionScaffolderTaskEventType      = "Type of the event: `log`, `completion`, `cancelled` or `recovered`."
+	descriptionScaffolderTaskEventCreatedAt = "Timestamp the event occurred at."
+	descriptionScaffolderTaskEventStepID    = "Identifier of the step the event was logged by, or null if it concerns the task."
+	descriptionScaffolderTaskEventStatus    = "Status of the step or the task reported by the event, or null if it does not report one."
+	descriptionScaffolderTaskEventMessage   = "Message of the event."
+	descriptionScaffolderTaskEventOutput    = "Output of the template, as JSON, for `completion` events, or null."
+	descriptionScaffolderTaskEventsLog      = "Messages of the events, in the order they occurred, e.g. to show them in CI summaries."
+	descriptionScaffolderTaskEventsFailed   = "Whether a step or the task is reported as failed by the events."
+	descriptionScaffolderTaskEventsFailures = "Messages of the events reporting a failed step or task."
+)
+
+// Metadata returns the data source type name.
+func (d *scaffolderTaskEventsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scaffolder_task_events"
+}
+
+// Schema defines the schema for the data source.
+func (d *scaffolderTaskEventsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the log of a task of the scaffolder of Backstage, running a " +
+			"[Software Template](https://backstage.io/docs/features/software-templates/), as structured events. Useful to surface why a task " +
+			"failed in outputs of Terraform and summaries of CI runs.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskEventsID},
+			"task_id": schema.StringAttribute{Required: true, Description: descriptionScaffolderTaskEventsTaskID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"step_id": schema.StringAttribute{Optional: true, Description: descriptionScaffolderTaskEventsStepID, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"events": schema.ListNestedAttribute{Computed: true, Description: descriptionScaffolderTaskEvents, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":         schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskEventID},
+					"type":       schema.StringAttribute{Computed: true, MarkdownDescription: descriptionScaffolderTaskEventType},
+					"created_at": schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskEventCreatedAt},
+					"step_id":    schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskEventStepID},
+					"status":     schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskEventStatus},
+					"message":    schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskEventMessage},
+					"output": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionScaffolderTaskEventOutput,
+						CustomType: jsontypes.NormalizedType{}},
+				},
+			}},
+			"log":      schema.ListAttribute{Computed: true, Description: descriptionScaffolderTaskEventsLog, ElementType: types.StringType},
+			"failed":   schema.BoolAttribute{Computed: true, Description: descriptionScaffolderTaskEventsFailed},
+			"failures": schema.ListAttribute{Computed: true, Description: descriptionScaffolderTaskEventsFailures, ElementType: types.StringType},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *scaffolderTaskEventsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *scaffolderTaskEventsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state scaffolderTaskEventsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id := state.TaskID.ValueString()
+	tflog.Debug(ctx, fmt.Sprintf("Getting events of scaffolder task %s from Backstage API", id))
+	events, response, err := d.getTaskEvents(ctx, id)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading events of Backstage scaffolder task",
+			d.withRequestID(fmt.Sprintf("Could not read events of Backstage scaffolder task %s: %s", id, err.Error())))
+		return
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		resp.Diagnostics.AddAttributeError(path.Root("task_id"), "Backstage scaffolder task not found",
+			d.withRequestID(fmt.Sprintf("Backstage scaffolder task %s does not exist.", id)))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading events of Backstage scaffolder task",
+			d.withRequestID(fmt.Sprintf("Could not read events of Backstage scaffolder task %s: %s", id, backstageError(response))))
+		return
+	}
+
+	state.ID = types.StringValue(id)
+	state.Events = []scaffolderTaskEventModel{}
+	state.Log = []types.String{}
+	state.Failures = []types.String{}
+	for _, e := range events {
+		if !state.StepID.IsNull() && e.Body.StepID != state.StepID.ValueString() {
+			continue
+		}
+
+		event := scaffolderTaskEventModel{
+			ID:        types.StringValue(e.ID.String()),
+			Type:      types.StringValue(e.Type),
+			CreatedAt: d.optionalString(e.CreatedAt),
+			StepID:    d.optionalString(e.Body.StepID),
+			Status:    d.optionalString(e.Body.Status),
+			Message:   d.optionalString(e.Body.Message),
+			Output:    jsontypes.NewNormalizedNull(),
+		}
+		if e.Body.Output != nil {
+			output, err := json.Marshal(e.Body.Output)
+			if err != nil {
+				resp.Diagnostics.AddError("Error parsing output of Backstage scaffolder task",
+					fmt.Sprintf("Could not parse output of Backstage scaffolder task %s: %s", id, err.Error()))
+				return
+			}
+			event.Output = jsontypes.NewNormalizedValue(string(output))
+		}
+		state.Events = append(state.Events, event)
+
+		if e.Body.Message != "" {
+			state.Log = append(state.Log, types.StringValue(e.Body.Message))
+		}
+		if e.Body.Status == scaffolderTaskStepStatusFailed {
+			state.Failures = append(state.Failures, types.StringValue(e.Body.Message))
+		}
+	}
+	state.Failed = types.BoolValue(len(state.Failures) > 0)
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
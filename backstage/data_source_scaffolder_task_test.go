@@ -0,0 +1,24 @@
+package backstage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceScaffolderTask(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_scaffolder_task" "test" {
+						id = "00000000-0000-0000-0000-000000000000"
+					}
+				`,
+				ExpectError: regexp.MustCompile("Backstage scaffolder task"),
+			},
+		},
+	})
+}
@@ -0,0 +1,155 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &starredEntitiesDataSource{}
+	_ datasource.DataSourceWithConfigure = &starredEntitiesDataSource{}
+)
+
+// NewStarredEntitiesDataSource is a helper function to simplify the provider implementation.
+func NewStarredEntitiesDataSource() datasource.DataSource {
+	return &starredEntitiesDataSource{}
+}
+
+// starredEntitiesDataSource is the data source implementation.
+type starredEntitiesDataSource struct {
+	*providerData
+}
+
+type starredEntitiesDataSourceModel struct {
+	ID          types.String   `tfsdk:"id"`
+	FeaturedTag types.String   `tfsdk:"featured_tag"`
+	EntityRefs  []types.String `tfsdk:"entity_refs"`
+	Refs        []types.String `tfsdk:"refs"`
+}
+
+// starredEntitiesSetting is the user setting storing the starred entities.
+type starredEntitiesSetting struct {
+	Value []string `json:"value"`
+}
+
+const (
+	pathStarredEntities = "/api/user-settings/buckets/starredEntities/keys/entityRefs"
+
+	descriptionStarredEntitiesFeaturedTag = "Tag of the featured entities. If set, entities having this tag are returned instead of the entities starred " +
+		"by the identity the provider is configured with."
+	descriptionStarredEntitiesEntityRefs = "Sorted list of entity references of the starred or featured entities."
+)
+
+// Metadata returns the data source type name.
+func (d *starredEntitiesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_starred_entities"
+}
+
+// Schema defines the schema for the data source.
+func (d *starredEntitiesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get the entities starred in Backstage by the identity the provider is configured with " +
+			"(stored by the [user settings](https://github.com/backstage/backstage/tree/master/plugins/user-settings-backend) plugin), or the entities " +
+			"having a tag marking them as featured. Useful for landing pages provisioned by Terraform to highlight the same entities as the portal does.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: "Identifier of the list of entities."},
+			"featured_tag": schema.StringAttribute{Optional: true, Description: descriptionStarredEntitiesFeaturedTag, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"refs":        schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"entity_refs": schema.ListAttribute{Computed: true, Description: descriptionStarredEntitiesEntityRefs, ElementType: types.StringType},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *starredEntitiesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *starredEntitiesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state starredEntitiesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var refs, filters []string
+	if state.FeaturedTag.IsNull() {
+		tflog.Debug(ctx, "Getting starred entities from Backstage API")
+		var setting starredEntitiesSetting
+		response, err := d.doJSON(ctx, http.MethodGet, pathStarredEntities, nil, &setting)
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading Backstage starred entities",
+				d.withRequestID(fmt.Sprintf("Could not read Backstage starred entities: %s", err.Error())))
+			return
+		}
+
+		// No entities are starred, if the setting does not exist.
+		if response.StatusCode != http.StatusOK && response.StatusCode != http.StatusNotFound {
+			resp.Diagnostics.AddError("Error reading Backstage starred entities",
+				d.withRequestID(fmt.Sprintf("Could not read Backstage starred entities: %s", response.Status)))
+			return
+		}
+
+		refs = d.allowedRefs(setting.Value, &resp.Diagnostics)
+		state.ID = types.StringValue("starred")
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Getting entities tagged %s from Backstage API", state.FeaturedTag.ValueString()))
+		filters = []string{"metadata.tags=" + state.FeaturedTag.ValueString()}
+		entities, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+			Filters: filters,
+			Fields:  []string{"kind", "metadata.namespace", "metadata.name"},
+		})
+		if err != nil {
+			resp.Diagnostics.AddError("Error reading Backstage featured entities",
+				d.withRequestID(fmt.Sprintf("Could not read Backstage entities tagged %s: %s", state.FeaturedTag.ValueString(), err.Error())))
+			return
+		}
+
+		if response.StatusCode != http.StatusOK {
+			resp.Diagnostics.AddError("Error reading Backstage featured entities",
+				d.withRequestID(fmt.Sprintf("Could not read Backstage entities tagged %s: %s", state.FeaturedTag.ValueString(), response.Status)))
+			return
+		}
+
+		for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+			refs = append(refs, fmt.Sprintf("%s:%s/%s", strings.ToLower(e.Kind), e.Metadata.Namespace, e.Metadata.Name))
+		}
+		state.ID = types.StringValue("featured:" + state.FeaturedTag.ValueString())
+	}
+
+	sort.Strings(refs)
+	state.EntityRefs = []types.String{}
+	for _, r := range refs {
+		state.EntityRefs = append(state.EntityRefs, types.StringValue(r))
+	}
+
+	state.Refs = refsSet(refs)
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_starred_entities", Filters: filters})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
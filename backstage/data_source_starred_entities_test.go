@@ -0,0 +1,29 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceStarredEntities_WithFeaturedTag(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceStarredEntitiesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_starred_entities.test", "id", "featured:java"),
+					resource.TestCheckResourceAttrSet("data.backstage_starred_entities.test", "entity_refs.#"),
+					resource.TestCheckResourceAttrSet("data.backstage_starred_entities.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceStarredEntitiesConfig = `
+data "backstage_starred_entities" "test" {
+  featured_tag = "java"
+}
+`
@@ -71,3 +71,51 @@ func TestAccDataSourceSystem_WithoutFallback_Error(t *testing.T) {
 		},
 	})
 }
+
+func TestAccDataSourceSystem_WithWaitFor(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_system" "test" {
+						name = "artist-engagement-portal"
+						wait_for = {
+							previous_etag   = "stale-etag-a9ab8"
+							timeout_seconds = 10
+						}
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_system.test", "kind", "System"),
+					resource.TestCheckResourceAttr("data.backstage_system.test", "spec.domain", "artists"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSystem_WithUnknownRelationTypesFailing(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceSystemConfigWithUnknownRelationTypesFailing,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_system.test", "relations.0.type", "hasPart"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSystemConfigWithUnknownRelationTypesFailing = `
+provider "backstage" {
+  unknown_relation_types = "fail"
+  custom_relation_types  = ["poweredBy"]
+}
+
+data "backstage_system" "test" {
+  name = "artist-engagement-portal"
+}
+`
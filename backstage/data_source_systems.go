@@ -0,0 +1,278 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &systemsDataSource{}
+	_ datasource.DataSourceWithConfigure = &systemsDataSource{}
+)
+
+// NewSystemsDataSource is a helper function to simplify the provider implementation.
+func NewSystemsDataSource() datasource.DataSource {
+	return &systemsDataSource{}
+}
+
+// systemsDataSource is the data source implementation.
+type systemsDataSource struct {
+	*providerData
+}
+
+type systemsDataSourceModel struct {
+	ID                types.String                `tfsdk:"id"`
+	Namespace         types.String                `tfsdk:"namespace"`
+	Domain            types.String                `tfsdk:"domain"`
+	Owner             types.String                `tfsdk:"owner"`
+	Tags              []types.String              `tfsdk:"tags"`
+	TagsMatch         types.String                `tfsdk:"tags_match"`
+	Limit             types.Int64                 `tfsdk:"limit"`
+	Offset            types.Int64                 `tfsdk:"offset"`
+	PageSize          types.Int64                 `tfsdk:"page_size"`
+	OrderBy           []entityOrderModel          `tfsdk:"order_by"`
+	NameRegex         types.String                `tfsdk:"name_regex"`
+	TitleRegex        types.String                `tfsdk:"title_regex"`
+	CountOnly         types.Bool                  `tfsdk:"count_only"`
+	RefsOnly          types.Bool                  `tfsdk:"refs_only"`
+	TotalCount        types.Int64                 `tfsdk:"total_count"`
+	Projection        types.String                `tfsdk:"projection"`
+	ProjectionResults []jsontypes.Normalized      `tfsdk:"projection_results"`
+	Refs              []types.String              `tfsdk:"refs"`
+	Systems           []systemsItemModel          `tfsdk:"systems"`
+	EntitiesByRef     map[string]systemsItemModel `tfsdk:"entities_by_ref"`
+}
+
+type systemsItemModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Ref         types.String   `tfsdk:"ref"`
+	Name        types.String   `tfsdk:"name"`
+	Namespace   types.String   `tfsdk:"namespace"`
+	Title       types.String   `tfsdk:"title"`
+	Description types.String   `tfsdk:"description"`
+	Owner       types.String   `tfsdk:"owner"`
+	Domain      types.String   `tfsdk:"domain"`
+	Tags        []types.String `tfsdk:"tags"`
+}
+
+const (
+	descriptionSystemsNamespace = "Namespace of the systems. If not set, systems of all namespaces are returned."
+	descriptionSystemsDomain    = "An entity reference to the domain of the systems, e.g. `domain:default/artists`. The kind and namespace " +
+		"default to `domain` and `namespace` of the data source, or `default` if it is not set."
+	descriptionSystemsTags = "Tags of the systems, e.g. `java`. If set, only the systems having any of the tags, or all of them as set in " +
+		"`tags_match`, are returned."
+	descriptionSystemsOwner = "An entity reference to the owner of the systems, e.g. `group:default/team-a`. It is normalized the way " +
+		"Backstage does, so `team-a` matches systems owned by `group:default/team-a`."
+	descriptionSystemsSystems    = "Systems sorted by their entity references."
+	descriptionSystemsRef        = "Entity reference to the system, e.g. `system:default/audio-playback`."
+	descriptionSystemsDataSource = "Identifier of the list of systems, the catalog filter used to read them."
+)
+
+// Metadata returns the data source type name.
+func (d *systemsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_systems"
+}
+
+// Schema defines the schema for the data source.
+func (d *systemsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a list of " +
+			"[System entities](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-system) from Backstage Software " +
+			"Catalog, filtered by the catalog on their domain and owner.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionSystemsDataSource},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionSystemsNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"domain": schema.StringAttribute{Optional: true, Description: descriptionSystemsDomain, Validators: catalogFilterValidators},
+			"owner":  schema.StringAttribute{Optional: true, Description: descriptionSystemsOwner, Validators: catalogFilterValidators},
+			"tags": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionSystemsTags, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(catalogFilterValidators...)}},
+			"tags_match": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTagsMatch, Validators: []validator.String{
+				stringvalidator.OneOf(tagsMatchAny, tagsMatchAll),
+			}},
+			"limit": schema.Int64Attribute{Optional: true, Description: descriptionEntityLimit, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"offset": schema.Int64Attribute{Optional: true, Description: descriptionEntityOffset, Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			}},
+			"page_size": schema.Int64Attribute{Optional: true, Description: descriptionEntityPageSize, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"order_by": schema.ListNestedAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderBy, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{Required: true, MarkdownDescription: descriptionEntityOrderByField, Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					}},
+					"direction": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderByDirection, Validators: []validator.String{
+						stringvalidator.OneOf(orderAscending, orderDescending),
+					}},
+				},
+			}},
+			"name_regex":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityNameRegex},
+			"title_regex": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitleRegex},
+			"count_only":  schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCountOnly},
+			"refs_only":   schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityRefsOnly},
+			"total_count": schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionEntityTotalCount},
+			"projection":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityProjection},
+			"projection_results": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntityProjectionResults,
+				ElementType: jsontypes.NormalizedType{}},
+			"refs": schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"systems": schema.ListNestedAttribute{Computed: true, Description: descriptionSystemsSystems, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+					"ref":         schema.StringAttribute{Computed: true, Description: descriptionSystemsRef},
+					"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+					"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+					"owner":       schema.StringAttribute{Computed: true, Description: descriptionSystemSpecOwner},
+					"domain":      schema.StringAttribute{Computed: true, Description: descriptionSystemSpecDomain},
+					"tags":        schema.ListAttribute{Computed: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+				},
+			}},
+		},
+	}
+
+	// The map holds the items of the list keyed by their references.
+	resp.Schema.Attributes["entities_by_ref"] = schema.MapNestedAttribute{Computed: true, MarkdownDescription: descriptionEntitiesByRef,
+		NestedObject: resp.Schema.Attributes["systems"].(schema.ListNestedAttribute).NestedObject}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *systemsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *systemsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state systemsDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileEntityRegexp(state.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	titleRegex := compileEntityRegexp(state.TitleRegex, path.Root("title_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := "kind=system"
+	if !state.Namespace.IsNull() {
+		filter += ",metadata.namespace=" + state.Namespace.ValueString()
+	}
+	// The domain and owner are matched on the partOf and ownedBy relations, which hold the normalized references, unlike the spec.
+	if !state.Domain.IsNull() {
+		namespace := state.Namespace.ValueString()
+		if namespace == "" {
+			namespace = backstage.DefaultNamespaceName
+		}
+		_, namespace, name := parseEntityRef(state.Domain.ValueString(), backstage.KindDomain, namespace)
+		filter += ",relations.partOf=" + canonicalEntityRef(backstage.KindDomain, namespace, name)
+	}
+	if owner := parseOwnerRef(state.Owner, state.Namespace.ValueString()); owner != nil {
+		filter += ",relations.ownedBy=" + owner.Ref.ValueString()
+	}
+	filter += tagsFilter(state.Tags, state.TagsMatch)
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting systems %s from Backstage API", filter))
+	entities, response, err := d.listEntities(ctx, &backstage.ListEntityOptions{
+		Filters: []string{filter},
+		Fields: projectionFields(state.Projection, countFields(state.CountOnly, []string{"kind", "metadata.uid", "metadata.name",
+			"metadata.namespace", "metadata.title", "metadata.description", "metadata.tags", "spec.owner", "spec.domain"})),
+		Order: listEntityOrder(state.OrderBy, orderByName),
+	}, state.Limit, state.Offset, state.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage systems",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage systems: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage systems",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage systems: %s", response.Status)))
+		return
+	}
+
+	state.ID = types.StringValue(filter)
+	state.Systems = []systemsItemModel{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		if !matchesEntityRegexps(e.Metadata, nameRegex, titleRegex) {
+			continue
+		}
+		if !matchesTags(e.Metadata.Tags, state.Tags, state.TagsMatch) {
+			continue
+		}
+
+		system := systemsItemModel{
+			ID:          types.StringValue(e.Metadata.UID),
+			Ref:         types.StringValue(strings.ToLower(fmt.Sprintf("system:%s/%s", e.Metadata.Namespace, e.Metadata.Name))),
+			Name:        types.StringValue(e.Metadata.Name),
+			Namespace:   types.StringValue(e.Metadata.Namespace),
+			Title:       d.optionalString(e.Metadata.Title),
+			Description: d.optionalString(e.Metadata.Description),
+			Owner:       specString(e.Spec, "owner"),
+			Domain:      specString(e.Spec, "domain"),
+			Tags:        []types.String{},
+		}
+
+		for _, t := range e.Metadata.Tags {
+			system.Tags = append(system.Tags, types.StringValue(t))
+		}
+
+		state.Systems = append(state.Systems, system)
+	}
+
+	// Entities ordered by the catalog as set in order_by keep their order.
+	if len(state.OrderBy) == 0 {
+		sort.Slice(state.Systems, func(i, j int) bool { return state.Systems[i].Ref.ValueString() < state.Systems[j].Ref.ValueString() })
+	}
+
+	refs := make([]string, 0, len(state.Systems))
+	for _, s := range state.Systems {
+		refs = append(refs, s.Ref.ValueString())
+	}
+	state.Refs = refsSet(refs)
+	state.TotalCount = types.Int64Value(int64(len(state.Refs)))
+	state.ProjectionResults = d.projectEntities(state.Projection, entities, refs, &resp.Diagnostics)
+	state.EntitiesByRef = entitiesByRef(state.Systems, func(i systemsItemModel) types.String { return i.Ref })
+	if state.RefsOnly.ValueBool() || state.CountOnly.ValueBool() {
+		state.Systems, state.EntitiesByRef = []systemsItemModel{}, map[string]systemsItemModel{}
+	}
+	if state.CountOnly.ValueBool() {
+		state.Refs = []types.String{}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_systems", Filters: []string{filter}})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
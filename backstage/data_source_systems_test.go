@@ -0,0 +1,71 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceSystems(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceSystemsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_systems.test", "id", "kind=system,relations.partOf=domain:default/playback"),
+					resource.TestCheckResourceAttrSet("data.backstage_systems.test", "systems.0.ref"),
+					resource.TestCheckResourceAttr("data.backstage_systems.test", "systems.0.domain", "playback"),
+					resource.TestCheckResourceAttrSet("data.backstage_systems.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceSystemsConfig = `
+data "backstage_systems" "test" {
+  domain = "playback"
+}
+`
+
+func TestAccDataSourceSystems_WithLimit(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_systems" "test" {
+						limit     = 1
+						page_size = 1
+						order_by  = [{ field = "metadata.name" }]
+					}
+				`,
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_systems.test", "systems.#", "1"),
+					resource.TestCheckResourceAttr("data.backstage_systems.test", "entities_by_ref.%", "1"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceSystems_WithRefsOnly(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_systems" "test" {
+						refs_only = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.backstage_systems.test", "refs.0"),
+					resource.TestCheckResourceAttr("data.backstage_systems.test", "systems.#", "0"),
+					resource.TestCheckResourceAttr("data.backstage_systems.test", "entities_by_ref.%", "0"),
+				),
+			},
+		},
+	})
+}
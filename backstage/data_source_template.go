@@ -0,0 +1,424 @@
+package backstage
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/scaffolder"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &templateDataSource{}
+	_ datasource.DataSourceWithConfigure = &templateDataSource{}
+)
+
+// NewTemplateDataSource is a helper function to simplify the provider implementation.
+func NewTemplateDataSource() datasource.DataSource {
+	return &templateDataSource{}
+}
+
+// templateDataSource is the data source implementation.
+type templateDataSource struct {
+	*providerData
+}
+
+type templateDataSourceModel struct {
+	ID                 types.String                `tfsdk:"id"`
+	Name               types.String                `tfsdk:"name"`
+	Namespace          types.String                `tfsdk:"namespace"`
+	ResolvedNamespace  types.String                `tfsdk:"resolved_namespace"`
+	ApiVersion         types.String                `tfsdk:"api_version"`
+	Kind               types.String                `tfsdk:"kind"`
+	ContentHash        types.String                `tfsdk:"content_hash"`
+	Status             *entityStatusModel          `tfsdk:"status"`
+	CatalogURL         types.String                `tfsdk:"catalog_url"`
+	TechDocsURL        types.String                `tfsdk:"techdocs_url"`
+	ScaffolderURL      types.String                `tfsdk:"scaffolder_url"`
+	Metadata           *entityMetadataModel        `tfsdk:"metadata"`
+	Relations          []entityRelationModel       `tfsdk:"relations"`
+	Spec               *templateSpecModel          `tfsdk:"spec"`
+	EntityPickers      []templateEntityPickerModel `tfsdk:"entity_pickers"`
+	ParsedOwner        *entityRefModel             `tfsdk:"parsed_owner"`
+	UnavailableActions []types.String              `tfsdk:"unavailable_actions"`
+	AnnotationKeys     []types.String              `tfsdk:"annotation_keys"`
+}
+
+type templateSpecModel struct {
+	Type       types.String         `tfsdk:"type"`
+	Owner      types.String         `tfsdk:"owner"`
+	Parameters jsontypes.Normalized `tfsdk:"parameters"`
+	Steps      jsontypes.Normalized `tfsdk:"steps"`
+	Output     jsontypes.Normalized `tfsdk:"output"`
+}
+
+type templateEntityPickerModel struct {
+	Parameter            types.String   `tfsdk:"parameter"`
+	Step                 types.Int64    `tfsdk:"step"`
+	Field                types.String   `tfsdk:"field"`
+	Required             types.Bool     `tfsdk:"required"`
+	AllowedKinds         []types.String `tfsdk:"allowed_kinds"`
+	DefaultKind          types.String   `tfsdk:"default_kind"`
+	DefaultNamespace     types.String   `tfsdk:"default_namespace"`
+	AllowArbitraryValues types.Bool     `tfsdk:"allow_arbitrary_values"`
+	CatalogFilters       []types.String `tfsdk:"catalog_filters"`
+}
+
+const (
+	descriptionTemplateScaffolderURL = "URL of the page of the Backstage UI to create software from the template, e.g. " +
+		"`https://demo.backstage.io/create/templates/default/react-ssr-template`."
+	kindTemplate = "Template"
+
+	pathScaffolderActions = "/api/scaffolder/v2/actions"
+
+	descriptionTemplateSpecType       = "The type of the template, e.g. `service` or `website`."
+	descriptionTemplateSpecOwner      = "An entity reference to the owner of the template."
+	descriptionTemplateSpecParameters = "JSON encoded parameters of the template, a JSON schema or a list of them, one per step of the template form."
+	descriptionTemplateSpecSteps      = "JSON encoded list of the steps of the template, executed by the scaffolder in order."
+	descriptionTemplateSpecOutput     = "JSON encoded output of the template, e.g. links shown to the user after the template is executed."
+	descriptionTemplateEntityPickers  = "Parameters of the template picking entities from the catalog, with the constraints they put on the entity " +
+		"references supplied for them, sorted by step and name."
+	descriptionTemplateUnavailableActions = "Actions used by the steps of the template that are not installed in the scaffolder, sorted, e.g. " +
+		"`publish:gitlab` when the GitLab module is missing. Executions of the template would fail at these steps. Not set, if the installed " +
+		"actions could not be listed."
+	descriptionTemplatePickerParameter            = "Name of the parameter."
+	descriptionTemplatePickerStep                 = "Index of the step of the template form the parameter belongs to."
+	descriptionTemplatePickerField                = "Name of the form field of the parameter, e.g. `EntityPicker` or `OwnerPicker`."
+	descriptionTemplatePickerRequired             = "Whether the parameter is required by its step."
+	descriptionTemplatePickerAllowedKinds         = "Kinds of the entities that can be picked."
+	descriptionTemplatePickerDefaultKind          = "Kind assumed for entity references without a kind."
+	descriptionTemplatePickerDefaultNamespace     = "Namespace assumed for entity references without a namespace."
+	descriptionTemplatePickerAllowArbitraryValues = "Whether values not matching any entity are accepted."
+	descriptionTemplatePickerCatalogFilters       = "Catalog filters the picked entity must match at least one of, in the format of the `filters` of " +
+		"`backstage_entities`."
+)
+
+// Metadata returns the data source type name.
+func (d *templateDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_template"
+}
+
+// Schema defines the schema for the data source.
+func (d *templateDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a specific " +
+			"[Template entity](https://backstage.io/docs/features/software-templates/writing-templates) from Backstage Software Catalog.",
+		Attributes: map[string]schema.Attribute{
+			"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+			"content_hash": schema.StringAttribute{Computed: true, Description: descriptionEntityContentHash},
+			"status": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatus, Attributes: map[string]schema.Attribute{
+				"items": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityStatusItems, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"type":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemType},
+						"level":   schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemLevel},
+						"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemMessage},
+						"error": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityStatusItemError, Attributes: map[string]schema.Attribute{
+							"name":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrName},
+							"message": schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrMsg},
+							"code":    schema.StringAttribute{Computed: true, Description: descriptionEntityStatusItemErrCode},
+						}},
+					},
+				}},
+			}},
+			"catalog_url":    schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityCatalogURL},
+			"techdocs_url":   schema.StringAttribute{Computed: true, MarkdownDescription: descriptionEntityTechDocsURL},
+			"scaffolder_url": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionTemplateScaffolderURL},
+			"name": schema.StringAttribute{Required: true, Description: descriptionEntityMetadataName, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(
+					regexp.MustCompile(patternEntityName),
+					"must follow Backstage format restrictions",
+				),
+			}},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionEntityMetadataNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(
+					regexp.MustCompile(patternEntityName),
+					"must follow Backstage format restrictions",
+				),
+			}},
+			"resolved_namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityResolvedNamespace},
+			"api_version":        schema.StringAttribute{Computed: true, Description: descriptionEntityApiVersion},
+			"kind":               schema.StringAttribute{Computed: true, Description: descriptionEntityKind},
+			"metadata": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityMetadata, Attributes: map[string]schema.Attribute{
+				"uid":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+				"etag":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataEtag},
+				"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+				"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+				"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+				"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+				"labels":      schema.MapAttribute{Computed: true, Description: descriptionEntityMetadataLabels, ElementType: types.StringType},
+				"annotations": schema.MapAttribute{Computed: true, Description: descriptionEntityMetadataAnnotations, ElementType: types.StringType},
+				"tags":        schema.ListAttribute{Computed: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+				"links": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityMetadataLinks, NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"url":   schema.StringAttribute{Computed: true, Description: descriptionEntityLinkURL},
+						"title": schema.StringAttribute{Computed: true, Description: descriptionEntityLinkTitle},
+						"icon":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkIco},
+						"type":  schema.StringAttribute{Computed: true, Description: descriptionEntityLinkType},
+					},
+				}},
+				"sensitive_annotations": schema.MapAttribute{Computed: true, Sensitive: true, Description: descriptionEntityMetadataSensitiveAnnotations,
+					ElementType: types.StringType},
+			}},
+			"relations": schema.ListNestedAttribute{Computed: true, Description: descriptionEntityRelations, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"type":       schema.StringAttribute{Computed: true, Description: descriptionEntityRelationType},
+					"target_ref": schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetRef},
+					"target": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityRelationTarget,
+						Attributes: map[string]schema.Attribute{
+							"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetName},
+							"kind":      schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetKind},
+							"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityRelationTargetNamespace},
+						}},
+				},
+			}},
+			"spec": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntitySpec, Attributes: map[string]schema.Attribute{
+				"type":       schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecType},
+				"owner":      schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecOwner},
+				"parameters": schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecParameters, CustomType: jsontypes.NormalizedType{}},
+				"steps":      schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecSteps, CustomType: jsontypes.NormalizedType{}},
+				"output":     schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecOutput, CustomType: jsontypes.NormalizedType{}},
+			}},
+			"entity_pickers": schema.ListNestedAttribute{Computed: true, Description: descriptionTemplateEntityPickers,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"parameter":              schema.StringAttribute{Computed: true, Description: descriptionTemplatePickerParameter},
+						"step":                   schema.Int64Attribute{Computed: true, Description: descriptionTemplatePickerStep},
+						"field":                  schema.StringAttribute{Computed: true, Description: descriptionTemplatePickerField},
+						"required":               schema.BoolAttribute{Computed: true, Description: descriptionTemplatePickerRequired},
+						"allowed_kinds":          schema.ListAttribute{Computed: true, Description: descriptionTemplatePickerAllowedKinds, ElementType: types.StringType},
+						"default_kind":           schema.StringAttribute{Computed: true, Description: descriptionTemplatePickerDefaultKind},
+						"default_namespace":      schema.StringAttribute{Computed: true, Description: descriptionTemplatePickerDefaultNamespace},
+						"allow_arbitrary_values": schema.BoolAttribute{Computed: true, Description: descriptionTemplatePickerAllowArbitraryValues},
+						"catalog_filters": schema.ListAttribute{Computed: true, Description: descriptionTemplatePickerCatalogFilters,
+							ElementType: types.StringType},
+					},
+				}},
+			"parsed_owner": schema.SingleNestedAttribute{Computed: true, Description: descriptionEntityParsedOwner, Attributes: map[string]schema.Attribute{
+				"ref":       schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRef},
+				"kind":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefKind},
+				"namespace": schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefNamespace},
+				"name":      schema.StringAttribute{Computed: true, Description: descriptionEntityParsedRefName},
+			}},
+			"unavailable_actions": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionTemplateUnavailableActions,
+				ElementType: types.StringType},
+			"annotation_keys": schema.ListAttribute{Optional: true, Description: descriptionEntityAnnotationKeys, ElementType: types.StringType},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *templateDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *templateDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state templateDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	state.ResolvedNamespace = d.resolveNamespace(state.Namespace)
+	name, namespace := state.Name.ValueString(), state.ResolvedNamespace.ValueString()
+
+	if !d.checkAccess(kindTemplate, namespace, &resp.Diagnostics) {
+		return
+	}
+
+	// The client has no typed service for templates, so the template is read from the list of entities.
+	tflog.Debug(ctx, fmt.Sprintf("Getting Template kind %s/%s from Backstage API", name, namespace))
+	templates, response, err := d.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: []string{fmt.Sprintf("kind=template,metadata.namespace=%s,metadata.name=%s", namespace, name)},
+	})
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage Template kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage Template kind %s/%s: %s", namespace, name, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage Template kind",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage Template kind %s/%s: %s", namespace, name, response.Status)))
+		return
+	}
+
+	if len(templates) == 0 {
+		resp.Diagnostics.AddAttributeError(path.Root("name"), "Backstage Template kind not found",
+			d.withRequestID(fmt.Sprintf("Backstage Template kind %s/%s does not exist.", namespace, name)))
+		return
+	}
+
+	template := templates[0]
+	state.ID = types.StringValue(template.Metadata.UID)
+	state.ContentHash = entityContentHash(template)
+	state.Status = entityStatus(template.Status)
+	state.ApiVersion = types.StringValue(template.ApiVersion)
+	state.Kind = types.StringValue(template.Kind)
+
+	for _, i := range template.Relations {
+		state.Relations = append(state.Relations, entityRelationModel{
+			Type:      types.StringValue(i.Type),
+			TargetRef: types.StringValue(canonicalTargetRef(i.TargetRef)),
+			Target: &entityRelationTargetModel{
+				Kind:      types.StringValue(i.Target.Kind),
+				Name:      types.StringValue(i.Target.Name),
+				Namespace: types.StringValue(i.Target.Namespace)},
+		})
+	}
+
+	state.Spec = &templateSpecModel{
+		Type:       specString(template.Spec, "type"),
+		Owner:      specString(template.Spec, "owner"),
+		Parameters: specJSON(template.Spec, "parameters", &resp.Diagnostics),
+		Steps:      specJSON(template.Spec, "steps", &resp.Diagnostics),
+		Output:     specJSON(template.Spec, "output", &resp.Diagnostics),
+	}
+
+	state.ParsedOwner = parseOwnerRef(state.Spec.Owner, template.Metadata.Namespace)
+	state.UnavailableActions = d.unavailableActions(ctx, template.Spec["steps"], &resp.Diagnostics)
+
+	state.EntityPickers = []templateEntityPickerModel{}
+	for _, c := range scaffolder.ParsePickerConstraints(template.Spec["parameters"]) {
+		picker := templateEntityPickerModel{
+			Parameter:            types.StringValue(c.Parameter),
+			Step:                 types.Int64Value(int64(c.Step)),
+			Field:                types.StringValue(c.Field),
+			Required:             types.BoolValue(c.Required),
+			DefaultKind:          d.optionalString(c.DefaultKind),
+			DefaultNamespace:     d.optionalString(c.DefaultNamespace),
+			AllowArbitraryValues: types.BoolValue(c.AllowArbitraryValues),
+		}
+		for _, k := range c.AllowedKinds {
+			picker.AllowedKinds = append(picker.AllowedKinds, types.StringValue(k))
+		}
+		for _, f := range c.CatalogFilters {
+			picker.CatalogFilters = append(picker.CatalogFilters, types.StringValue(f))
+		}
+		state.EntityPickers = append(state.EntityPickers, picker)
+	}
+
+	state.Metadata = &entityMetadataModel{
+		UID:         types.StringValue(template.Metadata.UID),
+		Etag:        types.StringValue(template.Metadata.Etag),
+		Name:        types.StringValue(template.Metadata.Name),
+		Namespace:   types.StringValue(template.Metadata.Namespace),
+		Title:       d.optionalString(template.Metadata.Title),
+		Description: d.optionalString(template.Metadata.Description),
+		Annotations: map[string]string{},
+		Labels:      map[string]string{},
+	}
+
+	for k, v := range template.Metadata.Labels {
+		state.Metadata.Labels[k] = v
+	}
+
+	for k, v := range template.Metadata.Annotations {
+		state.Metadata.Annotations[k] = v
+	}
+
+	for _, v := range template.Metadata.Tags {
+		state.Metadata.Tags = append(state.Metadata.Tags, types.StringValue(v))
+	}
+
+	for _, v := range template.Metadata.Links {
+		state.Metadata.Links = append(state.Metadata.Links, entityLinkModel{
+			URL:   types.StringValue(v.URL),
+			Title: d.optionalString(v.Title),
+			Icon:  d.optionalString(v.Icon),
+			Type:  d.optionalString(v.Type),
+		})
+	}
+
+	state.CatalogURL, state.TechDocsURL = d.entityURLs(state.Kind, state.Metadata)
+	state.ScaffolderURL = d.scaffolderURL(state.Metadata)
+	filterAnnotations(state.Metadata, state.AnnotationKeys)
+	d.protectAnnotations(state.Metadata)
+	d.checkRelationTypes(state.Relations, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_template", EntityRef: fmt.Sprintf("template:%s/%s", namespace, name)})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// specJSON returns the field of the spec of an entity encoded as JSON, or null if the field is not set.
+func specJSON(spec map[string]interface{}, field string, diags *diag.Diagnostics) jsontypes.Normalized {
+	v, ok := spec[field]
+	if !ok || v == nil {
+		return jsontypes.NewNormalizedNull()
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		diags.AddError("Error parsing Backstage entity spec", fmt.Sprintf("Could not encode field %s of spec: %s", field, err.Error()))
+		return jsontypes.NewNormalizedNull()
+	}
+
+	return jsontypes.NewNormalizedValue(string(b))
+}
+
+// unavailableActions returns the actions used by the steps that are not installed in the scaffolder. It returns nil with a warning, if the
+// installed actions can not be listed, e.g. because the scaffolder does not support the v2 API.
+func (d *templateDataSource) unavailableActions(ctx context.Context, steps interface{}, diags *diag.Diagnostics) []types.String {
+	tflog.Debug(ctx, "Listing actions installed in Backstage scaffolder")
+	var installed []scaffolder.Action
+	response, err := d.doJSON(ctx, http.MethodGet, pathScaffolderActions, nil, &installed)
+	if err == nil && response.StatusCode != http.StatusOK {
+		err = fmt.Errorf("%s", response.Status)
+	}
+	if err != nil {
+		diags.AddWarning("Could not list actions of Backstage scaffolder",
+			d.withRequestID(fmt.Sprintf("Could not list actions installed in Backstage scaffolder, so actions of the template are not checked: %s",
+				err.Error())))
+		return nil
+	}
+
+	actions := []types.String{}
+	for _, a := range scaffolder.UnavailableActions(steps, installed) {
+		actions = append(actions, types.StringValue(a))
+	}
+
+	return actions
+}
+
+// scaffolderURL returns the URL of the page of the Backstage UI to create software from the template, or null if its name is not known.
+func (d *templateDataSource) scaffolderURL(metadata *entityMetadataModel) types.String {
+	if metadata == nil || metadata.Name.IsNull() {
+		return types.StringNull()
+	}
+
+	namespace := metadata.Namespace.ValueString()
+	if namespace == "" {
+		namespace = backstage.DefaultNamespaceName
+	}
+
+	return types.StringValue(d.appURL + "/create/templates/" + url.PathEscape(namespace) + "/" + url.PathEscape(metadata.Name.ValueString()))
+}
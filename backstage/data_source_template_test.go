@@ -0,0 +1,107 @@
+package backstage
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTemplate(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceTemplateConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_template.test", "name", "react-ssr-template"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "kind", "Template"),
+					resource.TestCheckResourceAttrSet("data.backstage_template.test", "spec.type"),
+					resource.TestCheckResourceAttrSet("data.backstage_template.test", "spec.parameters"),
+					resource.TestCheckResourceAttrSet("data.backstage_template.test", "spec.steps"),
+					resource.TestCheckResourceAttrSet("data.backstage_template.test", "parsed_owner.ref"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "unavailable_actions.#", "0"),
+					resource.TestCheckResourceAttrSet("data.backstage_template.test", "entity_pickers.#"),
+					resource.TestMatchResourceAttr("data.backstage_template.test", "catalog_url",
+						regexp.MustCompile("/catalog/default/template/react-ssr-template$")),
+					resource.TestMatchResourceAttr("data.backstage_template.test", "scaffolder_url",
+						regexp.MustCompile("/create/templates/default/react-ssr-template$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceTemplate_EntityPickers(t *testing.T) {
+	const baseURL = "http://backstage.test"
+	defer gock.Off()
+	// Probes are registered first, as the path of the list of entities matches theirs too.
+	for _, probe := range []string{pathEntitiesByQuery, pathEntityFacets, pathScaffolderActions} {
+		gock.New(baseURL).Persist().Get(probe).Reply(http.StatusNotFound)
+	}
+	gock.New(baseURL).Persist().
+		Get("/api/catalog/entities$").
+		Reply(http.StatusOK).
+		JSON([]map[string]interface{}{{
+			"apiVersion": "scaffolder.backstage.io/v1beta3",
+			"kind":       "Template",
+			"metadata":   map[string]interface{}{"name": "service", "namespace": "default", "uid": "service-uid"},
+			"spec": map[string]interface{}{
+				"type":  "service",
+				"owner": "group:default/platform",
+				"parameters": []map[string]interface{}{{
+					"required": []string{"owner"},
+					"properties": map[string]interface{}{
+						"owner": map[string]interface{}{
+							"type":       "string",
+							"ui:field":   "OwnerPicker",
+							"ui:options": map[string]interface{}{"catalogFilter": map[string]interface{}{"kind": "Group", "spec.type": "team"}},
+						},
+						"system": map[string]interface{}{
+							"type":       "string",
+							"ui:field":   "EntityPicker",
+							"ui:options": map[string]interface{}{"allowedKinds": []string{"System"}, "defaultNamespace": "default"},
+						},
+					},
+				}},
+				"steps": []map[string]interface{}{},
+			},
+		}})
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "backstage" {
+					  base_url = %q
+					}
+
+					data "backstage_template" "test" {
+					  name = "service"
+					}
+				`, baseURL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.#", "2"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.0.parameter", "owner"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.0.field", "OwnerPicker"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.0.required", "true"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.0.catalog_filters.0", "kind=Group,spec.type=team"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.1.parameter", "system"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.1.allowed_kinds.0", "System"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.1.default_namespace", "default"),
+					resource.TestCheckResourceAttr("data.backstage_template.test", "entity_pickers.1.required", "false"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceTemplateConfig = `
+data "backstage_template" "test" {
+  name = "react-ssr-template"
+}
+`
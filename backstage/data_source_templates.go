@@ -0,0 +1,273 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &templatesDataSource{}
+	_ datasource.DataSourceWithConfigure = &templatesDataSource{}
+)
+
+// NewTemplatesDataSource is a helper function to simplify the provider implementation.
+func NewTemplatesDataSource() datasource.DataSource {
+	return &templatesDataSource{}
+}
+
+// templatesDataSource is the data source implementation.
+type templatesDataSource struct {
+	*providerData
+}
+
+type templatesDataSourceModel struct {
+	ID                types.String                  `tfsdk:"id"`
+	Namespace         types.String                  `tfsdk:"namespace"`
+	Owner             types.String                  `tfsdk:"owner"`
+	Tags              []types.String                `tfsdk:"tags"`
+	TagsMatch         types.String                  `tfsdk:"tags_match"`
+	Limit             types.Int64                   `tfsdk:"limit"`
+	Offset            types.Int64                   `tfsdk:"offset"`
+	PageSize          types.Int64                   `tfsdk:"page_size"`
+	OrderBy           []entityOrderModel            `tfsdk:"order_by"`
+	NameRegex         types.String                  `tfsdk:"name_regex"`
+	TitleRegex        types.String                  `tfsdk:"title_regex"`
+	CountOnly         types.Bool                    `tfsdk:"count_only"`
+	RefsOnly          types.Bool                    `tfsdk:"refs_only"`
+	TotalCount        types.Int64                   `tfsdk:"total_count"`
+	Projection        types.String                  `tfsdk:"projection"`
+	ProjectionResults []jsontypes.Normalized        `tfsdk:"projection_results"`
+	Refs              []types.String                `tfsdk:"refs"`
+	Templates         []templatesItemModel          `tfsdk:"templates"`
+	EntitiesByRef     map[string]templatesItemModel `tfsdk:"entities_by_ref"`
+}
+
+type templatesItemModel struct {
+	ID          types.String         `tfsdk:"id"`
+	Ref         types.String         `tfsdk:"ref"`
+	Name        types.String         `tfsdk:"name"`
+	Namespace   types.String         `tfsdk:"namespace"`
+	Title       types.String         `tfsdk:"title"`
+	Description types.String         `tfsdk:"description"`
+	Type        types.String         `tfsdk:"type"`
+	Owner       types.String         `tfsdk:"owner"`
+	Tags        []types.String       `tfsdk:"tags"`
+	Parameters  jsontypes.Normalized `tfsdk:"parameters"`
+}
+
+const (
+	descriptionTemplatesNamespace = "Namespace of the templates. If not set, templates of all namespaces are returned."
+	descriptionTemplatesOwner     = "An entity reference to the owner of the templates, e.g. `group:default/team-a`. It is normalized the way " +
+		"Backstage does, so `team-a` matches templates owned by `group:default/team-a`."
+	descriptionTemplatesTags = "Tags of the templates, e.g. `recommended`. If set, only the templates having any of the " +
+		"tags, or all of them as set in `tags_match`, are returned."
+	descriptionTemplatesTemplates  = "Templates sorted by their entity references."
+	descriptionTemplatesRef        = "Entity reference to the template, e.g. `template:default/react-ssr-template`."
+	descriptionTemplatesDataSource = "Identifier of the list of templates, the catalog filter used to read them."
+)
+
+// Metadata returns the data source type name.
+func (d *templatesDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_templates"
+}
+
+// Schema defines the schema for the data source.
+func (d *templatesDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a list of " +
+			"[Template entities](https://backstage.io/docs/features/software-templates/writing-templates) from Backstage Software Catalog, " +
+			"including the JSON schemas of their parameters, filtered by the catalog on their owner and tags. Useful to enumerate the " +
+			"templates available to self-service portals.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionTemplatesDataSource},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionTemplatesNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"owner": schema.StringAttribute{Optional: true, Description: descriptionTemplatesOwner, Validators: catalogFilterValidators},
+			"tags": schema.ListAttribute{Optional: true, Description: descriptionTemplatesTags, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(catalogFilterValidators...)}},
+			"tags_match": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTagsMatch, Validators: []validator.String{
+				stringvalidator.OneOf(tagsMatchAny, tagsMatchAll),
+			}},
+			"limit": schema.Int64Attribute{Optional: true, Description: descriptionEntityLimit, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"offset": schema.Int64Attribute{Optional: true, Description: descriptionEntityOffset, Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			}},
+			"page_size": schema.Int64Attribute{Optional: true, Description: descriptionEntityPageSize, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"order_by": schema.ListNestedAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderBy, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{Required: true, MarkdownDescription: descriptionEntityOrderByField, Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					}},
+					"direction": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderByDirection, Validators: []validator.String{
+						stringvalidator.OneOf(orderAscending, orderDescending),
+					}},
+				},
+			}},
+			"name_regex":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityNameRegex},
+			"title_regex": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityTitleRegex},
+			"count_only":  schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCountOnly},
+			"refs_only":   schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityRefsOnly},
+			"total_count": schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionEntityTotalCount},
+			"projection":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityProjection},
+			"projection_results": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntityProjectionResults,
+				ElementType: jsontypes.NormalizedType{}},
+			"refs": schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"templates": schema.ListNestedAttribute{Computed: true, Description: descriptionTemplatesTemplates, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":          schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+					"ref":         schema.StringAttribute{Computed: true, Description: descriptionTemplatesRef},
+					"name":        schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"namespace":   schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"title":       schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataTitle},
+					"description": schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataDescription},
+					"type":        schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecType},
+					"owner":       schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecOwner},
+					"tags":        schema.ListAttribute{Computed: true, Description: descriptionEntityMetadataTags, ElementType: types.StringType},
+					"parameters": schema.StringAttribute{Computed: true, Description: descriptionTemplateSpecParameters,
+						CustomType: jsontypes.NormalizedType{}},
+				},
+			}},
+		},
+	}
+
+	// The map holds the items of the list keyed by their references.
+	resp.Schema.Attributes["entities_by_ref"] = schema.MapNestedAttribute{Computed: true, MarkdownDescription: descriptionEntitiesByRef,
+		NestedObject: resp.Schema.Attributes["templates"].(schema.ListNestedAttribute).NestedObject}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *templatesDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *templatesDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state templatesDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileEntityRegexp(state.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	titleRegex := compileEntityRegexp(state.TitleRegex, path.Root("title_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := "kind=template"
+	if !state.Namespace.IsNull() {
+		filter += ",metadata.namespace=" + state.Namespace.ValueString()
+	}
+	// The owner is matched on the ownedBy relation, which holds the normalized reference, unlike spec.owner.
+	if owner := parseOwnerRef(state.Owner, state.Namespace.ValueString()); owner != nil {
+		filter += ",relations.ownedBy=" + owner.Ref.ValueString()
+	}
+	filter += tagsFilter(state.Tags, state.TagsMatch)
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting templates %s from Backstage API", filter))
+	entities, response, err := d.listEntities(ctx, &backstage.ListEntityOptions{
+		Filters: []string{filter},
+		Fields: projectionFields(state.Projection, countFields(state.CountOnly, []string{"kind", "metadata.uid", "metadata.name",
+			"metadata.namespace", "metadata.title", "metadata.description", "metadata.tags", "spec.type", "spec.owner", "spec.parameters"})),
+		Order: listEntityOrder(state.OrderBy, orderByName),
+	}, state.Limit, state.Offset, state.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage templates",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage templates: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage templates",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage templates: %s", response.Status)))
+		return
+	}
+
+	state.ID = types.StringValue(filter)
+	state.Templates = []templatesItemModel{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		if !matchesEntityRegexps(e.Metadata, nameRegex, titleRegex) {
+			continue
+		}
+		if !matchesTags(e.Metadata.Tags, state.Tags, state.TagsMatch) {
+			continue
+		}
+
+		template := templatesItemModel{
+			ID:          types.StringValue(e.Metadata.UID),
+			Ref:         types.StringValue(strings.ToLower(fmt.Sprintf("template:%s/%s", e.Metadata.Namespace, e.Metadata.Name))),
+			Name:        types.StringValue(e.Metadata.Name),
+			Namespace:   types.StringValue(e.Metadata.Namespace),
+			Title:       d.optionalString(e.Metadata.Title),
+			Description: d.optionalString(e.Metadata.Description),
+			Type:        specString(e.Spec, "type"),
+			Owner:       specString(e.Spec, "owner"),
+			Tags:        []types.String{},
+			Parameters:  specJSON(e.Spec, "parameters", &resp.Diagnostics),
+		}
+
+		for _, t := range e.Metadata.Tags {
+			template.Tags = append(template.Tags, types.StringValue(t))
+		}
+
+		state.Templates = append(state.Templates, template)
+	}
+
+	// Entities ordered by the catalog as set in order_by keep their order.
+	if len(state.OrderBy) == 0 {
+		sort.Slice(state.Templates, func(i, j int) bool {
+			return state.Templates[i].Ref.ValueString() < state.Templates[j].Ref.ValueString()
+		})
+	}
+
+	refs := make([]string, 0, len(state.Templates))
+	for _, t := range state.Templates {
+		refs = append(refs, t.Ref.ValueString())
+	}
+	state.Refs = refsSet(refs)
+	state.TotalCount = types.Int64Value(int64(len(state.Refs)))
+	state.ProjectionResults = d.projectEntities(state.Projection, entities, refs, &resp.Diagnostics)
+	state.EntitiesByRef = entitiesByRef(state.Templates, func(i templatesItemModel) types.String { return i.Ref })
+	if state.RefsOnly.ValueBool() || state.CountOnly.ValueBool() {
+		state.Templates, state.EntitiesByRef = []templatesItemModel{}, map[string]templatesItemModel{}
+	}
+	if state.CountOnly.ValueBool() {
+		state.Refs = []types.String{}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_templates", Filters: []string{filter}})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,31 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceTemplates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceTemplatesConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_templates.test", "id", "kind=template,metadata.namespace=default,metadata.tags=react"),
+					resource.TestCheckResourceAttrSet("data.backstage_templates.test", "templates.0.ref"),
+					resource.TestCheckResourceAttrSet("data.backstage_templates.test", "templates.0.parameters"),
+					resource.TestCheckResourceAttrSet("data.backstage_templates.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceTemplatesConfig = `
+data "backstage_templates" "test" {
+  namespace = "default"
+  tags      = ["react"]
+}
+`
@@ -0,0 +1,327 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ datasource.DataSource              = &usersDataSource{}
+	_ datasource.DataSourceWithConfigure = &usersDataSource{}
+)
+
+// NewUsersDataSource is a helper function to simplify the provider implementation.
+func NewUsersDataSource() datasource.DataSource {
+	return &usersDataSource{}
+}
+
+// usersDataSource is the data source implementation.
+type usersDataSource struct {
+	*providerData
+}
+
+type usersDataSourceModel struct {
+	ID                types.String              `tfsdk:"id"`
+	Namespace         types.String              `tfsdk:"namespace"`
+	MemberOf          types.String              `tfsdk:"member_of"`
+	EmailDomains      []types.String            `tfsdk:"email_domains"`
+	ProfileMatches    types.Map                 `tfsdk:"profile_matches"`
+	Limit             types.Int64               `tfsdk:"limit"`
+	Offset            types.Int64               `tfsdk:"offset"`
+	PageSize          types.Int64               `tfsdk:"page_size"`
+	OrderBy           []entityOrderModel        `tfsdk:"order_by"`
+	NameRegex         types.String              `tfsdk:"name_regex"`
+	CountOnly         types.Bool                `tfsdk:"count_only"`
+	RefsOnly          types.Bool                `tfsdk:"refs_only"`
+	TotalCount        types.Int64               `tfsdk:"total_count"`
+	Projection        types.String              `tfsdk:"projection"`
+	ProjectionResults []jsontypes.Normalized    `tfsdk:"projection_results"`
+	Refs              []types.String            `tfsdk:"refs"`
+	Users             []usersItemModel          `tfsdk:"users"`
+	EntitiesByRef     map[string]usersItemModel `tfsdk:"entities_by_ref"`
+}
+
+type usersItemModel struct {
+	ID          types.String   `tfsdk:"id"`
+	Ref         types.String   `tfsdk:"ref"`
+	Name        types.String   `tfsdk:"name"`
+	Namespace   types.String   `tfsdk:"namespace"`
+	DisplayName types.String   `tfsdk:"display_name"`
+	Email       types.String   `tfsdk:"email"`
+	MemberOf    []types.String `tfsdk:"member_of"`
+}
+
+const (
+	relationMemberOf = "memberOf"
+
+	descriptionUsersNamespace = "Namespace of the users. If not set, users of all namespaces are returned."
+	descriptionUsersMemberOf  = "An entity reference to a group, e.g. `group:default/team-a`. If set, only the direct members of the group are " +
+		"returned. The kind and namespace default to `group` and `namespace` of the data source, or `default` if it is not set."
+	descriptionUsersEmailDomains = "Domains of the emails of the users, e.g. `example.com`. If set, only the users with an email in one of the " +
+		"domains are returned, which leaves out bot and service accounts using other domains. Domains are matched case-insensitively."
+	descriptionUsersProfileMatches = "Regular expressions the attributes of `spec.profile` of the users must match, keyed by the attributes, " +
+		"e.g. `{ email = \"^[a-z]+[.][a-z]+@\" }` to only return users with `first.last` emails. Users without a matched attribute are left out."
+	descriptionUsersUsers        = "Users sorted by their entity references."
+	descriptionUsersRef          = "Entity reference to the user, e.g. `user:default/guest`."
+	descriptionUsersItemMemberOf = "Sorted entity references to the groups the user is a direct member of, from its `memberOf` relations."
+	descriptionUsersDataSource   = "Identifier of the list of users, the catalog filter used to read them followed by the filters applied to the " +
+		"returned users."
+)
+
+// Metadata returns the data source type name.
+func (d *usersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_users"
+}
+
+// Schema defines the schema for the data source.
+func (d *usersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this data source to get a list of " +
+			"[User entities](https://backstage.io/docs/features/software-catalog/descriptor-format#kind-user) from Backstage Software " +
+			"Catalog, optionally only the members of a group. Useful to grant access to every member of a team without a separate directory " +
+			"integration.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionUsersDataSource},
+			"namespace": schema.StringAttribute{Optional: true, Description: descriptionUsersNamespace, Validators: []validator.String{
+				stringvalidator.LengthBetween(1, 63),
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
+			}},
+			"member_of": schema.StringAttribute{Optional: true, Description: descriptionUsersMemberOf, Validators: catalogFilterValidators},
+			"email_domains": schema.ListAttribute{Optional: true, Description: descriptionUsersEmailDomains, ElementType: types.StringType,
+				Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))}},
+			"profile_matches": schema.MapAttribute{Optional: true, Description: descriptionUsersProfileMatches, ElementType: types.StringType,
+				Validators: []validator.Map{mapvalidator.SizeAtLeast(1), mapvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))}},
+			"limit": schema.Int64Attribute{Optional: true, Description: descriptionEntityLimit, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"offset": schema.Int64Attribute{Optional: true, Description: descriptionEntityOffset, Validators: []validator.Int64{
+				int64validator.AtLeast(0),
+			}},
+			"page_size": schema.Int64Attribute{Optional: true, Description: descriptionEntityPageSize, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"order_by": schema.ListNestedAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderBy, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"field": schema.StringAttribute{Required: true, MarkdownDescription: descriptionEntityOrderByField, Validators: []validator.String{
+						stringvalidator.LengthAtLeast(1),
+					}},
+					"direction": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityOrderByDirection, Validators: []validator.String{
+						stringvalidator.OneOf(orderAscending, orderDescending),
+					}},
+				},
+			}},
+			"name_regex":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityNameRegex},
+			"count_only":  schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCountOnly},
+			"refs_only":   schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityRefsOnly},
+			"total_count": schema.Int64Attribute{Computed: true, MarkdownDescription: descriptionEntityTotalCount},
+			"projection":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityProjection},
+			"projection_results": schema.ListAttribute{Computed: true, MarkdownDescription: descriptionEntityProjectionResults,
+				ElementType: jsontypes.NormalizedType{}},
+			"refs": schema.SetAttribute{Computed: true, Description: descriptionEntityRefs, ElementType: types.StringType},
+			"users": schema.ListNestedAttribute{Computed: true, Description: descriptionUsersUsers, NestedObject: schema.NestedAttributeObject{
+				Attributes: map[string]schema.Attribute{
+					"id":           schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataUID},
+					"ref":          schema.StringAttribute{Computed: true, Description: descriptionUsersRef},
+					"name":         schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataName},
+					"namespace":    schema.StringAttribute{Computed: true, Description: descriptionEntityMetadataNamespace},
+					"display_name": schema.StringAttribute{Computed: true, Description: descriptionUserSpecProfileDisplayName},
+					"email":        schema.StringAttribute{Computed: true, Description: descriptionUserSpecProfileEmail},
+					"member_of":    schema.ListAttribute{Computed: true, Description: descriptionUsersItemMemberOf, ElementType: types.StringType},
+				},
+			}},
+		},
+	}
+
+	// The map holds the items of the list keyed by their references.
+	resp.Schema.Attributes["entities_by_ref"] = schema.MapNestedAttribute{Computed: true, MarkdownDescription: descriptionEntitiesByRef,
+		NestedObject: resp.Schema.Attributes["users"].(schema.ListNestedAttribute).NestedObject}
+}
+
+// Configure adds the provider configured client to the data source.
+func (d *usersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	d.providerData = req.ProviderData.(*providerData)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *usersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state usersDataSourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	nameRegex := compileEntityRegexp(state.NameRegex, path.Root("name_regex"), &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	filter := "kind=user"
+	if !state.Namespace.IsNull() {
+		filter += ",metadata.namespace=" + state.Namespace.ValueString()
+	}
+	// The group is matched on the memberOf relation, which holds the normalized reference, unlike spec.memberOf.
+	if !state.MemberOf.IsNull() {
+		kind, namespace, name := parseEntityRef(state.MemberOf.ValueString(), "group", state.Namespace.ValueString())
+		filter += ",relations.memberOf=" + canonicalEntityRef(kind, namespace, name)
+	}
+
+	var profileMatches map[string]string
+	resp.Diagnostics.Append(state.ProfileMatches.ElementsAs(ctx, &profileMatches, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	patterns := map[string]*regexp.Regexp{}
+	for attribute, pattern := range profileMatches {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(path.Root("profile_matches").AtMapKey(attribute), "Invalid profile match of Backstage users",
+				fmt.Sprintf("Could not parse regular expression %q: %s", pattern, err.Error()))
+			return
+		}
+		patterns[attribute] = re
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting users %s from Backstage API", filter))
+	entities, response, err := d.listEntities(ctx, &backstage.ListEntityOptions{
+		Filters: []string{filter},
+		Fields: projectionFields(state.Projection, []string{"kind", "metadata.uid", "metadata.name", "metadata.namespace", "spec.profile",
+			"relations"}),
+		Order: listEntityOrder(state.OrderBy, orderByName),
+	}, state.Limit, state.Offset, state.PageSize)
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage users",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage users: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage users",
+			d.withRequestID(fmt.Sprintf("Could not read Backstage users: %s", response.Status)))
+		return
+	}
+
+	// Email domains and profile attributes can not be matched by the catalog filters, so they are applied to the returned users.
+	id := filter
+	domains := make([]string, 0, len(state.EmailDomains))
+	for _, domain := range state.EmailDomains {
+		domains = append(domains, strings.ToLower(strings.TrimPrefix(domain.ValueString(), "@")))
+	}
+	if len(domains) > 0 {
+		id += ",email_domains=" + strings.Join(domains, "|")
+	}
+	attributes := make([]string, 0, len(patterns))
+	for attribute := range patterns {
+		attributes = append(attributes, attribute)
+	}
+	sort.Strings(attributes)
+	for _, attribute := range attributes {
+		id += fmt.Sprintf(",spec.profile.%s=~%s", attribute, profileMatches[attribute])
+	}
+
+	state.ID = types.StringValue(id)
+	state.Users = []usersItemModel{}
+	for _, e := range d.allowedEntities(entities, &resp.Diagnostics) {
+		if !matchesEntityRegexps(e.Metadata, nameRegex, nil) {
+			continue
+		}
+		profile, _ := e.Spec["profile"].(map[string]interface{})
+		if !matchesEmailDomain(profile, domains) || !matchesProfile(profile, patterns) {
+			continue
+		}
+
+		state.Users = append(state.Users, usersItemModel{
+			ID:          types.StringValue(e.Metadata.UID),
+			Ref:         types.StringValue(strings.ToLower(fmt.Sprintf("user:%s/%s", e.Metadata.Namespace, e.Metadata.Name))),
+			Name:        types.StringValue(e.Metadata.Name),
+			Namespace:   types.StringValue(e.Metadata.Namespace),
+			DisplayName: specString(profile, "displayName"),
+			Email:       specString(profile, "email"),
+			MemberOf:    relationTargets(e.Relations, relationMemberOf),
+		})
+	}
+
+	// Entities ordered by the catalog as set in order_by keep their order.
+	if len(state.OrderBy) == 0 {
+		sort.Slice(state.Users, func(i, j int) bool { return state.Users[i].Ref.ValueString() < state.Users[j].Ref.ValueString() })
+	}
+
+	refs := make([]string, 0, len(state.Users))
+	for _, u := range state.Users {
+		refs = append(refs, u.Ref.ValueString())
+	}
+	state.Refs = refsSet(refs)
+	state.TotalCount = types.Int64Value(int64(len(state.Refs)))
+	state.ProjectionResults = d.projectEntities(state.Projection, entities, refs, &resp.Diagnostics)
+	state.EntitiesByRef = entitiesByRef(state.Users, func(i usersItemModel) types.String { return i.Ref })
+	if state.RefsOnly.ValueBool() || state.CountOnly.ValueBool() {
+		state.Users, state.EntitiesByRef = []usersItemModel{}, map[string]usersItemModel{}
+	}
+	if state.CountOnly.ValueBool() {
+		state.Refs = []types.String{}
+	}
+
+	d.recordRead(ctx, audit.Entry{DataSource: "backstage_users", Filters: []string{filter}})
+
+	diags := resp.State.Set(ctx, state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// matchesEmailDomain reports whether the email of the profile is in one of the domains, or whether there are no domains.
+func matchesEmailDomain(profile map[string]interface{}, domains []string) bool {
+	if len(domains) == 0 {
+		return true
+	}
+
+	email, _ := profile["email"].(string)
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	for _, domain := range domains {
+		if strings.EqualFold(email[at+1:], domain) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchesProfile reports whether every attribute of the profile matches its pattern.
+func matchesProfile(profile map[string]interface{}, patterns map[string]*regexp.Regexp) bool {
+	for attribute, pattern := range patterns {
+		value, ok := profile[attribute].(string)
+		if !ok || !pattern.MatchString(value) {
+			return false
+		}
+	}
+
+	return true
+}
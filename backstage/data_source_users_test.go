@@ -0,0 +1,53 @@
+package backstage
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceUsers(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceUsersConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_users.test", "id", "kind=user,metadata.namespace=default,relations.memberOf=group:default/team-a"),
+					resource.TestCheckResourceAttrSet("data.backstage_users.test", "users.0.ref"),
+					resource.TestCheckTypeSetElemAttr("data.backstage_users.test", "users.0.member_of.*", "group:default/team-a"),
+					resource.TestCheckResourceAttrSet("data.backstage_users.test", "refs.#"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceUsersConfig = `
+data "backstage_users" "test" {
+  namespace = "default"
+  member_of = "team-a"
+}
+`
+
+func TestAccDataSourceUsers_WithEmailDomains(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + testAccDataSourceUsersWithEmailDomainsConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_users.test", "id", "kind=user,email_domains=example.com,spec.profile.email=~^[a-z]"),
+					resource.TestCheckResourceAttrSet("data.backstage_users.test", "users.0.email"),
+				),
+			},
+		},
+	})
+}
+
+const testAccDataSourceUsersWithEmailDomainsConfig = `
+data "backstage_users" "test" {
+  email_domains   = ["@Example.com"]
+  profile_matches = { email = "^[a-z]" }
+}
+`
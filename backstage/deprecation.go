@@ -0,0 +1,34 @@
+package backstage
+
+import "fmt"
+
+// deprecation is a deprecated attribute of the provider, of a data source or of a resource.
+type deprecation struct {
+	// address is the deprecated attribute, prefixed with the type name of its data source or resource, or with `provider`, e.g.
+	// `provider.legacy_empty_strings`.
+	address string
+	// message tells what to migrate to. Terraform shows it as warning when the attribute is configured.
+	message string
+}
+
+// deprecations are the active deprecations, sorted by address. Attributes are marked deprecated in their schemas with the message of their
+// deprecation, so the warnings of Terraform and backstage_provider_info list the same deprecations.
+var deprecations = []deprecation{
+	{
+		address: "provider.legacy_empty_strings",
+		message: "legacy_empty_strings is deprecated and will be removed in the next major version of the provider. Compare optional fields " +
+			"of entities to null instead of \"\", and remove the attribute.",
+	},
+}
+
+// deprecationMessage returns the message of the deprecation of the attribute at the address. It panics if the attribute is not deprecated,
+// so schemas cannot mark attributes deprecated without listing them in deprecations.
+func deprecationMessage(address string) string {
+	for _, d := range deprecations {
+		if d.address == address {
+			return d.message
+		}
+	}
+
+	panic(fmt.Sprintf("attribute %s is not listed in deprecations", address))
+}
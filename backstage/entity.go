@@ -0,0 +1,1294 @@
+package backstage
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/transport"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/jmespath/go-jmespath"
+)
+
+// entitiesQueryResult is a page of entities returned by the query endpoint of the catalog.
+type entitiesQueryResult struct {
+	Items      []backstage.Entity `json:"items"`
+	TotalItems int64              `json:"totalItems"`
+	PageInfo   struct {
+		NextCursor *string `json:"nextCursor"`
+		PrevCursor *string `json:"prevCursor"`
+	} `json:"pageInfo"`
+}
+
+type entityRefModel struct {
+	Ref       types.String `tfsdk:"ref"`
+	Kind      types.String `tfsdk:"kind"`
+	Namespace types.String `tfsdk:"namespace"`
+	Name      types.String `tfsdk:"name"`
+}
+
+type entityMovedToModel struct {
+	Namespace types.String `tfsdk:"namespace"`
+	Ref       types.String `tfsdk:"ref"`
+}
+
+type entityAliasedToModel struct {
+	Name types.String `tfsdk:"name"`
+	Ref  types.String `tfsdk:"ref"`
+}
+
+type entityOrderModel struct {
+	Field     types.String `tfsdk:"field"`
+	Direction types.String `tfsdk:"direction"`
+}
+
+type entityRequestInfoModel struct {
+	RequestID  types.String `tfsdk:"request_id"`
+	StatusCode types.Int64  `tfsdk:"status_code"`
+	Headers    types.Map    `tfsdk:"headers"`
+}
+
+type entityGitOpsModel struct {
+	ArgoCD *entityArgoCDModel `tfsdk:"argocd"`
+	Flux   *entityFluxModel   `tfsdk:"flux"`
+	Helm   *entityHelmModel   `tfsdk:"helm"`
+}
+
+type entityArgoCDModel struct {
+	AppName   types.String `tfsdk:"app_name"`
+	Project   types.String `tfsdk:"project"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+type entityFluxModel struct {
+	Name      types.String `tfsdk:"name"`
+	Namespace types.String `tfsdk:"namespace"`
+}
+
+type entityHelmModel struct {
+	ReleaseName types.String `tfsdk:"release_name"`
+	Namespace   types.String `tfsdk:"namespace"`
+}
+
+type entityWaitForModel struct {
+	PreviousEtag   types.String `tfsdk:"previous_etag"`
+	TimeoutSeconds types.Int64  `tfsdk:"timeout_seconds"`
+}
+
+// wellKnownKinds are the kinds of entities defined by Backstage. See
+// https://backstage.io/docs/features/software-catalog/descriptor-format.
+var wellKnownKinds = []string{
+	backstage.KindAPI, backstage.KindComponent, backstage.KindDomain, backstage.KindGroup, backstage.KindLocation, backstage.KindResource,
+	backstage.KindSystem, kindTemplate, backstage.KindUser,
+}
+
+// wellKnownLifecycles are the lifecycles of components and APIs defined by Backstage.
+var wellKnownLifecycles = []string{"experimental", "production", "deprecated"}
+
+// wellKnownRelationTypes are the types of relations defined by Backstage. See
+// https://backstage.io/docs/features/software-catalog/well-known-relations.
+var wellKnownRelationTypes = map[string]bool{
+	"ownedBy": true, "ownerOf": true,
+	"consumesApi": true, "apiConsumedBy": true,
+	"providesApi": true, "apiProvidedBy": true,
+	"dependsOn": true, "dependencyOf": true,
+	"parentOf": true, "childOf": true,
+	"memberOf": true, "hasMember": true,
+	"partOf": true, "hasPart": true,
+}
+
+const (
+	pathEntities        = "/api/catalog/entities"
+	pathEntitiesByQuery = "/api/catalog/entities/by-query"
+	pathEntityFacets    = "/api/catalog/entity-facets"
+	pathEntitiesByRefs  = "/api/catalog/entities/by-refs"
+
+	readAsService  = "service"
+	readAsIdentity = "identity"
+
+	orderAscending  = "asc"
+	orderDescending = "desc"
+
+	// entitiesQueryPageSize is the number of entities read per request from the query endpoint of the catalog.
+	entitiesQueryPageSize = 500
+	pathEntityByName      = "/api/catalog/entities/by-name/%s/%s/%s"
+
+	// patternEntityRef matches entity references with optional kind and namespace, e.g. `team-a` or `group:default/team-a`.
+	patternEntityRef = `^([^:/]+:)?([^:/]+/)?[^:/]+$`
+
+	// patternEntityRefWithKind matches entity references with a kind, e.g. `resource:artists-db` or `resource:default/artists-db`.
+	patternEntityRefWithKind = `^[^:/]+:.+$`
+
+	// patternTag matches tags of entities, e.g. `java` or `c++`.
+	patternTag = `^[a-z0-9:+#]+(-[a-z0-9:+#]+)*$`
+
+	// annotationAliases is the default annotation listing former names of entities, kept by teams renaming entities.
+	annotationAliases = "backstage.io/aliases"
+
+	// annotationOrphan is set by the catalog on entities no longer emitted by any location.
+	annotationOrphan = "backstage.io/orphan"
+
+	// annotationManagedByLocation is set by the catalog on entities to the location emitting them, e.g. `url:https://...`.
+	annotationManagedByLocation = "backstage.io/managed-by-location"
+
+	tagsMatchAny = "any"
+	tagsMatchAll = "all"
+
+	// annotationTechDocsRef is set on entities whose documentation is published to TechDocs.
+	annotationTechDocsRef = "backstage.io/techdocs-ref"
+
+	relationTypesIgnore = "ignore"
+	relationTypesWarn   = "warn"
+	relationTypesFail   = "fail"
+
+	sensitiveAnnotationsMark  = "mark"
+	sensitiveAnnotationsStrip = "strip"
+
+	annotationArgoCDAppName        = "argocd/app-name"
+	annotationArgoCDAppNamespace   = "argocd/app-namespace"
+	annotationArgoCDProjectName    = "argocd/project-name"
+	annotationKubernetesID         = "backstage.io/kubernetes-id"
+	annotationKubernetesNamespace  = "backstage.io/kubernetes-namespace"
+	annotationHelmReleaseName      = "meta.helm.sh/release-name"
+	annotationHelmReleaseNamespace = "meta.helm.sh/release-namespace"
+
+	entityWaitForDefaultTimeout = 60 * time.Second
+	entityWaitForInterval       = 2 * time.Second
+
+	descriptionEntityExpectedOwner = "An entity reference to the expected owner of the entity. If set, reading the data source fails when `spec.owner` " +
+		"of the entity differs from this value. Both references are normalized before they are compared, so `team-a` matches `group:default/team-a`."
+	descriptionEntityParsedOwner = "The owner of the entity from `spec.owner`, with kind and namespace defaulted the way Backstage does when they " +
+		"are left out: `group` kind and namespace of the entity."
+	descriptionEntityParsedRef          = "Normalized entity reference, e.g. `group:default/team-a`."
+	descriptionEntityParsedRefKind      = "Kind of the entity in lower case, e.g. `group`."
+	descriptionEntityParsedRefNamespace = "Namespace of the entity."
+	descriptionEntityParsedRefName      = "Name of the entity."
+	descriptionEntityFollowMoves        = "Whether to look the entity up in other namespaces, when it does not exist in `namespace`, and read it from " +
+		"the namespace it was moved to (default: false). The entity is followed only if exactly one namespace has an entity of the same kind " +
+		"and name."
+	descriptionEntityMovedTo = "The namespace the entity was moved to, if it was followed there because of `follow_moves`. " +
+		"`resolved_namespace` is set to it as well."
+	descriptionEntityMovedToNamespace = "Namespace the entity was found in."
+	descriptionEntityMovedToRef       = "Entity reference to the entity in the namespace it was found in."
+	descriptionEntityFollowAliases    = "Whether to look the entity up by the former names listed in the `alias_annotations` of the provider, when it does " +
+		"not exist in `namespace`, and read it under its current name (default: false). The entity is followed only if exactly one entity of the " +
+		"same kind in the namespace lists the name."
+	descriptionEntityUID = "A globally unique ID of the entity to read instead of `name` and `namespace`, e.g. the `id` of an earlier read, so " +
+		"renames of the entity in the catalog do not break references pinned to it."
+	descriptionEntityAnnotation = "Values of annotations to look the entity up by instead of `name`, e.g. `{ \"github.com/project-slug\" = " +
+		"\"org/repo\" }`. Exactly one entity must have all of them. It is looked up in `namespace`, if set, in all namespaces otherwise."
+	descriptionEntityApiVersionRequested = "Version of specification format for this particular entity that this is written against. If set, " +
+		"reading the entity fails when it is served in another version, e.g. `backstage.io/v1beta1` instead of `backstage.io/v1alpha1`."
+	descriptionEntityTitle = "Title of the entity to look it up by instead of `name`, e.g. `Artist Web`. Exactly one entity must have it. It is " +
+		"looked up in `namespace`, if set, in all namespaces otherwise."
+	descriptionEntityOrderBy = "Fields to order the entities by in the catalog, in order of precedence, e.g. `metadata.title` and then " +
+		"`metadata.name`. Entities without a field are ordered last. If set, it replaces the default order of the list."
+	descriptionEntityOrderByField     = "Dot separated path of the field, e.g. `metadata.title` or `spec.lifecycle`."
+	descriptionEntityOrderByDirection = "Direction of the order: `" + orderAscending + "` or `" + orderDescending + "` (default: `" +
+		orderAscending + "`)."
+	descriptionEntityLimit = "Maximum number of entities to read, e.g. to read just the first entities in the order of the list. If not set, " +
+		"all entities are read."
+	descriptionEntityOffset   = "Number of entities to skip before reading the entities."
+	descriptionEntityPageSize = "Maximum number of entities to read per request. If set, the entities are read in several requests, so large " +
+		"lists are not read in a single response."
+	descriptionEntityRefsOnly = "Whether to set only `refs`, leaving the list of entities and `entities_by_ref` empty (default: false). " +
+		"Keeps the state small when only the references of the entities are needed."
+	descriptionEntityCatalogURL = "URL of the page of the entity in the catalog of the Backstage UI, built from `app_url` of the provider, e.g. " +
+		"`https://demo.backstage.io/catalog/default/component/artist-web`."
+	descriptionEntityTechDocsURL = "URL of the documentation of the entity in TechDocs of the Backstage UI, e.g. " +
+		"`https://demo.backstage.io/docs/default/component/artist-web`. Null, if the entity has no `" + annotationTechDocsRef + "` annotation."
+	descriptionEntityTagsMatch = "Whether entities must have any of `tags` (`" + tagsMatchAny + "`), or all of them (`" + tagsMatchAll + "`). " +
+		"Defaults to `" + tagsMatchAny + "`. The catalog can only match any of several values of a field, so with `" + tagsMatchAll + "` it " +
+		"matches the first tag and the others are matched on the entities read, so fewer than `limit` entities may be returned."
+	descriptionEntityNameRegex = "A [regular expression](https://github.com/google/re2/wiki/Syntax) the names of the entities must match, " +
+		"e.g. `^payments-`. Matched on the entities read, so fewer than `limit` entities may be returned."
+	descriptionEntityTitleRegex = "A [regular expression](https://github.com/google/re2/wiki/Syntax) the titles of the entities must match. " +
+		"Entities without a title do not match. Matched on the entities read, so fewer than `limit` entities may be returned."
+	descriptionEntityCountOnly = "Whether to set only `total_count`, leaving `refs`, the list of entities and `entities_by_ref` empty " +
+		"(default: false). Only the fields identifying the entities are read, unless the filters of the data source are matched on others, " +
+		"so counting large lists is cheap."
+	descriptionEntityTotalCount = "Number of the entities read, e.g. to check that there are no more than a number of experimental components."
+	descriptionEntitiesByRef    = "The listed entities keyed by their canonical entity references, e.g. `component:default/artist-web`. Unlike " +
+		"the list, the map does not change when entities are added or removed before others, so it can drive `for_each` directly."
+	descriptionEntityAliasedTo     = "The current name of the entity, if it was followed there because of `follow_aliases`."
+	descriptionEntityAliasedToName = "Current name of the entity."
+	descriptionEntityAliasedToRef  = "Entity reference to the entity under its current name."
+	descriptionEntityRequestInfo   = "Details of the response of the Backstage API the entity was read from, to diagnose throttling or caching " +
+		"by gateways in front of the Backstage instance."
+	descriptionEntityRequestInfoRequestID  = "Correlation ID sent as `X-Request-Id` header with the request."
+	descriptionEntityRequestInfoStatusCode = "Status code of the response. Not set, if no response was received."
+	descriptionEntityRequestInfoHeaders    = "Headers of the response listed in `response_headers` of the provider, keyed by their names as listed " +
+		"there, e.g. `X-RateLimit-Remaining`. Headers missing from the response are left out."
+	descriptionEntityWaitFor = "Makes the data source poll Backstage until the entity exists and, if `previous_etag` is set, until its etag differs " +
+		"from it. Useful when the entity is registered or refreshed by a resource in the same configuration."
+	descriptionEntityWaitForPreviousEtag   = "Etag of the entity before it was refreshed. Polling continues while the entity still has this etag."
+	descriptionEntityWaitForTimeoutSeconds = "Maximum time to poll for in seconds (default: 60). Once it expires, the last read entity is used."
+	descriptionEntityAnnotationKeys        = "Keys of the annotations of the entity to write to the state. If not set, all annotations are written. Useful for " +
+		"entities carrying large generated annotations, such as SBOMs or digests, that would bloat the state."
+	descriptionEntityResolvedNamespace = "Namespace the entity was looked up in: `namespace` if set, otherwise the default namespace of the provider. " +
+		"If the data source falls back, the namespace of `fallback`, if set."
+	descriptionEntityRefs = "Canonical entity references to the listed entities, e.g. `component:default/artist-web`. Unlike the list, the set " +
+		"does not change when entities are added or removed before others, so it can be used directly in `for_each`."
+	descriptionEntityQuery = "A [JMESPath](https://jmespath.org/) expression applied to the raw JSON of the entity, e.g. " +
+		"`metadata.annotations.\"github.com/project-slug\"`. Gives access to fields not in the schema of the data source."
+	descriptionEntityTargetsByType = "Canonical entity references to the targets of the relations of the entity, keyed by the types of the relations, " +
+		"e.g. `ownedBy`. References are sorted and unique."
+	descriptionEntityQueryResult = "Result of `query` as JSON, or null if `query` is not set or the data source falls back."
+	descriptionEntityProjection  = "A [JMESPath](https://jmespath.org/) expression applied to the raw JSON of each of the listed entities, e.g. " +
+		"`{name: metadata.name, slug: metadata.annotations.\"github.com/project-slug\"}`. Entities are read with all their fields when set."
+	descriptionEntityProjectionResults = "Results of `projection` as JSON, one for each of the listed entities in their order, leaving out null " +
+		"results, e.g. of entities without the projected field. Null if `projection` is not set, empty if the data source falls back."
+	descriptionEntityContentHash = "A stable SHA-256 hash of the normalized JSON of the entity. Unlike `etag`, it changes only when the content of " +
+		"the entity changes, so it can be used to trigger rebuilds of dependent resources."
+
+	descriptionEntityGitOps = "Identifiers of the entity in GitOps tools, translated from its annotations, to wire the entity to the `argocd`, " +
+		"`flux` and `helm` providers. Annotations left out by `annotation_keys` are still taken into account, but " +
+		"`sensitive_annotations` of the provider are not."
+	descriptionEntityGitOpsArgoCD = "The Argo CD application of the entity, from the `argocd/app-name`, `argocd/project-name` and " +
+		"`argocd/app-namespace` annotations. Not set, if the entity has no `argocd/app-name` annotation."
+	descriptionEntityGitOpsArgoCDAppName   = "Name of the Argo CD application."
+	descriptionEntityGitOpsArgoCDProject   = "Argo CD project of the application."
+	descriptionEntityGitOpsArgoCDNamespace = "Namespace of the Argo CD application."
+	descriptionEntityGitOpsFlux            = "The Flux objects of the entity, from the `backstage.io/kubernetes-id` and " +
+		"`backstage.io/kubernetes-namespace` annotations. Not set, if the entity has no `backstage.io/kubernetes-id` annotation."
+	descriptionEntityGitOpsFluxName      = "Name of the Flux `Kustomization` or `HelmRelease` of the entity."
+	descriptionEntityGitOpsFluxNamespace = "Namespace of the Flux objects of the entity."
+	descriptionEntityGitOpsHelm          = "The Helm release of the entity, from the `meta.helm.sh/release-name` and `meta.helm.sh/release-namespace` " +
+		"annotations, falling back to the `backstage.io/kubernetes-id` and `backstage.io/kubernetes-namespace` annotations. Not set, if " +
+		"the entity has none of them."
+	descriptionEntityGitOpsHelmReleaseName = "Name of the Helm release."
+	descriptionEntityGitOpsHelmNamespace   = "Namespace of the Helm release."
+)
+
+// catalogFilterValidators validate values used in the filter of the catalog, which can not hold the separators of the filter.
+var catalogFilterValidators = []validator.String{
+	stringvalidator.LengthAtLeast(1),
+	stringvalidator.RegexMatches(regexp.MustCompile(`^[^,=]+$`), "must not contain `,` or `=`"),
+}
+
+// fallbackTypeValidators validate the type of entities in fallbacks, which Backstage requires to be set.
+var fallbackTypeValidators = []validator.String{
+	stringvalidator.LengthAtLeast(1),
+}
+
+// fallbackLifecycleValidators validate the lifecycle of entities in fallbacks against the lifecycles well known to Backstage.
+var fallbackLifecycleValidators = []validator.String{
+	stringvalidator.OneOf(wellKnownLifecycles...),
+}
+
+// fallbackOwnerValidators validate the owner of entities in fallbacks, which must be an entity reference, e.g. `group:default/team-a`.
+var fallbackOwnerValidators = []validator.String{
+	stringvalidator.RegexMatches(regexp.MustCompile(patternEntityRef), "must be an entity reference, e.g. `group:default/team-a`"),
+}
+
+// fallbackTitleValidators validate the title of entities in fallbacks, which Backstage requires to be a non-empty string. Blank titles
+// would be shown instead of the names of the entities.
+var fallbackTitleValidators = []validator.String{
+	stringvalidator.LengthAtLeast(1),
+	stringvalidator.RegexMatches(regexp.MustCompile(`\S`), "must not be blank"),
+}
+
+// fallbackDescriptionValidators validate the description of entities in fallbacks, which Backstage requires to be a non-empty string.
+var fallbackDescriptionValidators = []validator.String{
+	stringvalidator.LengthAtLeast(1),
+}
+
+// fallbackTagsValidators validate the tags of entities in fallbacks, which Backstage requires to be sequences of `[a-z0-9:+#]` separated by
+// `-`, of at most 63 characters.
+var fallbackTagsValidators = []validator.List{
+	listvalidator.ValueStringsAre(
+		stringvalidator.LengthBetween(1, 63),
+		stringvalidator.RegexMatches(regexp.MustCompile(patternTag), "must follow Backstage format restrictions, e.g. `java` or `c++`"),
+	),
+}
+
+// checkApiVersion adds an error to diagnostics when an apiVersion is requested and the entity is served in another one.
+func checkApiVersion(requested types.String, served types.String, kind string, name string, namespace string, diags *diag.Diagnostics) {
+	if requested.IsNull() || requested.IsUnknown() || requested.Equal(served) {
+		return
+	}
+
+	diags.AddAttributeError(path.Root("api_version"), fmt.Sprintf("Unexpected apiVersion of Backstage %s kind", kind),
+		fmt.Sprintf("Backstage %s kind %s/%s is served in apiVersion %q, but %q was requested.", kind, namespace, name, served.ValueString(),
+			requested.ValueString()))
+}
+
+// checkExpectedOwner adds an error to diagnostics when the expected owner is set and does not match the owner of the entity. Both owners are
+// normalized with the defaulting of Backstage before they are compared.
+func checkExpectedOwner(expected types.String, owner types.String, kind string, name string, namespace string, diags *diag.Diagnostics) {
+	if expected.IsNull() || expected.IsUnknown() {
+		return
+	}
+
+	var expectedRef, ownerRef string
+	if ref := parseOwnerRef(expected, namespace); ref != nil {
+		expectedRef = ref.Ref.ValueString()
+	}
+	if ref := parseOwnerRef(owner, namespace); ref != nil {
+		ownerRef = ref.Ref.ValueString()
+	}
+
+	if !strings.EqualFold(ownerRef, expectedRef) {
+		diags.AddAttributeError(path.Root("expected_owner"), fmt.Sprintf("Unexpected owner of Backstage %s kind", kind),
+			fmt.Sprintf("Backstage %s kind %s/%s is owned by %q, but %q was expected.", kind, namespace, name, ownerRef, expectedRef))
+	}
+}
+
+// gitOpsBridge translates the annotations of an entity into its identifiers in GitOps tools. Annotations that are not set, or are sensitive,
+// become null values, and tools the entity has no name annotation for are left out.
+func (p *providerData) gitOpsBridge(metadata *entityMetadataModel) *entityGitOpsModel {
+	if metadata == nil {
+		return nil
+	}
+
+	annotation := func(keys ...string) types.String {
+		for _, k := range keys {
+			if v, ok := metadata.Annotations[k]; ok && v != "" && !p.sensitiveAnnotations[k] {
+				return types.StringValue(v)
+			}
+		}
+		return types.StringNull()
+	}
+
+	gitOps := &entityGitOpsModel{}
+	if name := annotation(annotationArgoCDAppName); !name.IsNull() {
+		gitOps.ArgoCD = &entityArgoCDModel{
+			AppName:   name,
+			Project:   annotation(annotationArgoCDProjectName),
+			Namespace: annotation(annotationArgoCDAppNamespace),
+		}
+	}
+	if name := annotation(annotationKubernetesID); !name.IsNull() {
+		gitOps.Flux = &entityFluxModel{Name: name, Namespace: annotation(annotationKubernetesNamespace)}
+	}
+	if name := annotation(annotationHelmReleaseName, annotationKubernetesID); !name.IsNull() {
+		gitOps.Helm = &entityHelmModel{
+			ReleaseName: name,
+			Namespace:   annotation(annotationHelmReleaseNamespace, annotationKubernetesNamespace),
+		}
+	}
+
+	return gitOps
+}
+
+// canonicalEntityRef returns the entity reference in the canonical form used in the outputs of the provider: lower case, with the namespace
+// defaulting to `default`.
+func canonicalEntityRef(kind, namespace, name string) string {
+	if namespace == "" {
+		namespace = backstage.DefaultNamespaceName
+	}
+
+	return strings.ToLower(fmt.Sprintf("%s:%s/%s", kind, namespace, name))
+}
+
+// refsSet returns the canonical entity references as elements of a set, without duplicates.
+func refsSet(refs []string) []types.String {
+	seen := make(map[string]bool, len(refs))
+	set := make([]types.String, 0, len(refs))
+	for _, r := range refs {
+		kind, namespace, name := parseEntityRef(r, "", backstage.DefaultNamespaceName)
+		if ref := canonicalEntityRef(kind, namespace, name); !seen[ref] {
+			seen[ref] = true
+			set = append(set, types.StringValue(ref))
+		}
+	}
+
+	return set
+}
+
+// parseOwnerRef parses a reference to the owner of an entity the way Backstage does: the kind defaults to `group` and the namespace to the one
+// of the owned entity. It returns nil, if the owner is not set.
+func parseOwnerRef(owner types.String, namespace string) *entityRefModel {
+	if owner.ValueString() == "" {
+		return nil
+	}
+
+	if namespace == "" {
+		namespace = backstage.DefaultNamespaceName
+	}
+
+	kind, namespace, name := parseEntityRef(owner.ValueString(), "group", namespace)
+	kind = strings.ToLower(kind)
+
+	return &entityRefModel{
+		Ref:       types.StringValue(fmt.Sprintf("%s:%s/%s", kind, namespace, name)),
+		Kind:      types.StringValue(kind),
+		Namespace: types.StringValue(namespace),
+		Name:      types.StringValue(name),
+	}
+}
+
+// filterAnnotations removes the annotations of the metadata whose keys are not among the keys, if any keys are set.
+func filterAnnotations(metadata *entityMetadataModel, keys []types.String) {
+	if metadata == nil || keys == nil {
+		return
+	}
+
+	allowed := make(map[string]bool, len(keys))
+	for _, k := range keys {
+		allowed[k.ValueString()] = true
+	}
+
+	for k := range metadata.Annotations {
+		if !allowed[k] {
+			delete(metadata.Annotations, k)
+		}
+	}
+}
+
+// protectAnnotations moves the annotations of the metadata configured as sensitive in the provider to its sensitive annotations, or removes
+// them, if the provider is configured to strip them.
+func (p *providerData) protectAnnotations(metadata *entityMetadataModel) {
+	if metadata == nil || len(p.sensitiveAnnotations) == 0 {
+		return
+	}
+
+	for k, v := range metadata.Annotations {
+		if !p.sensitiveAnnotations[k] {
+			continue
+		}
+
+		delete(metadata.Annotations, k)
+		if p.stripSensitiveAnnotations {
+			continue
+		}
+
+		if metadata.SensitiveAnnotations == nil {
+			metadata.SensitiveAnnotations = map[string]string{}
+		}
+		metadata.SensitiveAnnotations[k] = v
+	}
+}
+
+// isOrphan reports whether the catalog marked the entity as orphaned, i.e. no location emits it anymore.
+func isOrphan(annotations map[string]string) bool {
+	return annotations[annotationOrphan] == "true"
+}
+
+// hasErrors reports whether the status of the entity has items of level error, e.g. failures to process it.
+func hasErrors(status *backstage.EntityStatus) bool {
+	if status == nil {
+		return false
+	}
+
+	for _, i := range status.Items {
+		if i.Level == entityStatusLevelError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolveUID looks up the name and namespace of the entity with the UID, if set, so it is read like an entity looked up by its name. The
+// response and error of the lookup are returned if it fails, nil if the UID is not set or the lookup succeeded.
+func (p *providerData) resolveUID(ctx context.Context, kind string, uid types.String, name *types.String, namespace *types.String) (*http.Response,
+	error) {
+	if uid.IsNull() {
+		return nil, nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Looking up %s kind by UID %s", kind, uid.ValueString()))
+	entity, response, err := p.client.Catalog.Entities.Get(ctx, uid.ValueString())
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+
+	if !strings.EqualFold(entity.Kind, kind) {
+		return response, fmt.Errorf("entity with UID %s is of kind %s", uid.ValueString(), entity.Kind)
+	}
+
+	*name = types.StringValue(entity.Metadata.Name)
+	*namespace = types.StringValue(entity.Metadata.Namespace)
+
+	return nil, nil
+}
+
+// resolveAnnotations looks up the name and namespace of the entity with the values of annotations, if set, so it is read like an entity looked
+// up by its name. See resolveFilter.
+func (p *providerData) resolveAnnotations(ctx context.Context, kind string, annotations map[string]string, namespace types.String,
+	name *types.String, resolvedNamespace *types.String) (*http.Response, error) {
+	if len(annotations) == 0 {
+		return nil, nil
+	}
+
+	keys := make([]string, 0, len(annotations))
+	for k := range annotations {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	conditions := make([]string, 0, len(keys))
+	for _, k := range keys {
+		conditions = append(conditions, fmt.Sprintf("metadata.annotations.%s=%s", k, annotations[k]))
+	}
+
+	return p.resolveFilter(ctx, kind, conditions, fmt.Sprintf("annotations %v", annotations), namespace, name, resolvedNamespace)
+}
+
+// resolveTitle looks up the name and namespace of the entity with the title, if set, so it is read like an entity looked up by its name. See
+// resolveFilter.
+func (p *providerData) resolveTitle(ctx context.Context, kind string, title types.String, namespace types.String, name *types.String,
+	resolvedNamespace *types.String) (*http.Response, error) {
+	if title.IsNull() {
+		return nil, nil
+	}
+
+	return p.resolveFilter(ctx, kind, []string{"metadata.title=" + title.ValueString()}, fmt.Sprintf("title %q", title.ValueString()), namespace,
+		name, resolvedNamespace)
+}
+
+// resolveFilter looks up the name and namespace of the entity of the kind matching all conditions of a filter of the catalog. The entity is
+// looked up in the namespace, if set, in all namespaces otherwise. The response and error of the lookup are returned if it fails or does not
+// find exactly one entity, nil if the lookup succeeded.
+func (p *providerData) resolveFilter(ctx context.Context, kind string, conditions []string, description string, namespace types.String,
+	name *types.String, resolvedNamespace *types.String) (*http.Response, error) {
+	filter := []string{"kind=" + kind}
+	if !namespace.IsNull() {
+		filter = append(filter, "metadata.namespace="+namespace.ValueString())
+	}
+	filter = append(filter, conditions...)
+
+	tflog.Debug(ctx, fmt.Sprintf("Looking up %s kind by %s", kind, description))
+	entities, response, err := p.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: []string{strings.Join(filter, ",")},
+		Fields:  []string{"metadata.name", "metadata.namespace"},
+	})
+	if err != nil || response.StatusCode != http.StatusOK {
+		return response, err
+	}
+
+	var found []backstage.Entity
+	refs := []string{}
+	for _, e := range entities {
+		if p.policy.allows(kind, e.Metadata.Namespace) {
+			found = append(found, e)
+			refs = append(refs, canonicalEntityRef(kind, e.Metadata.Namespace, e.Metadata.Name))
+		}
+	}
+
+	if len(found) == 0 {
+		return response, fmt.Errorf("no entity has %s", description)
+	}
+	if len(found) > 1 {
+		return response, fmt.Errorf("%d entities have %s, exactly one is expected: %s", len(found), description, strings.Join(refs, ", "))
+	}
+
+	*name = types.StringValue(found[0].Metadata.Name)
+	*resolvedNamespace = types.StringValue(found[0].Metadata.Namespace)
+
+	return nil, nil
+}
+
+// findMove looks up the entity in other namespaces, when following moves is enabled and the entity was not found. It returns the namespace
+// the entity was moved to, or nil if it was not found in exactly one other namespace.
+func (p *providerData) findMove(ctx context.Context, follow types.Bool, kind string, name string, namespace string, response *http.Response, err error,
+	diags *diag.Diagnostics) *entityMovedToModel {
+	if !follow.ValueBool() || name == "" || err != nil || response == nil || response.StatusCode != http.StatusNotFound {
+		return nil
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Looking up %s kind %s in other namespaces than %s", kind, name, namespace))
+	entities, response, err := p.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+		Filters: []string{fmt.Sprintf("kind=%s,metadata.name=%s", kind, name)},
+		Fields:  []string{"metadata.namespace"},
+	})
+	if err != nil || response.StatusCode != http.StatusOK {
+		diags.AddWarning(fmt.Sprintf("Could not follow move of Backstage %s kind", kind),
+			p.withRequestID(fmt.Sprintf("Could not look up Backstage %s kind %s in other namespaces.", kind, name)))
+		return nil
+	}
+
+	var namespaces []string
+	for _, e := range entities {
+		if e.Metadata.Namespace != namespace && p.policy.allows(kind, e.Metadata.Namespace) {
+			namespaces = append(namespaces, e.Metadata.Namespace)
+		}
+	}
+
+	if len(namespaces) != 1 {
+		if len(namespaces) > 1 {
+			sort.Strings(namespaces)
+			diags.AddWarning(fmt.Sprintf("Could not follow move of Backstage %s kind", kind),
+				fmt.Sprintf("Backstage %s kind %s exists in multiple other namespaces: %s.", kind, name, strings.Join(namespaces, ", ")))
+		}
+		return nil
+	}
+
+	diags.AddWarning(fmt.Sprintf("Backstage %s kind moved", kind),
+		fmt.Sprintf("Backstage %s kind %s/%s does not exist, it was read from namespace %s instead.", kind, namespace, name, namespaces[0]))
+
+	return &entityMovedToModel{
+		Namespace: types.StringValue(namespaces[0]),
+		Ref:       types.StringValue(strings.ToLower(fmt.Sprintf("%s:%s/%s", kind, namespaces[0], name))),
+	}
+}
+
+// findAlias looks the entity up by the former names listed in alias annotations, when following aliases is enabled and the entity was not
+// found. It returns the current name of the entity, or nil if not exactly one entity lists the name.
+func (p *providerData) findAlias(ctx context.Context, follow types.Bool, kind string, name string, namespace string, response *http.Response,
+	err error, diags *diag.Diagnostics) *entityAliasedToModel {
+	if !follow.ValueBool() || name == "" || err != nil || response == nil || response.StatusCode != http.StatusNotFound {
+		return nil
+	}
+
+	names := map[string]bool{}
+	for _, annotation := range p.aliasAnnotations {
+		tflog.Debug(ctx, fmt.Sprintf("Looking up %s kind %s/%s by annotation %s", kind, namespace, name, annotation))
+		entities, response, err := p.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+			Filters: []string{fmt.Sprintf("kind=%s,metadata.namespace=%s,metadata.annotations.%s", kind, namespace, annotation)},
+			Fields:  []string{"metadata.name", "metadata.annotations"},
+		})
+		if err != nil || response.StatusCode != http.StatusOK {
+			diags.AddWarning(fmt.Sprintf("Could not follow alias of Backstage %s kind", kind),
+				p.withRequestID(fmt.Sprintf("Could not look up Backstage %s kind %s/%s by its former names.", kind, namespace, name)))
+			return nil
+		}
+
+		// Annotations are matched on their presence by the catalog, the names they list are matched here.
+		for _, e := range entities {
+			for _, alias := range strings.Split(e.Metadata.Annotations[annotation], ",") {
+				if strings.EqualFold(strings.TrimSpace(alias), name) {
+					names[e.Metadata.Name] = true
+				}
+			}
+		}
+	}
+
+	current := make([]string, 0, len(names))
+	for n := range names {
+		current = append(current, n)
+	}
+	sort.Strings(current)
+
+	if len(current) != 1 {
+		if len(current) > 1 {
+			diags.AddWarning(fmt.Sprintf("Could not follow alias of Backstage %s kind", kind),
+				fmt.Sprintf("Multiple Backstage %s kinds in namespace %s have former name %s: %s.", kind, namespace, name, strings.Join(current, ", ")))
+		}
+		return nil
+	}
+	diags.AddWarning(fmt.Sprintf("Backstage %s kind renamed", kind),
+		fmt.Sprintf("Backstage %s kind %s/%s does not exist, it was read under its current name %s instead.", kind, namespace, name, current[0]))
+
+	return &entityAliasedToModel{
+		Name: types.StringValue(current[0]),
+		Ref:  types.StringValue(canonicalEntityRef(kind, namespace, current[0])),
+	}
+}
+
+// waitForEntity gets the entity, polling until it is found and its etag differs from the previous one, if waitFor is set. Polls bypass the
+// cache of responses, which would serve the entity as first read. When the timeout expires, a warning is added to diagnostics and the last
+// result is returned.
+func waitForEntity[T any](ctx context.Context, waitFor *entityWaitForModel, diags *diag.Diagnostics,
+	get func(context.Context) (*T, *http.Response, error), etag func(*T) string) (*T, *http.Response, error) {
+	entity, response, err := get(ctx)
+	if waitFor == nil {
+		return entity, response, err
+	}
+
+	timeout := entityWaitForDefaultTimeout
+	if !waitFor.TimeoutSeconds.IsNull() {
+		timeout = time.Duration(waitFor.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		if err != nil {
+			return entity, response, err
+		}
+
+		stale := response.StatusCode == http.StatusNotFound
+		if response.StatusCode == http.StatusOK && entity != nil && !waitFor.PreviousEtag.IsNull() {
+			stale = etag(entity) == waitFor.PreviousEtag.ValueString()
+		}
+
+		if !stale {
+			return entity, response, err
+		}
+
+		if time.Now().Add(entityWaitForInterval).After(deadline) {
+			diags.AddWarning("Timeout waiting for Backstage entity",
+				fmt.Sprintf("Backstage entity was not found or not refreshed within %s, the last read data is used.", timeout))
+			return entity, response, err
+		}
+
+		tflog.Debug(ctx, "Waiting for Backstage entity to be found or refreshed")
+		select {
+		case <-ctx.Done():
+			return entity, response, ctx.Err()
+		case <-time.After(entityWaitForInterval):
+		}
+
+		entity, response, err = get(transport.WithoutCache(ctx))
+	}
+}
+
+// parseEntityRef splits an entity reference of the form `[<kind>:][<namespace>/]<name>` into its parts, using the defaults for the parts
+// that are left out. The kind is returned as written in the reference.
+func parseEntityRef(ref string, defaultKind string, defaultNamespace string) (kind string, namespace string, name string) {
+	kind, namespace, name = defaultKind, defaultNamespace, ref
+	if i := strings.Index(name, ":"); i >= 0 {
+		kind, name = name[:i], name[i+1:]
+	}
+	if i := strings.Index(name, "/"); i >= 0 {
+		namespace, name = name[:i], name[i+1:]
+	}
+
+	return kind, namespace, name
+}
+
+// canonicalTargetRef returns the target reference of a relation in canonical lower case form, as Backstage may keep the case of kinds written
+// in descriptors, e.g. `Group:default/team-a`.
+func canonicalTargetRef(ref string) string {
+	kind, namespace, name := parseEntityRef(ref, "", backstage.DefaultNamespaceName)
+	return canonicalEntityRef(kind, namespace, name)
+}
+
+// relationTargetsByType returns the sorted and unique canonical references to the targets of the relations, keyed by the types of the
+// relations.
+func relationTargetsByType(ctx context.Context, relations []entityRelationModel, diags *diag.Diagnostics) types.Map {
+	targets := map[string][]string{}
+	for _, r := range relations {
+		targets[r.Type.ValueString()] = append(targets[r.Type.ValueString()], r.TargetRef.ValueString())
+	}
+
+	byType := make(map[string][]types.String, len(targets))
+	for t, refs := range targets {
+		byType[t] = refsSet(refs)
+		sort.Slice(byType[t], func(i, j int) bool { return byType[t][i].ValueString() < byType[t][j].ValueString() })
+	}
+
+	v, d := types.MapValueFrom(ctx, types.ListType{ElemType: types.StringType}, byType)
+	diags.Append(d...)
+
+	return v
+}
+
+// entityContentHash returns a stable hash of the normalized JSON of the entity. The uid, etag and status of the entity are left out, as they
+// change without any change of its content.
+func entityContentHash(entity interface{}) types.String {
+	v, err := json.Marshal(entity)
+	if err != nil {
+		return types.StringNull()
+	}
+
+	var normalized map[string]interface{}
+	if err := json.Unmarshal(v, &normalized); err != nil {
+		return types.StringNull()
+	}
+
+	if metadata, ok := normalized["metadata"].(map[string]interface{}); ok {
+		delete(metadata, "uid")
+		delete(metadata, "etag")
+	}
+	delete(normalized, "status")
+
+	// Keys of maps are sorted when marshalled, making the JSON stable.
+	if v, err = json.Marshal(normalized); err != nil {
+		return types.StringNull()
+	}
+
+	return contentHash(v)
+}
+
+// contentHash returns the hex encoded SHA-256 hash of the content.
+func contentHash(content []byte) types.String {
+	sum := sha256.Sum256(content)
+	return types.StringValue(hex.EncodeToString(sum[:]))
+}
+
+// checkRelationTypes adds a warning or an error to diagnostics for each relation of a type that is neither well known nor configured as custom
+// in the provider, depending on the configured handling of unknown relation types. Relations are exposed verbatim regardless.
+func (p *providerData) checkRelationTypes(relations []entityRelationModel, diags *diag.Diagnostics) {
+	if p.unknownRelationTypes == "" || p.unknownRelationTypes == relationTypesIgnore {
+		return
+	}
+
+	reported := map[string]bool{}
+	for _, r := range relations {
+		t := r.Type.ValueString()
+		if wellKnownRelationTypes[t] || p.customRelationTypes[t] || reported[t] {
+			continue
+		}
+		reported[t] = true
+
+		const summary = "Unknown Backstage relation type"
+		detail := fmt.Sprintf("Relation of type %q to %s is not a well-known relation type of Backstage, nor is it configured in "+
+			"custom_relation_types of the provider.", t, r.TargetRef.ValueString())
+		if p.unknownRelationTypes == relationTypesFail {
+			diags.AddError(summary, detail)
+		} else {
+			diags.AddWarning(summary, detail)
+		}
+	}
+}
+
+// queryEntities reads a page of entities from the query endpoint of the catalog, which supports cursor based pagination.
+func (p *providerData) queryEntities(ctx context.Context, query url.Values) (*entitiesQueryResult, *http.Response, error) {
+	var result entitiesQueryResult
+	response, err := p.doJSON(ctx, http.MethodGet, pathEntitiesByQuery+"?"+query.Encode(), nil, &result)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return nil, response, err
+	}
+
+	return &result, response, nil
+}
+
+// entityFacet reads the distinct values of the field across the entities matching any of the filters from the facets of the catalog, sorted.
+func (p *providerData) entityFacet(ctx context.Context, field string, filters []string) ([]string, *http.Response, error) {
+	var result struct {
+		Facets map[string][]struct {
+			Value string `json:"value"`
+			Count int64  `json:"count"`
+		} `json:"facets"`
+	}
+	query := url.Values{"facet": []string{field}, "filter": filters}
+	response, err := p.doJSON(ctx, http.MethodGet, pathEntityFacets+"?"+query.Encode(), nil, &result)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return nil, response, err
+	}
+
+	values := make([]string, 0, len(result.Facets[field]))
+	for _, f := range result.Facets[field] {
+		values = append(values, f.Value)
+	}
+	sort.Strings(values)
+
+	return values, response, nil
+}
+
+// entitiesByRefs reads the entities with the canonical references, with the fields only, in one request to the by-refs endpoint of the
+// catalog, or listing them by filters, if the catalog does not support it. Entities that do not exist are left out.
+func (p *providerData) entitiesByRefs(ctx context.Context, refs []string, fields []string) ([]backstage.Entity, *http.Response, error) {
+	if caps, err := p.capabilities(ctx); err != nil || !caps.EntitiesByRefs {
+		filters := make([]string, 0, len(refs))
+		for _, r := range refs {
+			kind, namespace, name := parseEntityRef(r, "", backstage.DefaultNamespaceName)
+			filters = append(filters, fmt.Sprintf("kind=%s,metadata.namespace=%s,metadata.name=%s", kind, namespace, name))
+		}
+		return p.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{Filters: filters, Fields: fields})
+	}
+
+	var result struct {
+		Items []*backstage.Entity `json:"items"`
+	}
+	query := url.Values{"fields": []string{strings.Join(fields, ",")}}
+	response, err := p.doJSON(ctx, http.MethodPost, pathEntitiesByRefs+"?"+query.Encode(), map[string][]string{"entityRefs": refs}, &result)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return nil, response, err
+	}
+
+	entities := make([]backstage.Entity, 0, len(result.Items))
+	for _, e := range result.Items {
+		if e != nil {
+			entities = append(entities, *e)
+		}
+	}
+
+	return entities, response, nil
+}
+
+// orderByName is the default order of entities listed by plural data sources.
+var orderByName = []backstage.ListEntityOrder{{Field: "metadata.name", Direction: orderAscending}}
+
+// entityURLs returns the URLs of the catalog page and, if the entity has documentation, the TechDocs of the entity in the Backstage UI. Both
+// are null, if the name of the entity is not known. TechDocs address entities in lower case, the catalog only lowers the kind.
+func (p *providerData) entityURLs(kind types.String, metadata *entityMetadataModel) (types.String, types.String) {
+	if metadata == nil || metadata.Name.IsNull() {
+		return types.StringNull(), types.StringNull()
+	}
+
+	namespace := metadata.Namespace.ValueString()
+	if namespace == "" {
+		namespace = backstage.DefaultNamespaceName
+	}
+	entityPath := url.PathEscape(namespace) + "/" + url.PathEscape(strings.ToLower(kind.ValueString())) + "/" +
+		url.PathEscape(metadata.Name.ValueString())
+	catalogURL := types.StringValue(p.appURL + "/catalog/" + entityPath)
+
+	_, docs := metadata.Annotations[annotationTechDocsRef]
+	if _, sensitive := metadata.SensitiveAnnotations[annotationTechDocsRef]; !docs && !sensitive {
+		return catalogURL, types.StringNull()
+	}
+
+	return catalogURL, types.StringValue(p.appURL + "/docs/" + strings.ToLower(entityPath))
+}
+
+// tagsFilter returns the conditions of a catalog filter matching entities with the tags. Values of the same field in a filter are matched
+// when any of them matches, so if all the tags are required, only the first is matched by the catalog, the others by matchesTags.
+func tagsFilter(tags []types.String, match types.String) string {
+	if match.ValueString() == tagsMatchAll && len(tags) > 0 {
+		tags = tags[:1]
+	}
+
+	filter := ""
+	for _, t := range tags {
+		filter += ",metadata.tags=" + t.ValueString()
+	}
+
+	return filter
+}
+
+// matchesTags reports whether the entity has all the tags, if all of them are required. Tags are compared case insensitively, the way the
+// catalog matches them.
+func matchesTags(entityTags []string, tags []types.String, match types.String) bool {
+	if match.ValueString() != tagsMatchAll {
+		return true
+	}
+
+	for _, t := range tags {
+		found := false
+		for _, e := range entityTags {
+			found = found || strings.EqualFold(e, t.ValueString())
+		}
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// compileEntityRegexp compiles the regular expression set in the attribute, or returns nil if it is not set. A diagnostic is added, if the
+// expression is invalid.
+func compileEntityRegexp(value types.String, attribute path.Path, diags *diag.Diagnostics) *regexp.Regexp {
+	if value.IsNull() {
+		return nil
+	}
+
+	re, err := regexp.Compile(value.ValueString())
+	if err != nil {
+		diags.AddAttributeError(attribute, "Invalid regular expression of Backstage entities",
+			fmt.Sprintf("Could not parse regular expression %q: %s", value.ValueString(), err.Error()))
+	}
+
+	return re
+}
+
+// matchesEntityRegexps reports whether the name and title of the entity match the regular expressions, if set.
+func matchesEntityRegexps(metadata backstage.EntityMeta, nameRegex, titleRegex *regexp.Regexp) bool {
+	if nameRegex != nil && !nameRegex.MatchString(metadata.Name) {
+		return false
+	}
+
+	return titleRegex == nil || (metadata.Title != "" && titleRegex.MatchString(metadata.Title))
+}
+
+// countFields returns the fields to read entities with: only those identifying them and their tags and title, which may be matched on the
+// entities read, if only their count is needed.
+func countFields(countOnly types.Bool, fields []string) []string {
+	if countOnly.ValueBool() {
+		return []string{"kind", "metadata.uid", "metadata.name", "metadata.namespace", "metadata.title", "metadata.tags"}
+	}
+
+	return fields
+}
+
+// projectionFields returns the fields to read entities with: all of them, if the projection is set, as it may use any field of the raw
+// entities.
+func projectionFields(projection types.String, fields []string) []string {
+	if !projection.IsNull() {
+		return nil
+	}
+
+	return fields
+}
+
+// entitiesByRef returns the items keyed by their entity references.
+func entitiesByRef[T any](items []T, ref func(T) types.String) map[string]T {
+	byRef := make(map[string]T, len(items))
+	for _, i := range items {
+		byRef[ref(i).ValueString()] = i
+	}
+
+	return byRef
+}
+
+// listEntityOrder returns the order of entities to list, or the default order if no order is set.
+func listEntityOrder(orderBy []entityOrderModel, defaultOrder []backstage.ListEntityOrder) []backstage.ListEntityOrder {
+	if len(orderBy) == 0 {
+		return defaultOrder
+	}
+
+	order := make([]backstage.ListEntityOrder, 0, len(orderBy))
+	for _, o := range orderBy {
+		direction := o.Direction.ValueString()
+		if direction == "" {
+			direction = orderAscending
+		}
+		order = append(order, backstage.ListEntityOrder{Field: o.Field.ValueString(), Direction: direction})
+	}
+
+	return order
+}
+
+// listEntities lists entities from the list endpoint of the catalog like the catalog client does, skipping the first offset entities and
+// returning at most limit entities, if they are set. If the page size is set, the entities are read in requests of at most that many.
+func (p *providerData) listEntities(ctx context.Context, options *backstage.ListEntityOptions, limit types.Int64, offset types.Int64,
+	pageSize types.Int64) ([]backstage.Entity, *http.Response, error) {
+	if limit.IsNull() && offset.IsNull() && pageSize.IsNull() {
+		return p.client.Catalog.Entities.List(ctx, options)
+	}
+
+	query := url.Values{}
+	query["filter"] = options.Filters
+	if len(options.Fields) > 0 {
+		query.Set("fields", strings.Join(options.Fields, ","))
+	}
+	for _, o := range options.Order {
+		query.Add("order", o.Direction+":"+o.Field)
+	}
+
+	entities := []backstage.Entity{}
+	next := offset.ValueInt64()
+	for {
+		count := pageSize.ValueInt64()
+		if remaining := limit.ValueInt64() - int64(len(entities)); !limit.IsNull() && (count == 0 || remaining < count) {
+			count = remaining
+		}
+		if count > 0 {
+			query.Set("limit", strconv.FormatInt(count, 10))
+		}
+		if next > 0 {
+			query.Set("offset", strconv.FormatInt(next, 10))
+		}
+
+		var page []backstage.Entity
+		response, err := p.doJSON(ctx, http.MethodGet, pathEntities+"?"+query.Encode(), nil, &page)
+		if err != nil || response.StatusCode != http.StatusOK {
+			return nil, response, err
+		}
+
+		entities = append(entities, page...)
+		next += int64(len(page))
+		if pageSize.IsNull() || int64(len(page)) < pageSize.ValueInt64() || (!limit.IsNull() && int64(len(entities)) >= limit.ValueInt64()) {
+			return entities, response, nil
+		}
+	}
+}
+
+// queryAllEntities reads all entities matching the query of the first page from the query endpoint of the catalog, or at most limit entities,
+// if it is not 0. The entities are read in pages following their cursors, so large catalogs are not read in a single response.
+func (p *providerData) queryAllEntities(ctx context.Context, query url.Values, limit int) ([]backstage.Entity, *http.Response, error) {
+	entities := []backstage.Entity{}
+	for {
+		pageSize := entitiesQueryPageSize
+		if limit > 0 && limit-len(entities) < pageSize {
+			pageSize = limit - len(entities)
+		}
+		query.Set("limit", strconv.Itoa(pageSize))
+		page, response, err := p.queryEntities(ctx, query)
+		if page == nil {
+			return nil, response, err
+		}
+
+		entities = append(entities, page.Items...)
+		tflog.Debug(ctx, fmt.Sprintf("Read page of %d entities, %d of %d entities read", len(page.Items), len(entities), page.TotalItems))
+		if page.PageInfo.NextCursor == nil || len(page.Items) == 0 || (limit > 0 && len(entities) >= limit) {
+			return entities, response, nil
+		}
+
+		// The cursor retains the filters and order of the first page.
+		query = url.Values{}
+		query.Set("cursor", *page.PageInfo.NextCursor)
+	}
+}
+
+// withIdentity returns a copy of the context whose requests to the Backstage API are sent with the identity token instead of the token of
+// the provider, so the catalog filters entities by the permissions of the user.
+func (p *providerData) withIdentity(ctx context.Context) context.Context {
+	return transport.WithHeaders(ctx, map[string]string{"Authorization": "Bearer " + p.identityToken})
+}
+
+// applyFallbackDefaults sets attributes of the fallback that are not set to the defaults configured in the provider for the kind. Defaults are
+// keyed by dot separated paths of the attributes, e.g. `spec.owner`. For map attributes, the rest of the path is the key in the map.
+func (p *providerData) applyFallbackDefaults(kind string, fallback interface{}, diags *diag.Diagnostics) {
+	defaults := p.fallbackDefaults[strings.ToLower(kind)]
+
+	keys := make([]string, 0, len(defaults))
+	for k := range defaults {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if err := setFallbackDefault(reflect.ValueOf(fallback), strings.Split(k, "."), defaults[k]); err != nil {
+			diags.AddAttributeError(path.Root("fallback"), "Invalid fallback default of Backstage "+kind+" kind",
+				fmt.Sprintf("Default %q configured in fallback_defaults of the provider cannot be applied: %s.", k, err.Error()))
+		}
+	}
+}
+
+// setFallbackDefault sets the string attribute at the path of the data model to the value, unless it is already set. Attributes are matched
+// by their tfsdk tags, unset nested attributes are created on the way.
+func setFallbackDefault(v reflect.Value, attributePath []string, value string) error {
+	for i, name := range attributePath {
+		if v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+
+		if v.Kind() == reflect.Map && v.Type().Key().Kind() == reflect.String && v.Type().Elem().Kind() == reflect.String {
+			if v.IsNil() {
+				v.Set(reflect.MakeMap(v.Type()))
+			}
+			key := reflect.ValueOf(strings.Join(attributePath[i:], "."))
+			if !v.MapIndex(key).IsValid() {
+				v.SetMapIndex(key, reflect.ValueOf(value))
+			}
+			return nil
+		}
+
+		if v.Kind() != reflect.Struct {
+			return fmt.Errorf("attribute %s is not an object", strings.Join(attributePath[:i], "."))
+		}
+
+		field, ok := fieldByTag(v, name)
+		if !ok {
+			return fmt.Errorf("attribute %s does not exist", strings.Join(attributePath[:i+1], "."))
+		}
+		v = field
+	}
+
+	s, ok := v.Interface().(types.String)
+	if !ok {
+		return fmt.Errorf("attribute %s is not a string", strings.Join(attributePath, "."))
+	}
+	if s.IsNull() {
+		v.Set(reflect.ValueOf(types.StringValue(value)))
+	}
+
+	return nil
+}
+
+// fieldByTag returns the field of the struct with the tfsdk tag.
+func fieldByTag(v reflect.Value, tag string) (reflect.Value, bool) {
+	for i := 0; i < v.NumField(); i++ {
+		if v.Type().Field(i).Tag.Get("tfsdk") == tag {
+			return v.Field(i), true
+		}
+	}
+
+	return reflect.Value{}, false
+}
+
+// projectEntities applies the JMESPath expression to the raw JSON of the entities with the references, in their order, returning the JSON
+// encoded results that are not null. Sensitive annotations are removed from the entities first, as the results are not sensitive. The
+// results are null, if the expression is not set.
+func (p *providerData) projectEntities(expression types.String, entities []backstage.Entity, refs []string, diags *diag.Diagnostics) []jsontypes.Normalized {
+	if expression.IsNull() {
+		return nil
+	}
+
+	compiled, err := jmespath.Compile(expression.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("projection"), "Invalid projection of Backstage entities",
+			fmt.Sprintf("Could not parse projection %q: %s.", expression.ValueString(), err.Error()))
+		return nil
+	}
+
+	byRef := make(map[string]backstage.Entity, len(entities))
+	for _, e := range entities {
+		byRef[canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)] = e
+	}
+
+	results := []jsontypes.Normalized{}
+	for _, r := range refs {
+		e, ok := byRef[r]
+		if !ok {
+			continue
+		}
+
+		// The expression is applied to the generic JSON form of the entity, as read from the catalog.
+		var raw interface{}
+		v, err := json.Marshal(e)
+		if err == nil {
+			err = json.Unmarshal(v, &raw)
+		}
+		var result interface{}
+		if err == nil {
+			p.removeSensitiveAnnotations(raw)
+			result, err = compiled.Search(raw)
+		}
+		if err == nil && result != nil {
+			v, err = json.Marshal(result)
+			if err == nil {
+				results = append(results, jsontypes.NewNormalizedValue(string(v)))
+			}
+		}
+		if err != nil {
+			diags.AddAttributeError(path.Root("projection"), "Error projecting Backstage entities",
+				fmt.Sprintf("Could not apply projection %q to Backstage entity %s: %s.", expression.ValueString(), r, err.Error()))
+			return nil
+		}
+	}
+
+	return results
+}
+
+// queryEntity reads the raw JSON of the entity from the catalog and applies the JMESPath expression to it, returning the JSON encoded result.
+// The result is null, if the expression is not set.
+func (p *providerData) queryEntity(ctx context.Context, expression types.String, kind string, namespace string, name string,
+	diags *diag.Diagnostics) jsontypes.Normalized {
+	if expression.IsNull() {
+		return jsontypes.NewNormalizedNull()
+	}
+
+	compiled, err := jmespath.Compile(expression.ValueString())
+	if err != nil {
+		diags.AddAttributeError(path.Root("query"), "Invalid query of Backstage "+kind+" kind",
+			fmt.Sprintf("Could not parse query %q: %s.", expression.ValueString(), err.Error()))
+		return jsontypes.NewNormalizedNull()
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting raw %s kind %s/%s from Backstage API", kind, namespace, name))
+	var raw interface{}
+	response, err := p.doJSON(ctx, http.MethodGet, fmt.Sprintf(pathEntityByName, url.PathEscape(strings.ToLower(kind)), url.PathEscape(namespace),
+		url.PathEscape(name)), nil, &raw)
+	if err != nil {
+		diags.AddError("Error querying Backstage "+kind+" kind",
+			p.withRequestID(fmt.Sprintf("Could not read raw Backstage %s kind %s/%s: %s", kind, namespace, name, err.Error())))
+		return jsontypes.NewNormalizedNull()
+	}
+
+	if response.StatusCode != http.StatusOK {
+		diags.AddError("Error querying Backstage "+kind+" kind",
+			p.withRequestID(fmt.Sprintf("Could not read raw Backstage %s kind %s/%s: %s", kind, namespace, name, response.Status)))
+		return jsontypes.NewNormalizedNull()
+	}
+
+	// Sensitive annotations are removed, as the result is not sensitive and would otherwise reveal them in the plan and state.
+	p.removeSensitiveAnnotations(raw)
+	result, err := compiled.Search(raw)
+	if err == nil {
+		var v []byte
+		if v, err = json.Marshal(result); err == nil {
+			return jsontypes.NewNormalizedValue(string(v))
+		}
+	}
+
+	diags.AddAttributeError(path.Root("query"), "Error querying Backstage "+kind+" kind",
+		fmt.Sprintf("Could not apply query %q to Backstage %s kind %s/%s: %s.", expression.ValueString(), kind, namespace, name, err.Error()))
+	return jsontypes.NewNormalizedNull()
+}
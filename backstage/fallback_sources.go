@@ -0,0 +1,327 @@
+package backstage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	fallbackSourceInline    = "inline"
+	fallbackSourceFile      = "file"
+	fallbackSourceDirectory = "directory"
+	fallbackSourceLastRead  = "last_read"
+
+	descriptionEntityFallbackFile    = "Path of a descriptor file of the entity, in YAML or JSON, e.g. its `catalog-info.yaml`, to fall back to."
+	descriptionEntityFallbackSources = "Sources of the fallback in order of precedence, when the entity cannot be read from Backstage: `" +
+		fallbackSourceInline + "` (the `fallback` attribute), `" + fallbackSourceFile + "` (`fallback_file`), `" + fallbackSourceDirectory +
+		"` (`fallback_directory` of the provider) and `" + fallbackSourceLastRead + "` (`last_read_directory` of the provider). Sources are " +
+		"resolved in parallel and the first one providing the entity is used. Defaults to all of them in this order."
+	descriptionEntityFallbackSource = "Source of the fallback used, as listed in `fallback_sources`, or null if the entity was read from Backstage."
+)
+
+// defaultFallbackSources are the sources of fallbacks in order of precedence, if a data source does not list them.
+var defaultFallbackSources = []string{fallbackSourceInline, fallbackSourceFile, fallbackSourceDirectory, fallbackSourceLastRead}
+
+// fallbackFileValidators validate the path of fallback files of data sources.
+var fallbackFileValidators = []validator.String{
+	stringvalidator.LengthAtLeast(1),
+}
+
+// fallbackSourcesValidators validate the sources of fallbacks listed by data sources.
+var fallbackSourcesValidators = []validator.List{
+	listvalidator.SizeAtLeast(1),
+	listvalidator.UniqueValues(),
+	listvalidator.ValueStringsAre(stringvalidator.OneOf(defaultFallbackSources...)),
+}
+
+// resolveFallback replaces the fallback with the one of the first of the sources providing it, in order of precedence, and returns the
+// source used, null if none provided the entity. Sources are read in parallel, failures to read them are reported as warnings. The fallback
+// is a pointer to the fallback model of the data source, which is read from descriptors of the entity by matching attributes by their tfsdk
+// tags. Fallback defaults of the provider are applied to fallbacks read from descriptors, as to the inline fallback.
+func (p *providerData) resolveFallback(ctx context.Context, kind, namespace, name string, sources []types.String, file types.String,
+	fallback interface{}, diags *diag.Diagnostics) types.String {
+	order := defaultFallbackSources
+	if sources != nil {
+		order = make([]string, 0, len(sources))
+		for _, s := range sources {
+			order = append(order, s.ValueString())
+		}
+	}
+
+	target := reflect.ValueOf(fallback).Elem()
+	inline := target.Interface()
+	target.Set(reflect.Zero(target.Type()))
+
+	paths := map[string]string{}
+	if !file.IsNull() {
+		paths[fallbackSourceFile] = file.ValueString()
+	}
+	if p.fallbackDirectory != "" {
+		paths[fallbackSourceDirectory] = entityDescriptorPath(p.fallbackDirectory, kind, namespace, name)
+	}
+	if p.lastReadDirectory != "" {
+		paths[fallbackSourceLastRead] = entityDescriptorPath(p.lastReadDirectory, kind, namespace, name)
+	}
+
+	type result struct {
+		entity map[string]interface{}
+		err    error
+	}
+	results := make([]result, len(order))
+	var wg sync.WaitGroup
+	for i, source := range order {
+		descriptorPath, ok := paths[source]
+		if !ok {
+			continue
+		}
+		wg.Add(1)
+		go func(i int, source string) {
+			defer wg.Done()
+			entity, err := readEntityDescriptor(descriptorPath, kind, name)
+			// Descriptors missing from the directories of the provider are expected, only the file of the data source must exist.
+			if errors.Is(err, os.ErrNotExist) && source != fallbackSourceFile {
+				err = nil
+			}
+			results[i] = result{entity: entity, err: err}
+		}(i, source)
+	}
+	wg.Wait()
+
+	for i, source := range order {
+		if source == fallbackSourceInline {
+			if !reflect.ValueOf(inline).IsNil() {
+				target.Set(reflect.ValueOf(inline))
+				return types.StringValue(source)
+			}
+			continue
+		}
+
+		if results[i].err != nil {
+			diags.AddWarning(fmt.Sprintf("Could not read %s fallback of Backstage %s kind", source, kind),
+				fmt.Sprintf("Could not read fallback of Backstage %s kind %s/%s from %s: %s", kind, namespace, name, paths[source],
+					results[i].err.Error()))
+			continue
+		}
+		if results[i].entity == nil {
+			continue
+		}
+
+		model := reflect.New(target.Type().Elem())
+		if err := decodeEntityDescriptor(results[i].entity, model.Elem(), true); err != nil {
+			diags.AddWarning(fmt.Sprintf("Could not read %s fallback of Backstage %s kind", source, kind),
+				fmt.Sprintf("Could not read fallback of Backstage %s kind %s/%s from %s: %s", kind, namespace, name, paths[source], err.Error()))
+			continue
+		}
+		p.applyFallbackDefaults(kind, model.Interface(), diags)
+
+		tflog.Debug(ctx, fmt.Sprintf("Falling back to %s kind %s/%s from %s", kind, namespace, name, paths[source]))
+		target.Set(model)
+		return types.StringValue(source)
+	}
+
+	return types.StringNull()
+}
+
+// saveLastRead writes the entity to the last read directory of the provider, if configured, so it can be fallen back to by later runs.
+// Sensitive annotations are left out, and the files are only readable by the user, like the disk cache. Failures to write are logged, but do
+// not fail the read.
+func (p *providerData) saveLastRead(ctx context.Context, kind, namespace, name string, entity interface{}) {
+	if p.lastReadDirectory == "" {
+		return
+	}
+
+	descriptorPath := entityDescriptorPath(p.lastReadDirectory, kind, namespace, name)
+	v, err := p.withoutSensitiveAnnotations(entity)
+	if err == nil {
+		err = os.MkdirAll(filepath.Dir(descriptorPath), 0o700)
+	}
+	if err == nil {
+		err = os.WriteFile(descriptorPath, v, 0o600)
+	}
+	if err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to save last read of %s kind %s/%s: %s", kind, namespace, name, err.Error()))
+	}
+}
+
+// withoutSensitiveAnnotations returns the entity as JSON, without the annotations listed in `sensitive_annotations` of the provider.
+func (p *providerData) withoutSensitiveAnnotations(entity interface{}) ([]byte, error) {
+	v, err := json.Marshal(entity)
+	if err != nil || len(p.sensitiveAnnotations) == 0 {
+		return v, err
+	}
+
+	var raw map[string]interface{}
+	if err := json.Unmarshal(v, &raw); err != nil {
+		return nil, err
+	}
+	p.removeSensitiveAnnotations(raw)
+
+	return json.Marshal(raw)
+}
+
+// removeSensitiveAnnotations removes the annotations listed in `sensitive_annotations` of the provider from the entity decoded from JSON.
+func (p *providerData) removeSensitiveAnnotations(raw interface{}) {
+	entity, _ := raw.(map[string]interface{})
+	if metadata, ok := entity["metadata"].(map[string]interface{}); ok {
+		if annotations, ok := metadata["annotations"].(map[string]interface{}); ok {
+			for k := range p.sensitiveAnnotations {
+				delete(annotations, k)
+			}
+		}
+	}
+}
+
+// entityDescriptorPath returns the path of the descriptor of the entity within the directory: `<namespace>/<kind>/<name>.json`, lower case.
+func entityDescriptorPath(directory, kind, namespace, name string) string {
+	return filepath.Join(directory, strings.ToLower(namespace), strings.ToLower(kind), strings.ToLower(name)+".json")
+}
+
+// readEntityDescriptor reads the descriptor of the entity from the YAML or JSON file. Of files with several documents, such as
+// `catalog-info.yaml` describing several entities, the document of the kind and name is read, other files must describe the entity.
+func readEntityDescriptor(descriptorPath, kind, name string) (map[string]interface{}, error) {
+	f, err := os.Open(descriptorPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var documents []map[string]interface{}
+	decoder := yaml.NewDecoder(f)
+	for {
+		var document map[string]interface{}
+		if err := decoder.Decode(&document); errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		if document != nil {
+			documents = append(documents, document)
+		}
+	}
+
+	for _, document := range documents {
+		documentKind, _ := document["kind"].(string)
+		metadata, _ := document["metadata"].(map[string]interface{})
+		documentName, _ := metadata["name"].(string)
+		if strings.EqualFold(documentKind, kind) && strings.EqualFold(documentName, name) {
+			return document, nil
+		}
+	}
+	if len(documents) == 1 {
+		return documents[0], nil
+	}
+
+	return nil, fmt.Errorf("no document describes %s kind %s", kind, name)
+}
+
+// decodeEntityDescriptor sets the attributes of the data model to the fields of the descriptor, matching attributes by their tfsdk tags
+// converted to camel case. At the root of the entity, `id`, `name` and `namespace` are read from its metadata.
+func decodeEntityDescriptor(descriptor map[string]interface{}, v reflect.Value, root bool) error {
+	metadata, _ := descriptor["metadata"].(map[string]interface{})
+	for i := 0; i < v.NumField(); i++ {
+		tag := v.Type().Field(i).Tag.Get("tfsdk")
+		value, ok := descriptor[camelCase(tag)]
+		if root {
+			switch tag {
+			case "id":
+				value, ok = metadata["uid"]
+			case "name", "namespace":
+				value, ok = metadata[tag]
+			}
+		}
+		if !ok || value == nil {
+			continue
+		}
+
+		if err := decodeDescriptorValue(value, v.Field(i)); err != nil {
+			return fmt.Errorf("%s: %w", tag, err)
+		}
+	}
+
+	return nil
+}
+
+// decodeDescriptorValue sets the field of the data model to the value of the descriptor.
+func decodeDescriptorValue(value interface{}, field reflect.Value) error {
+	switch field.Interface().(type) {
+	case types.String:
+		switch value.(type) {
+		case map[string]interface{}, []interface{}:
+			return errors.New("expected a string")
+		}
+		field.Set(reflect.ValueOf(types.StringValue(fmt.Sprint(value))))
+		return nil
+	case map[string]string:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("expected a map")
+		}
+		m := make(map[string]string, len(values))
+		for k, v := range values {
+			m[k] = fmt.Sprint(v)
+		}
+		field.Set(reflect.ValueOf(m))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.Ptr:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("expected an object")
+		}
+		nested := reflect.New(field.Type().Elem())
+		if err := decodeEntityDescriptor(values, nested.Elem(), false); err != nil {
+			return err
+		}
+		field.Set(nested)
+	case reflect.Slice:
+		values, ok := value.([]interface{})
+		if !ok {
+			return errors.New("expected a list")
+		}
+		items := reflect.MakeSlice(field.Type(), len(values), len(values))
+		for i, item := range values {
+			if err := decodeDescriptorValue(item, items.Index(i)); err != nil {
+				return err
+			}
+		}
+		field.Set(items)
+	case reflect.Struct:
+		values, ok := value.(map[string]interface{})
+		if !ok {
+			return errors.New("expected an object")
+		}
+		return decodeEntityDescriptor(values, field, false)
+	}
+
+	return nil
+}
+
+// camelCase converts the snake case name of an attribute to the camel case name of the field of entities, e.g. `api_version` to `apiVersion`.
+func camelCase(name string) string {
+	parts := strings.Split(name, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+
+	return strings.Join(parts, "")
+}
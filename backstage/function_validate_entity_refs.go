@@ -0,0 +1,70 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+var _ function.Function = &validateEntityRefsFunction{}
+
+// NewValidateEntityRefsFunction is a helper function to simplify the provider implementation.
+func NewValidateEntityRefsFunction() function.Function {
+	return &validateEntityRefsFunction{}
+}
+
+// validateEntityRefsFunction is the function implementation.
+type validateEntityRefsFunction struct{}
+
+// Metadata returns the function name.
+func (f *validateEntityRefsFunction) Metadata(_ context.Context, _ function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "validate_entity_refs"
+}
+
+// Definition defines the parameters and return type of the function.
+func (f *validateEntityRefsFunction) Definition(_ context.Context, _ function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Checks that all elements of a list are well-formed entity references",
+		MarkdownDescription: "Returns `true` if all elements of the list are well-formed entity references, with optional kind and namespace, " +
+			"e.g. `team-a` or `group:default/team-a`. Fails with the indices of the elements that are not, so it can be used as `condition` " +
+			"of the validation of variables of modules accepting many references.",
+		Parameters: []function.Parameter{
+			function.ListParameter{
+				Name:                "refs",
+				MarkdownDescription: "Entity references to check.",
+				ElementType:         types.StringType,
+			},
+		},
+		Return: function.BoolReturn{},
+	}
+}
+
+// Run checks the entity references.
+func (f *validateEntityRefsFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var refs []string
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, req.Arguments.Get(ctx, &refs))
+	if resp.Error != nil {
+		return
+	}
+
+	pattern := regexp.MustCompile(patternEntityRef)
+	invalid := []string{}
+	for i, r := range refs {
+		if !pattern.MatchString(r) {
+			invalid = append(invalid, fmt.Sprintf("%d (%q)", i, r))
+		}
+	}
+
+	if len(invalid) > 0 {
+		resp.Error = function.NewArgumentFuncError(0, fmt.Sprintf("Elements at indices %s are not well-formed entity references, expected "+
+			"[<kind>:][<namespace>/]<name>, e.g. group:default/team-a.", strings.Join(invalid, ", ")))
+		return
+	}
+
+	resp.Error = function.ConcatFuncErrors(resp.Error, resp.Result.Set(ctx, true))
+}
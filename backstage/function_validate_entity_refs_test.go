@@ -0,0 +1,32 @@
+package backstage
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccFunctionValidateEntityRefs(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: `
+					output "test" {
+						value = provider::backstage::validate_entity_refs(["team-a", "default/team-a", "group:default/team-a"])
+					}
+				`,
+				Check: resource.TestCheckOutput("test", "true"),
+			},
+			{
+				Config: `
+					output "test" {
+						value = provider::backstage::validate_entity_refs(["team-a", "group:default/team/a", "user:"])
+					}
+				`,
+				ExpectError: regexp.MustCompile(`indices 1 \("group:default/team/a"\), 2 \("user:"\)`),
+			},
+		},
+	})
+}
@@ -0,0 +1,80 @@
+package backstage
+
+import (
+	"net/http"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// Option customizes the provider built by New, e.g. to register additional data sources and resources.
+type Option func(*backstageProvider)
+
+// WithDataSources registers additional data sources with the provider, next to the ones it implements. Their type names must not clash
+// with the ones of the provider, e.g. by using a company-specific prefix such as `backstage_acme_`.
+func WithDataSources(factories ...func() datasource.DataSource) Option {
+	return func(p *backstageProvider) {
+		p.extraDataSources = append(p.extraDataSources, factories...)
+	}
+}
+
+// WithResources registers additional resources with the provider, next to the ones it implements. Their type names must not clash with the
+// ones of the provider.
+func WithResources(factories ...func() resource.Resource) Option {
+	return func(p *backstageProvider) {
+		p.extraResources = append(p.extraResources, factories...)
+	}
+}
+
+// ProviderData is passed by the provider as ProviderData to the Configure methods of data sources and resources, so the ones registered
+// with WithDataSources and WithResources can reuse the clients of the provider, with its authentication, headers, retries and cache:
+//
+//	func (d *acmeDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+//		if data, ok := req.ProviderData.(backstage.ProviderData); ok {
+//			d.client = data.Client()
+//		}
+//	}
+type ProviderData interface {
+	// Client returns the client of the Backstage API.
+	Client() *backstage.Client
+
+	// HTTPClient returns the HTTP client of the provider, for requests to APIs of Backstage plugins the client does not support.
+	HTTPClient() *http.Client
+
+	// BaseURL returns the base URL of the Backstage instance, which URLs of requests sent with HTTPClient are built from.
+	BaseURL() string
+
+	// DefaultNamespace returns the namespace of entities data sources read, if they do not set one.
+	DefaultNamespace() string
+
+	// WithRequestID appends the correlation ID of requests to the detail of a diagnostic.
+	WithRequestID(detail string) string
+}
+
+var _ ProviderData = &providerData{}
+
+// Client returns the client of the Backstage API.
+func (p *providerData) Client() *backstage.Client {
+	return p.client
+}
+
+// HTTPClient returns the HTTP client of the provider.
+func (p *providerData) HTTPClient() *http.Client {
+	return p.httpClient
+}
+
+// BaseURL returns the base URL of the Backstage instance.
+func (p *providerData) BaseURL() string {
+	return p.baseURL
+}
+
+// DefaultNamespace returns the namespace of entities data sources read, if they do not set one.
+func (p *providerData) DefaultNamespace() string {
+	return p.defaultNamespace
+}
+
+// WithRequestID appends the correlation ID of requests to the detail of a diagnostic.
+func (p *providerData) WithRequestID(detail string) string {
+	return p.withRequestID(detail)
+}
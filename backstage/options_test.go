@@ -0,0 +1,66 @@
+package backstage
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccProvider_WithDataSources(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: map[string]func() (tfprotov6.ProviderServer, error){
+			"backstage": providerserver.NewProtocol6WithError(New("test", WithDataSources(newTestExtensionDataSource))()),
+		},
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProviderConfig + `
+					data "backstage_test_extension" "test" {}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_test_extension.test", "default_namespace", "default"),
+					resource.TestCheckResourceAttrSet("data.backstage_test_extension.test", "base_url"),
+				),
+			},
+		},
+	})
+}
+
+// testExtensionDataSource is a data source registered with WithDataSources, as internal forks would.
+type testExtensionDataSource struct {
+	data ProviderData
+}
+
+func newTestExtensionDataSource() datasource.DataSource {
+	return &testExtensionDataSource{}
+}
+
+func (d *testExtensionDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_test_extension"
+}
+
+func (d *testExtensionDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"base_url":          schema.StringAttribute{Computed: true},
+			"default_namespace": schema.StringAttribute{Computed: true},
+		},
+	}
+}
+
+func (d *testExtensionDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, _ *datasource.ConfigureResponse) {
+	if data, ok := req.ProviderData.(ProviderData); ok {
+		d.data = data
+	}
+}
+
+func (d *testExtensionDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("base_url"), types.StringValue(d.data.BaseURL()))...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("default_namespace"), types.StringValue(d.data.DefaultNamespace()))...)
+}
@@ -2,18 +2,32 @@ package backstage
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"maps"
 	"net/http"
 	"os"
+	"path/filepath"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/cache"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/metrics"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/token"
 	"github.com/datolabs-io/terraform-provider-backstage/internal/transport"
 	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/function"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -23,39 +37,219 @@ import (
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
-var _ provider.Provider = &backstageProvider{}
+var (
+	_ provider.Provider              = &backstageProvider{}
+	_ provider.ProviderWithFunctions = &backstageProvider{}
+)
 
 // backstageProvider defines the provider implementation.
 type backstageProvider struct {
 	version string
+
+	// extraDataSources and extraResources are registered with options of New, next to the ones of the provider.
+	extraDataSources []func() datasource.DataSource
+	extraResources   []func() resource.Resource
+
+	// closers are the recorders of metrics of each Configure, which Close emits the metrics held back by.
+	closers []metrics.Closer
 }
 
 // backstageProviderModel describes the provider data model.
 type backstageProviderModel struct {
-	BaseURL          types.String `tfsdk:"base_url"`
-	DefaultNamespace types.String `tfsdk:"default_namespace"`
-	Headers          types.Map    `tfsdk:"headers"`
-	Retries          types.Int64  `tfsdk:"retries"`
-	TimeoutSeconds   types.Int64  `tfsdk:"timeout_seconds"`
+	BaseURL                      types.String                   `tfsdk:"base_url"`
+	AppURL                       types.String                   `tfsdk:"app_url"`
+	APIKey                       types.String                   `tfsdk:"api_key"`
+	IdentityToken                types.String                   `tfsdk:"identity_token"`
+	DefaultNamespace             types.String                   `tfsdk:"default_namespace"`
+	Headers                      types.Map                      `tfsdk:"headers"`
+	Retries                      types.Int64                    `tfsdk:"retries"`
+	TimeoutSeconds               types.Int64                    `tfsdk:"timeout_seconds"`
+	TLSServerName                types.String                   `tfsdk:"tls_server_name"`
+	UnixSocket                   types.String                   `tfsdk:"unix_socket"`
+	DialAddress                  types.String                   `tfsdk:"dial_address"`
+	RetryBudgetSeconds           types.Int64                    `tfsdk:"retry_budget_seconds"`
+	TokenClockSkewSeconds        types.Int64                    `tfsdk:"token_clock_skew_seconds"`
+	ExpectedRunSeconds           types.Int64                    `tfsdk:"expected_run_seconds"`
+	RequestID                    types.String                   `tfsdk:"request_id"`
+	AuditLogFile                 types.String                   `tfsdk:"audit_log_file"`
+	UnknownRelationTypes         types.String                   `tfsdk:"unknown_relation_types"`
+	CustomRelationTypes          []string                       `tfsdk:"custom_relation_types"`
+	FallbackDefaults             map[string]map[string]string   `tfsdk:"fallback_defaults"`
+	FallbackDirectory            types.String                   `tfsdk:"fallback_directory"`
+	LastReadDirectory            types.String                   `tfsdk:"last_read_directory"`
+	SensitiveAnnotations         []string                       `tfsdk:"sensitive_annotations"`
+	SensitiveAnnotationsHandling types.String                   `tfsdk:"sensitive_annotations_handling"`
+	LegacyEmptyStrings           types.Bool                     `tfsdk:"legacy_empty_strings"`
+	AliasAnnotations             []string                       `tfsdk:"alias_annotations"`
+	ResponseHeaders              []string                       `tfsdk:"response_headers"`
+	Cache                        *providerCacheModel            `tfsdk:"cache"`
+	Metrics                      *providerMetricsModel          `tfsdk:"metrics"`
+	AccessPolicy                 *providerAccessPolicyModel     `tfsdk:"access_policy"`
+	FailureInjection             *providerFailureInjectionModel `tfsdk:"failure_injection"`
+}
+
+// defaultResponseHeaders are the headers of responses exposed in request_info of data sources, if the provider does not list them.
+var defaultResponseHeaders = []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset", "Retry-After", "X-Served-By", "X-Cache"}
+
+// providerCacheModel describes the cache configuration data model.
+type providerCacheModel struct {
+	Type            types.String `tfsdk:"type"`
+	TTLSeconds      types.Int64  `tfsdk:"ttl_seconds"`
+	Directory       types.String `tfsdk:"directory"`
+	Endpoint        types.String `tfsdk:"endpoint"`
+	WarmFilters     []string     `tfsdk:"warm_filters"`
+	WarmConcurrency types.Int64  `tfsdk:"warm_concurrency"`
+}
+
+// providerMetricsModel describes the metrics configuration data model.
+type providerMetricsModel struct {
+	Type     types.String `tfsdk:"type"`
+	Endpoint types.String `tfsdk:"endpoint"`
+	Prefix   types.String `tfsdk:"prefix"`
+	Job      types.String `tfsdk:"job"`
+}
+
+// providerAccessPolicyModel describes the access policy configuration data model.
+type providerAccessPolicyModel struct {
+	Default           types.String `tfsdk:"default"`
+	AllowedKinds      []string     `tfsdk:"allowed_kinds"`
+	DeniedKinds       []string     `tfsdk:"denied_kinds"`
+	AllowedNamespaces []string     `tfsdk:"allowed_namespaces"`
+	DeniedNamespaces  []string     `tfsdk:"denied_namespaces"`
 }
 
+// providerFailureInjectionModel describes the failure injection configuration data model.
+type providerFailureInjectionModel struct {
+	Rate       types.Float64 `tfsdk:"rate"`
+	StatusCode types.Int64   `tfsdk:"status_code"`
+}
+
+const (
+	// defaultTokenClockSkew is the default tolerance for differences between the clocks of the provider and of Backstage.
+	defaultTokenClockSkew = time.Minute
+	// defaultExpectedRun is the default expected duration of Terraform runs, tokens expiring within which are warned about.
+	defaultExpectedRun = 15 * time.Minute
+)
+
 const (
 	patternURL                 = "https?://.+"
 	envBaseURL                 = "BACKSTAGE_BASE_URL"
+	envAPIKey                  = "BACKSTAGE_API_KEY"
+	envIdentityToken           = "BACKSTAGE_IDENTITY_TOKEN"
 	envDefaultNamespace        = "BACKSTAGE_DEFAULT_NAMESPACE"
 	envHeaders                 = "BACKSTAGE_HEADERS"
 	envRetries                 = "BACKSTAGE_RETRIES"
 	envTimeoutSeconds          = "BACKSTAGE_TIMEOUT_SECONDS"
+	envCacheEndpoint           = "BACKSTAGE_CACHE_ENDPOINT"
+	envRequestID               = "BACKSTAGE_REQUEST_ID"
+	envAuditLogFile            = "BACKSTAGE_AUDIT_LOG_FILE"
+	envTLSServerName           = "BACKSTAGE_TLS_SERVER_NAME"
+	cacheTypeMemory            = "memory"
+	cacheTypeDisk              = "disk"
+	cacheTypeHTTP              = "http"
+	metricsTypeStatsd          = "statsd"
+	metricsTypePushgateway     = "pushgateway"
+	metricsDefaultPrefix       = "terraform_provider_backstage"
 	descriptionProviderBaseURL = "Base URL of the Backstage instance, e.g. https://demo.backstage.io. May also be provided via `" + envBaseURL +
-		"` environment variable."
+		"` environment variable. It must point at the backend serving the API, not at the frontend: reads answered by the frontend fail with " +
+		"an error suggesting the URL of the backend."
+	descriptionProviderAppURL = "Base URL of the Backstage UI, e.g. https://demo.backstage.io, links to pages of entities such as `catalog_url` " +
+		"are built from. Defaults to `base_url`, which suits instances serving the UI and the API from the same URL."
+	descriptionProviderTLSServerName = "Host name to verify the certificate of the Backstage instance against, and to send as server name (SNI) " +
+		"in the TLS handshake, instead of the host of `base_url`. Useful when Backstage is reached via an IP address or an internal alias, but " +
+		"its certificate is issued for another host name. May also be provided via `" + envTLSServerName + "` environment variable."
+	descriptionProviderUnixSocket = "Path of a Unix domain socket to connect to Backstage over, e.g. of a sidecar proxying it, instead of " +
+		"connecting to the host of `base_url`. Requests keep the host of `base_url` in the `Host` header."
+	descriptionProviderDialAddress = "Address to connect to Backstage at, e.g. `127.0.0.1:8080` of a `kubectl port-forward`, instead of the " +
+		"host of `base_url`. Requests keep the host of `base_url` in the `Host` header, and certificates are verified against it, unless " +
+		"`tls_server_name` is set."
+	descriptionProviderAPIKey = "Static token sent as `Authorization: Bearer` header with each request to the Backstage API, unless the " +
+		"`Authorization` header is set in `headers`. May also be provided via `" + envAPIKey + "` environment variable."
+	descriptionProviderIdentityToken = "Backstage token of a user, whose permissions data sources with `read_as = \"identity\"` read entities " +
+		"with, so they see the entities the user sees in the portal. May also be provided via `" + envIdentityToken + "` environment variable."
 	descriptionProviderDefaultNamespace = "Name of default namespace for entities (`default`, if not set). May also be provided via `" + envDefaultNamespace +
 		"` environment variable."
 	descriptionProviderHeaders = "Headers to be sent with each request to the Backstage API. Useful for authentication. May also be provided via `" + envHeaders +
-		"` environment variable."
+		"` environment variable, as comma separated `name=value` pairs. The headers set in the configuration replace all the headers of " +
+		"the environment variable."
 	descriptionProviderRetries = "Number of retries to attempt on recoverable API errors (default: 0). May also be provided via `" + envRetries +
 		"` environment variable."
 	descriptionProviderTimeoutSeconds = "Timeout for requests to the Backstage API in seconds (default: 15). May also be provided via `" + envTimeoutSeconds +
 		"` environment variable."
+	descriptionProviderRequestID = "Correlation ID sent as `X-Request-Id` header with each request to the Backstage API and included in error messages, " +
+		"so failed reads can be matched to logs of the Backstage backend. Generated for each Terraform run, if not set. May also be provided via `" +
+		envRequestID + "` environment variable."
+	descriptionProviderAuditLogFile = "Path of a JSON Lines file to record every read of entities to, along with the Terraform workspace and run " +
+		"it was requested by and whether fallback data was used. Reads are not recorded, if not set. May also be provided via `" + envAuditLogFile +
+		"` environment variable."
+	descriptionProviderUnknownRelationTypes = "Handling of relations of types that are neither well known to Backstage nor listed in " +
+		"`custom_relation_types`: `" + relationTypesIgnore + "` (default), `" + relationTypesWarn + "` or `" + relationTypesFail +
+		"`. Relations of all types are exposed verbatim by data sources regardless."
+	descriptionProviderAliasAnnotations = "Keys of annotations listing former names of entities, separated by commas, which data sources with " +
+		"`follow_aliases` look entities up by when they are not found by name (default: `" + annotationAliases + "`)."
+	descriptionProviderResponseHeaders = "Names of headers of responses of the Backstage API that data sources expose in `request_info`, such as " +
+		"rate limits or cache statuses set by gateways in front of the Backstage instance (default: `X-RateLimit-Limit`, `X-RateLimit-Remaining`, " +
+		"`X-RateLimit-Reset`, `Retry-After`, `X-Served-By` and `X-Cache`)."
+	descriptionProviderCustomRelationTypes = "Types of relations added by plugins or custom processors that are expected and not reported as unknown."
+	descriptionProviderFallbackDefaults    = "Defaults of fallbacks of data sources, keyed by kind of the entity (e.g. `Component`) and dot separated path " +
+		"of the attribute within `fallback` (e.g. `spec.owner` or `metadata.annotations.backstage.io/techdocs-ref`). Defaults are merged under the " +
+		"fallback set in each data source: they only apply to the attributes the data source does not set."
+	descriptionProviderFallbackDirectory = "Directory of descriptors of entities, in YAML or JSON, that data sources fall back to when their entity " +
+		"cannot be read from Backstage, if `" + fallbackSourceDirectory + "` is listed in their `fallback_sources`. The descriptor of each entity " +
+		"is read from `<namespace>/<kind>/<name>.json` within the directory, in lower case."
+	descriptionProviderLastReadDirectory = "Directory each entity read by data sources is saved to, at `<namespace>/<kind>/<name>.json` in lower " +
+		"case, so data sources fall back to the entity as last read when it cannot be read from Backstage, if `" + fallbackSourceLastRead +
+		"` is listed in their `fallback_sources`. Point it at a directory kept between runs, such as a cache of the CI system. Files are only " +
+		"readable by the user running Terraform, and leave out the annotations listed in `sensitive_annotations`."
+	descriptionProviderSensitiveAnnotations = "Keys of annotations whose values are secrets, such as integration keys. Data sources move them from " +
+		"`metadata.annotations` to `metadata.sensitive_annotations`, which is marked as sensitive, so their values are not shown in plans and CI logs."
+	descriptionProviderSensitiveAnnotationsHandling = "Handling of annotations listed in `sensitive_annotations`: `" + sensitiveAnnotationsMark +
+		"` (default) to move them to `metadata.sensitive_annotations`, or `" + sensitiveAnnotationsStrip + "` to leave them out of the state entirely."
+	descriptionProviderLegacyEmptyStrings = "Whether data sources write optional fields of entities that are not set, such as `spec.system` or " +
+		"`metadata.title`, as empty strings, like earlier versions of the provider did, instead of null values (default: false). Set it to keep configurations " +
+		"comparing these fields to `\"\"` working while they are migrated to null checks."
+	descriptionProviderAccessPolicy = "Restricts the kinds and namespaces of entities data sources may read, so usage of a broad token on a shared " +
+		"runner can be constrained in code. Reading a single entity that is denied fails, denied entities are left out of lists. Entities of all " +
+		"kinds and namespaces may be read, if not set."
+	descriptionProviderAccessPolicyDefault = "Whether kinds and namespaces not listed are `" + accessPolicyAllow + "`ed (default) or `" + accessPolicyDeny +
+		"`ed. When `" + accessPolicyDeny + "`, both the kind and the namespace of an entity must be allowed for it to be read."
+	descriptionProviderAccessPolicyAllowedKinds      = "Kinds of entities that may be read, when `default` is `" + accessPolicyDeny + "`."
+	descriptionProviderAccessPolicyDeniedKinds       = "Kinds of entities that may not be read."
+	descriptionProviderAccessPolicyAllowedNamespaces = "Namespaces of entities that may be read, when `default` is `" + accessPolicyDeny + "`."
+	descriptionProviderAccessPolicyDeniedNamespaces  = "Namespaces of entities that may not be read."
+	descriptionProviderRetryBudgetSeconds            = "Maximum time in seconds to wait between retries of requests in total during a Terraform run. Once it " +
+		"is spent, failed requests are no longer retried, so a degraded Backstage instance can not stall a run for `retries` of every request. " +
+		"Waits are not limited, if not set."
+	descriptionProviderTokenClockSkewSeconds = "Tolerance in seconds for differences between the clocks of the provider and of Backstage, when " +
+		"checking the validity period of `api_key` and `identity_token`, if they are JSON Web Tokens (default: 60). Tokens expired, or " +
+		"not valid yet, beyond the tolerance fail the configuration of the provider instead of requests midway through the run."
+	descriptionProviderExpectedRunSeconds = "Expected duration of Terraform runs in seconds (default: 900). A warning is shown, if `api_key` or " +
+		"`identity_token` is a JSON Web Token expiring within it, as requests late in the run would fail to authenticate."
+	descriptionProviderFailureInjection = "Configuration of failures injected into requests to the Backstage API, to test how configurations behave " +
+		"when the Backstage instance degrades. Failed requests are not sent and are retried like other failures. Meant for test environments only: " +
+		"failures are not injected, if not set."
+	descriptionProviderFailureInjectionRate       = "Share of requests to fail, between 0 and 1, e.g. `0.2` to fail every fifth request on average."
+	descriptionProviderFailureInjectionStatusCode = "Status code of the responses of failed requests (default: 503). If 0, requests fail with a " +
+		"connection error instead."
+	descriptionProviderCache     = "Configuration of the cache for responses of the Backstage API. Responses are not cached, if not set."
+	descriptionProviderCacheType = "Type of the cache: `" + cacheTypeMemory + "` (for the duration of a single Terraform run), `" + cacheTypeDisk +
+		"` (shared between runs on a single runner) or `" + cacheTypeHTTP + "` (shared between runners via a remote key/value store)."
+	descriptionProviderCacheTTLSeconds = "Time in seconds after which cached responses expire (default: 300)."
+	descriptionProviderCacheDirectory  = "Directory to store cached responses in, when `type` is `" + cacheTypeDisk + "`. Defaults to `terraform-provider-backstage` " +
+		"directory in the user cache directory."
+	descriptionProviderCacheEndpoint = "Base URL of the remote key/value store, when `type` is `" + cacheTypeHTTP + "`. Values are read with `GET {endpoint}/{key}` " +
+		"and stored with `PUT {endpoint}/{key}`. May also be provided via `" + envCacheEndpoint + "` environment variable."
+	descriptionProviderCacheWarmFilters = "Filters of the entities to read into the cache when the provider is configured, e.g. " +
+		"`kind=component,spec.owner=group:default/team-a`, so the data sources reading them are served from the cache instead of reading them " +
+		"one by one. Entities matching any of the filters are read. The cache is not warmed, if not set."
+	descriptionProviderCacheWarmConcurrency = "Number of entities read at a time when warming the cache (default: 8)."
+	descriptionProviderMetrics              = "Configuration of metrics emitted for provider operations: counts and latencies of requests to the Backstage API, " +
+		"usage of fallbacks and cache hits and misses. Metrics are not emitted, if not set."
+	descriptionProviderMetricsType = "Type of the metrics backend: `" + metricsTypeStatsd + "` or `" + metricsTypePushgateway + "` (Prometheus Pushgateway). " +
+		"Metrics are pushed to the Pushgateway every second, and once more when Terraform stops the provider."
+	descriptionProviderMetricsEndpoint = "Address of the StatsD daemon (`host:port`) or base URL of the Prometheus Pushgateway."
+	descriptionProviderMetricsPrefix   = "Prefix of names of all metrics (default: `" + metricsDefaultPrefix + "`)."
+	descriptionProviderMetricsJob      = "Name of the job metrics are pushed for, when `type` is `" + metricsTypePushgateway + "` (default: `terraform`)."
 )
 
 // Metadata returns the provider type name.
@@ -71,19 +265,105 @@ func (p *backstageProvider) Schema(_ context.Context, _ provider.SchemaRequest,
 			"You must configure the provider with proper base URL of your Backstage instance before you can use it.\n\n" +
 			"Use the navigation on the left to read about the available resources and data sources.\n\n To learn the basic of Terraform using this provider, " +
 			"follow hands-on [get started tutorials](https://learn.hashicorp.com/tutorials/terraform/infrastructure-as-code).\n\n" +
+			"Attributes that may also be provided via environment variables take the value set in the configuration, if any, over the " +
+			"environment variable, with a warning if both are set and differ. In earlier versions, `" + envHeaders + "`, `" + envRetries +
+			"` and `" + envTimeoutSeconds + "` took precedence over the configuration instead.\n\n" +
 			"Interested in the provider's latest features, or want to make sure you're up to date? Check out the " +
 			"[releases](https://github.com/datolabs-io/terraform-provider-backstage/releases) for version information and release notes.",
 		Attributes: map[string]schema.Attribute{
 			"base_url": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderBaseURL, Validators: []validator.String{
 				stringvalidator.RegexMatches(regexp.MustCompile(patternURL), "must be a valid URL"),
 			}},
+			"app_url": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderAppURL, Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(patternURL), "must be a valid URL"),
+			}},
+			"api_key":        schema.StringAttribute{Optional: true, Sensitive: true, MarkdownDescription: descriptionProviderAPIKey},
+			"identity_token": schema.StringAttribute{Optional: true, Sensitive: true, MarkdownDescription: descriptionProviderIdentityToken},
 			"default_namespace": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderDefaultNamespace, Validators: []validator.String{
 				stringvalidator.LengthBetween(1, 63),
 				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityName), "must follow Backstage format restrictions"),
 			}},
-			"headers":         schema.MapAttribute{Optional: true, ElementType: types.StringType, MarkdownDescription: descriptionProviderHeaders},
+			"headers": schema.MapAttribute{Optional: true, ElementType: types.StringType, MarkdownDescription: descriptionProviderHeaders},
+			"tls_server_name": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderTLSServerName, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}},
+			"unix_socket": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderUnixSocket, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+				stringvalidator.ConflictsWith(path.MatchRoot("dial_address")),
+			}},
+			"dial_address": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderDialAddress, Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^\S+:\d+$`), "must be a host and port, e.g. 127.0.0.1:8080"),
+			}},
 			"retries":         schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderRetries},
 			"timeout_seconds": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderTimeoutSeconds},
+			"request_id":      schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderRequestID},
+			"audit_log_file":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderAuditLogFile},
+			"unknown_relation_types": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderUnknownRelationTypes,
+				Validators: []validator.String{stringvalidator.OneOf(relationTypesIgnore, relationTypesWarn, relationTypesFail)}},
+			"custom_relation_types": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+				MarkdownDescription: descriptionProviderCustomRelationTypes},
+			"fallback_defaults": schema.MapAttribute{Optional: true, ElementType: types.MapType{ElemType: types.StringType},
+				MarkdownDescription: descriptionProviderFallbackDefaults},
+			"fallback_directory":  schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderFallbackDirectory},
+			"last_read_directory": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderLastReadDirectory},
+			"sensitive_annotations": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+				MarkdownDescription: descriptionProviderSensitiveAnnotations},
+			"sensitive_annotations_handling": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderSensitiveAnnotationsHandling,
+				Validators: []validator.String{stringvalidator.OneOf(sensitiveAnnotationsMark, sensitiveAnnotationsStrip)}},
+			"legacy_empty_strings": schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionProviderLegacyEmptyStrings,
+				DeprecationMessage: deprecationMessage("provider.legacy_empty_strings")},
+			"response_headers": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+				MarkdownDescription: descriptionProviderResponseHeaders},
+			"alias_annotations": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+				MarkdownDescription: descriptionProviderAliasAnnotations, Validators: []validator.List{listvalidator.SizeAtLeast(1)}},
+			"metrics": schema.SingleNestedAttribute{Optional: true, MarkdownDescription: descriptionProviderMetrics, Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{Required: true, MarkdownDescription: descriptionProviderMetricsType, Validators: []validator.String{
+					stringvalidator.OneOf(metricsTypeStatsd, metricsTypePushgateway),
+				}},
+				"endpoint": schema.StringAttribute{Required: true, MarkdownDescription: descriptionProviderMetricsEndpoint},
+				"prefix":   schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderMetricsPrefix},
+				"job":      schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderMetricsJob},
+			}},
+			"access_policy": schema.SingleNestedAttribute{Optional: true, MarkdownDescription: descriptionProviderAccessPolicy, Attributes: map[string]schema.Attribute{
+				"default": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderAccessPolicyDefault, Validators: []validator.String{
+					stringvalidator.OneOf(accessPolicyAllow, accessPolicyDeny),
+				}},
+				"allowed_kinds": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+					MarkdownDescription: descriptionProviderAccessPolicyAllowedKinds},
+				"denied_kinds": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+					MarkdownDescription: descriptionProviderAccessPolicyDeniedKinds},
+				"allowed_namespaces": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+					MarkdownDescription: descriptionProviderAccessPolicyAllowedNamespaces},
+				"denied_namespaces": schema.ListAttribute{Optional: true, ElementType: types.StringType,
+					MarkdownDescription: descriptionProviderAccessPolicyDeniedNamespaces},
+			}},
+			"retry_budget_seconds": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderRetryBudgetSeconds,
+				Validators: []validator.Int64{int64validator.AtLeast(0)}},
+			"token_clock_skew_seconds": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderTokenClockSkewSeconds,
+				Validators: []validator.Int64{int64validator.AtLeast(0)}},
+			"expected_run_seconds": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderExpectedRunSeconds,
+				Validators: []validator.Int64{int64validator.AtLeast(0)}},
+			"failure_injection": schema.SingleNestedAttribute{Optional: true, MarkdownDescription: descriptionProviderFailureInjection,
+				Attributes: map[string]schema.Attribute{
+					"rate": schema.Float64Attribute{Required: true, MarkdownDescription: descriptionProviderFailureInjectionRate,
+						Validators: []validator.Float64{float64validator.Between(0, 1)}},
+					"status_code": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderFailureInjectionStatusCode,
+						Validators: []validator.Int64{int64validator.Any(int64validator.OneOf(0), int64validator.Between(400, 599))}},
+				}},
+			"cache": schema.SingleNestedAttribute{Optional: true, MarkdownDescription: descriptionProviderCache, Attributes: map[string]schema.Attribute{
+				"type": schema.StringAttribute{Required: true, MarkdownDescription: descriptionProviderCacheType, Validators: []validator.String{
+					stringvalidator.OneOf(cacheTypeMemory, cacheTypeDisk, cacheTypeHTTP),
+				}},
+				"ttl_seconds": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderCacheTTLSeconds},
+				"directory":   schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderCacheDirectory},
+				"endpoint": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionProviderCacheEndpoint, Validators: []validator.String{
+					stringvalidator.RegexMatches(regexp.MustCompile(patternURL), "must be a valid URL"),
+				}},
+				"warm_filters": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionProviderCacheWarmFilters, ElementType: types.StringType,
+					Validators: []validator.List{listvalidator.SizeAtLeast(1), listvalidator.ValueStringsAre(stringvalidator.LengthAtLeast(1))}},
+				"warm_concurrency": schema.Int64Attribute{Optional: true, MarkdownDescription: descriptionProviderCacheWarmConcurrency,
+					Validators: []validator.Int64{int64validator.Between(1, 64)}},
+			}},
 		},
 	}
 }
@@ -104,6 +384,16 @@ func (p *backstageProvider) Configure(ctx context.Context, req provider.Configur
 			"Either target apply the source of the value first, set the value statically in the configuration, or use the %s environment variable.", envBaseURL))
 	}
 
+	if config.APIKey.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("api_key"), "Unknown API key of Backstage instance", fmt.Sprintf(
+			"Either target apply the source of the value first, set the value statically in the configuration, or use the %s environment variable.", envAPIKey))
+	}
+
+	if config.IdentityToken.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(path.Root("identity_token"), "Unknown identity token of Backstage instance", fmt.Sprintf(
+			"Either target apply the source of the value first, set the value statically in the configuration, or use the %s environment variable.", envIdentityToken))
+	}
+
 	if config.DefaultNamespace.IsUnknown() {
 		resp.Diagnostics.AddAttributeError(path.Root("default_namespace"), "Unknown default entities namespace of Backstage instance", fmt.Sprintf(
 			"Either target apply the source of the value first, set the value statically in the configuration, or use the %s environment variable.", envDefaultNamespace))
@@ -113,10 +403,19 @@ func (p *backstageProvider) Configure(ctx context.Context, req provider.Configur
 		return
 	}
 
-	baseURL := os.Getenv(envBaseURL)
-	if !config.BaseURL.IsNull() {
-		baseURL = config.BaseURL.ValueString()
+	baseURL := configOrEnv(config.BaseURL, envBaseURL, path.Root("base_url"), &resp.Diagnostics)
+	apiKey := configOrEnv(config.APIKey, envAPIKey, path.Root("api_key"), &resp.Diagnostics)
+	identityToken := configOrEnv(config.IdentityToken, envIdentityToken, path.Root("identity_token"), &resp.Diagnostics)
+
+	clockSkew, expectedRun := defaultTokenClockSkew, defaultExpectedRun
+	if !config.TokenClockSkewSeconds.IsNull() {
+		clockSkew = time.Duration(config.TokenClockSkewSeconds.ValueInt64()) * time.Second
 	}
+	if !config.ExpectedRunSeconds.IsNull() {
+		expectedRun = time.Duration(config.ExpectedRunSeconds.ValueInt64()) * time.Second
+	}
+	checkToken(apiKey, path.Root("api_key"), clockSkew, expectedRun, &resp.Diagnostics)
+	checkToken(identityToken, path.Root("identity_token"), clockSkew, expectedRun, &resp.Diagnostics)
 
 	if regex := regexp.MustCompile(patternURL); baseURL == "" || !regex.MatchString(baseURL) {
 		resp.Diagnostics.AddAttributeError(path.Root("base_url"), "Missing or invalid Base URL of Backstage instance", fmt.Sprintf(
@@ -125,10 +424,7 @@ func (p *backstageProvider) Configure(ctx context.Context, req provider.Configur
 
 	}
 
-	defaultNamespace := os.Getenv(envDefaultNamespace)
-	if !config.DefaultNamespace.IsNull() {
-		defaultNamespace = config.DefaultNamespace.ValueString()
-	}
+	defaultNamespace := configOrEnv(config.DefaultNamespace, envDefaultNamespace, path.Root("default_namespace"), &resp.Diagnostics)
 	if defaultNamespace == "" {
 		defaultNamespace = backstage.DefaultNamespaceName
 	}
@@ -144,23 +440,31 @@ func (p *backstageProvider) Configure(ctx context.Context, req provider.Configur
 		for _, kv := range regexp.MustCompile(`(.*?)=([^=]*)(?:,|$)`).FindAllStringSubmatch(headersEnv, -1) {
 			headers[kv[1]] = kv[2]
 		}
-	} else {
-		if !config.Headers.IsNull() {
-			config.Headers.ElementsAs(ctx, &headers, true)
+	}
+	if !config.Headers.IsNull() {
+		configHeaders := make(map[string]string)
+		resp.Diagnostics.Append(config.Headers.ElementsAs(ctx, &configHeaders, true)...)
+		if len(headers) > 0 && !maps.Equal(headers, configHeaders) {
+			addEnvDiffersWarning(path.Root("headers"), envHeaders, &resp.Diagnostics)
 		}
+		headers = configHeaders
+	}
+
+	hasAuthorization := false
+	for k := range headers {
+		hasAuthorization = hasAuthorization || http.CanonicalHeaderKey(k) == "Authorization"
+	}
+	if apiKey != "" && !hasAuthorization {
+		headers["Authorization"] = "Bearer " + apiKey
+		ctx = tflog.MaskAllFieldValuesStrings(ctx, apiKey)
 	}
 
 	retries := 0
-	retriesStr := os.Getenv(envRetries)
-	if retriesStr != "" {
+	if retriesStr := configOrEnv(int64String(config.Retries), envRetries, path.Root("retries"), &resp.Diagnostics); retriesStr != "" {
 		var err error
 		if retries, err = strconv.Atoi(retriesStr); err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("retries"), "Invalid number of retries", fmt.Sprintf("The provider cannot create the Backstage API client as there is invalid value for the number of retries: %s.", envRetries))
 		}
-	} else {
-		if !config.Retries.IsNull() {
-			retries = int(config.Retries.ValueInt64())
-		}
 	}
 
 	if resp.Diagnostics.HasError() {
@@ -168,36 +472,122 @@ func (p *backstageProvider) Configure(ctx context.Context, req provider.Configur
 	}
 
 	timeoutSeconds := 15
-	timeoutSecondsStr := os.Getenv(envTimeoutSeconds)
-	if timeoutSecondsStr != "" {
+	if timeoutSecondsStr := configOrEnv(int64String(config.TimeoutSeconds), envTimeoutSeconds, path.Root("timeout_seconds"),
+		&resp.Diagnostics); timeoutSecondsStr != "" {
 		var err error
 		if timeoutSeconds, err = strconv.Atoi(timeoutSecondsStr); err != nil {
 			resp.Diagnostics.AddAttributeError(path.Root("timeout_seconds"), "Invalid timeout for requests to the Backstage API", fmt.Sprintf("The provider cannot create the Backstage API client as there is invalid value for the timeout for requests to the Backstage API: %s.", envTimeoutSeconds))
 		}
-	} else {
-		if !config.TimeoutSeconds.IsNull() {
-			timeoutSeconds = int(config.TimeoutSeconds.ValueInt64())
+	}
+
+	requestID := configOrEnv(config.RequestID, envRequestID, path.Root("request_id"), &resp.Diagnostics)
+	if requestID == "" {
+		var err error
+		if requestID, err = uuid.GenerateUUID(); err != nil {
+			resp.Diagnostics.AddError("Unable to generate request ID", fmt.Sprintf(
+				"The provider cannot create the Backstage API client as correlation ID of requests could not be generated: %s.", err.Error()))
+			return
 		}
 	}
 
+	responseCache, err := newResponseCache(config.Cache, &resp.Diagnostics)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("cache"), "Invalid cache configuration", fmt.Sprintf(
+			"The provider cannot create the Backstage API client as there is invalid cache configuration: %s.", err.Error()))
+		return
+	}
+
+	recorder, err := newMetricsRecorder(config.Metrics)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("metrics"), "Invalid metrics configuration", fmt.Sprintf(
+			"The provider cannot create the Backstage API client as there is invalid metrics configuration: %s.", err.Error()))
+		return
+	}
+	if closer, ok := recorder.(metrics.Closer); ok {
+		p.closers = append(p.closers, closer)
+	}
+
+	var auditLog *audit.Log
+	if auditLogFile := configOrEnv(config.AuditLogFile, envAuditLogFile, path.Root("audit_log_file"), &resp.Diagnostics); auditLogFile != "" {
+		auditLog = audit.New(auditLogFile)
+	}
+
+	tlsServerName := configOrEnv(config.TLSServerName, envTLSServerName, path.Root("tls_server_name"), &resp.Diagnostics)
+
 	ctx = tflog.SetField(ctx, "backstage_base_url", baseURL)
 	ctx = tflog.SetField(ctx, "backstage_default_namespace", defaultNamespace)
 	ctx = tflog.SetField(ctx, "backstage_headers", headers)
 	ctx = tflog.SetField(ctx, "backstage_retries", retries)
 	ctx = tflog.SetField(ctx, "backstage_timeout_seconds", timeoutSeconds)
+	ctx = tflog.SetField(ctx, "backstage_request_id", requestID)
+	ctx = tflog.SetField(ctx, "backstage_tls_server_name", tlsServerName)
 
 	tflog.Debug(ctx, "Creating Backstage API client")
 
 	baseClient := &http.Client{}
 	baseClient.Timeout = time.Duration(timeoutSeconds) * time.Second
 
+	// The innermost transport connects to Backstage, so all the others, including the retries, apply to requests over a socket or
+	// port-forward alike.
+	network, address := "tcp", config.DialAddress.ValueString()
+	if !config.UnixSocket.IsNull() {
+		network, address = "unix", config.UnixSocket.ValueString()
+	}
+	if tlsServerName != "" || address != "" {
+		baseClient.Transport = transport.NewDialTransport(network, address, tlsServerName)
+	}
+
+	// Failures are injected below the retries, so injected failures are retried like real ones.
+	if config.FailureInjection != nil {
+		statusCode := http.StatusServiceUnavailable
+		if !config.FailureInjection.StatusCode.IsNull() {
+			statusCode = int(config.FailureInjection.StatusCode.ValueInt64())
+		}
+		tflog.Warn(ctx, "Injecting failures into requests to Backstage API", map[string]interface{}{"rate": config.FailureInjection.Rate.ValueFloat64()})
+		baseClient.Transport = &transport.FaultTransport{
+			Rate:          config.FailureInjection.Rate.ValueFloat64(),
+			StatusCode:    statusCode,
+			Recorder:      recorder,
+			BaseTransport: baseClient.Transport,
+		}
+	}
+
 	if retries > 0 {
 		retryableClient := retryablehttp.NewClient()
 		retryableClient.RetryMax = retries
 		retryableClient.HTTPClient.Timeout = baseClient.Timeout
+		retryableClient.HTTPClient.Transport = baseClient.Transport
+		if !config.RetryBudgetSeconds.IsNull() {
+			budget := transport.NewRetryBudget(time.Duration(config.RetryBudgetSeconds.ValueInt64()) * time.Second)
+			retryableClient.CheckRetry = budget.CheckRetry(retryableClient.CheckRetry)
+			retryableClient.Backoff = budget.Backoff(retryableClient.Backoff)
+		}
 		baseClient = retryableClient.StandardClient()
 	}
 
+	// Responses of the frontend are detected above the retries, so requests answered by it are not retried.
+	baseClient.Transport = &transport.FrontendTransport{
+		BaseTransport: baseClient.Transport,
+	}
+
+	baseClient.Transport = &transport.MetricsTransport{
+		BaseTransport: baseClient.Transport,
+		Recorder:      recorder,
+	}
+
+	if responseCache != nil {
+		baseClient.Transport = &transport.CacheTransport{
+			BaseTransport: baseClient.Transport,
+			Cache:         responseCache,
+			Recorder:      recorder,
+		}
+	}
+
+	baseClient.Transport = &transport.RequestIDTransport{
+		BaseTransport: baseClient.Transport,
+		RequestID:     requestID,
+	}
+
 	baseClient.Transport = &transport.HeadersTransport{
 		BaseTransport: baseClient.Transport,
 		Headers:       headers,
@@ -208,37 +598,262 @@ func (p *backstageProvider) Configure(ctx context.Context, req provider.Configur
 		resp.Diagnostics.AddError("Unable to create Backstage API client",
 			fmt.Sprintf("An unexpected error occurred when creating the Backstage API client: %s", err.Error()),
 		)
+		return
+	}
+
+	data := &providerData{
+		client:                    client,
+		httpClient:                baseClient,
+		version:                   p.version,
+		deprecationsInUse:         map[string]bool{},
+		baseURL:                   baseURL,
+		appURL:                    strings.TrimSuffix(baseURL, "/"),
+		defaultNamespace:          defaultNamespace,
+		metrics:                   recorder,
+		requestID:                 requestID,
+		audit:                     auditLog,
+		unknownRelationTypes:      config.UnknownRelationTypes.ValueString(),
+		customRelationTypes:       map[string]bool{},
+		fallbackDefaults:          map[string]map[string]string{},
+		fallbackDirectory:         config.FallbackDirectory.ValueString(),
+		lastReadDirectory:         config.LastReadDirectory.ValueString(),
+		sensitiveAnnotations:      map[string]bool{},
+		stripSensitiveAnnotations: config.SensitiveAnnotationsHandling.ValueString() == sensitiveAnnotationsStrip,
+		legacyEmptyStrings:        config.LegacyEmptyStrings.ValueBool(),
+		policy:                    newAccessPolicy(config.AccessPolicy),
+		aliasAnnotations:          []string{annotationAliases},
+		responseHeaders:           defaultResponseHeaders,
+		identityToken:             identityToken,
+	}
+	if !config.LegacyEmptyStrings.IsNull() {
+		data.deprecationsInUse["provider.legacy_empty_strings"] = true
+	}
+	if config.AliasAnnotations != nil {
+		data.aliasAnnotations = config.AliasAnnotations
+	}
+	if config.ResponseHeaders != nil {
+		data.responseHeaders = config.ResponseHeaders
+	}
+	if !config.AppURL.IsNull() {
+		data.appURL = strings.TrimSuffix(config.AppURL.ValueString(), "/")
+	}
+	for kind, defaults := range config.FallbackDefaults {
+		data.fallbackDefaults[strings.ToLower(kind)] = defaults
+	}
+	for _, t := range config.CustomRelationTypes {
+		data.customRelationTypes[t] = true
+	}
+	for _, k := range config.SensitiveAnnotations {
+		data.sensitiveAnnotations[k] = true
+	}
+
+	if config.Cache != nil && config.Cache.WarmFilters != nil {
+		concurrency := defaultCacheWarmConcurrency
+		if !config.Cache.WarmConcurrency.IsNull() {
+			concurrency = int(config.Cache.WarmConcurrency.ValueInt64())
+		}
+		data.warmCache(ctx, config.Cache.WarmFilters, concurrency, &resp.Diagnostics)
 	}
 
-	resp.ResourceData = client
-	resp.DataSourceData = client
+	resp.ResourceData = data
+	resp.DataSourceData = data
+}
+
+// newResponseCache creates the cache for responses of the Backstage API based on the configuration. It returns nil if no cache is configured.
+func newResponseCache(config *providerCacheModel, diags *diag.Diagnostics) (cache.Cache, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	ttl := 300 * time.Second
+	if !config.TTLSeconds.IsNull() {
+		ttl = time.Duration(config.TTLSeconds.ValueInt64()) * time.Second
+	}
+
+	switch config.Type.ValueString() {
+	case cacheTypeMemory:
+		return cache.NewMemory(ttl), nil
+	case cacheTypeDisk:
+		directory := config.Directory.ValueString()
+		if directory == "" {
+			userCacheDir, err := os.UserCacheDir()
+			if err != nil {
+				return nil, fmt.Errorf("unable to determine cache directory: %w", err)
+			}
+			directory = filepath.Join(userCacheDir, "terraform-provider-backstage")
+		}
+		return cache.NewDisk(directory, ttl), nil
+	case cacheTypeHTTP:
+		endpoint := configOrEnv(config.Endpoint, envCacheEndpoint, path.Root("cache").AtName("endpoint"), diags)
+		if endpoint == "" {
+			return nil, fmt.Errorf("endpoint must be set in the configuration or via %s environment variable", envCacheEndpoint)
+		}
+		return cache.NewHTTP(endpoint, ttl), nil
+	default:
+		return nil, fmt.Errorf("unsupported cache type: %s", config.Type.ValueString())
+	}
+}
+
+// newMetricsRecorder creates the recorder of metrics based on the configuration. It returns a no-op recorder if no metrics are configured.
+func newMetricsRecorder(config *providerMetricsModel) (metrics.Recorder, error) {
+	if config == nil {
+		return metrics.Noop{}, nil
+	}
+
+	prefix := metricsDefaultPrefix
+	if !config.Prefix.IsNull() {
+		prefix = config.Prefix.ValueString()
+	}
+
+	switch config.Type.ValueString() {
+	case metricsTypeStatsd:
+		return metrics.NewStatsd(config.Endpoint.ValueString(), prefix)
+	case metricsTypePushgateway:
+		job := "terraform"
+		if !config.Job.IsNull() {
+			job = config.Job.ValueString()
+		}
+		return metrics.NewPushgateway(config.Endpoint.ValueString(), job, prefix, time.Second), nil
+	default:
+		return nil, fmt.Errorf("unsupported metrics type: %s", config.Type.ValueString())
+	}
 }
 
 func (p *backstageProvider) Resources(context.Context) []func() resource.Resource {
-	return []func() resource.Resource{
+	return append([]func() resource.Resource{
 		NewLocationResource,
-	}
+		NewEventResource,
+		NewEntityCleanupResource,
+		NewCatalogRefreshResource,
+		NewScaffolderTaskResource,
+	}, p.extraResources...)
 }
 
 func (p *backstageProvider) DataSources(context.Context) []func() datasource.DataSource {
-	return []func() datasource.DataSource{
+	return append([]func() datasource.DataSource{
 		NewEntityDataSource,
+		NewEntitiesDataSource,
+		NewCapabilitiesDataSource,
+		NewEntityErrorsDataSource,
+		NewEntityReferencersDataSource,
+		NewDependencyClosureDataSource,
+		NewAnnotationsDataSource,
+		NewStarredEntitiesDataSource,
 		NewApiDataSource,
+		NewApisDataSource,
 		NewComponentDataSource,
+		NewComponentsDataSource,
+		NewConstantsDataSource,
+		NewProviderInfoDataSource,
 		NewDomainDataSource,
+		NewDomainsDataSource,
 		NewGroupDataSource,
+		NewGroupsDataSource,
 		NewLocationDataSource,
+		NewLocationsDataSource,
 		NewResourceDataSource,
+		NewResourcesDataSource,
+		NewScaffolderDryRunDataSource,
+		NewScaffolderTaskDataSource,
+		NewScaffolderTaskEventsDataSource,
 		NewSystemDataSource,
+		NewSystemsDataSource,
+		NewTemplateDataSource,
+		NewTemplatesDataSource,
 		NewUserDataSource,
+		NewUsersDataSource,
+	}, p.extraDataSources...)
+}
+
+func (p *backstageProvider) Functions(context.Context) []func() function.Function {
+	return []func() function.Function{
+		NewValidateEntityRefsFunction,
 	}
 }
 
-// New instantiates a new Backstage provider.
-func New(version string) func() provider.Provider {
+// checkToken adds an error to diagnostics when the token is a JSON Web Token expired, or not valid yet, beyond the clock skew, and a
+// warning when it expires within the expected run.
+func checkToken(t string, attribute path.Path, clockSkew time.Duration, expectedRun time.Duration, diags *diag.Diagnostics) {
+	claims, ok := token.Parse(t)
+	if !ok {
+		return
+	}
+
+	expiresDuringRun, err := claims.Check(time.Now(), clockSkew, expectedRun)
+	if err != nil {
+		diags.AddAttributeError(attribute, "Invalid token for Backstage instance", fmt.Sprintf(
+			"The provider cannot authenticate to Backstage with the token set in %s: %s, allowing for a clock skew of %s. Renew the token.",
+			attribute, err.Error(), clockSkew))
+		return
+	}
+
+	if expiresDuringRun {
+		diags.AddAttributeWarning(attribute, "Token for Backstage instance expires soon", fmt.Sprintf(
+			"The token set in %s expires at %s, within the expected run of %s, so requests late in the run may fail to authenticate. "+
+				"Renew the token before the run, or set expected_run_seconds to the actual duration of runs.",
+			attribute, claims.ExpiresAt.UTC().Format(time.RFC3339), expectedRun))
+	}
+}
+
+// configOrEnv returns the value set in the configuration, or the value of the environment variable if the configuration does not set it.
+// The configuration takes precedence for every attribute that may be provided via the environment, with a warning if both are set and
+// differ, as that usually means the environment points to another Backstage instance than the configuration does.
+func configOrEnv(value types.String, env string, attribute path.Path, diags *diag.Diagnostics) string {
+	envValue := os.Getenv(env)
+	if value.IsNull() {
+		return envValue
+	}
+
+	if envValue != "" && envValue != value.ValueString() {
+		addEnvDiffersWarning(attribute, env, diags)
+	}
+
+	return value.ValueString()
+}
+
+// addEnvDiffersWarning adds a warning to diagnostics that the value of the attribute set in the configuration is used instead of the
+// differing value of the environment variable. Values are left out, as some of them are secrets.
+func addEnvDiffersWarning(attribute path.Path, env string, diags *diag.Diagnostics) {
+	diags.AddAttributeWarning(attribute, "Configuration of Backstage provider differs from environment", fmt.Sprintf(
+		"The value of %s set in the configuration is used instead of the one set in the %s environment variable.", attribute, env))
+}
+
+// int64String returns the number as a string, so it can be compared with the value of an environment variable.
+func int64String(value types.Int64) types.String {
+	if value.IsNull() {
+		return types.StringNull()
+	}
+
+	return types.StringValue(strconv.FormatInt(value.ValueInt64(), 10))
+}
+
+// Close emits the metrics held back by the recorders of the provider, e.g. pushing the metrics recorded last to the Prometheus Pushgateway,
+// and stops them. It is meant to be called once the provider is served no more, so metrics of short runs are not lost.
+func Close(ctx context.Context, p provider.Provider) error {
+	bp, ok := p.(*backstageProvider)
+	if !ok {
+		return nil
+	}
+
+	var errs []error
+	for _, c := range bp.closers {
+		errs = append(errs, c.Close(ctx))
+	}
+	bp.closers = nil
+
+	return errors.Join(errs...)
+}
+
+// New instantiates a new Backstage provider, customized by the options, e.g. with additional data sources registered by WithDataSources.
+func New(version string, opts ...Option) func() provider.Provider {
 	return func() provider.Provider {
-		return &backstageProvider{
+		p := &backstageProvider{
 			version: version,
 		}
+		for _, opt := range opts {
+			opt(p)
+		}
+
+		return p
 	}
 }
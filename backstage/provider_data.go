@@ -0,0 +1,213 @@
+package backstage
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/audit"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/capabilities"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/metrics"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// providerData is shared by the provider with all data sources and resources.
+type providerData struct {
+	// client is the Backstage API client.
+	client *backstage.Client
+
+	// httpClient is the HTTP client used for requests to the Backstage APIs not supported by client.
+	httpClient *http.Client
+
+	// version is the version of the provider.
+	version string
+
+	// deprecationsInUse are the addresses of the deprecated attributes of the provider its configuration sets.
+	deprecationsInUse map[string]bool
+
+	// baseURL is the base URL of the Backstage instance.
+	baseURL string
+
+	// appURL is the base URL of the Backstage UI, without a trailing slash, links to pages of entities are built from.
+	appURL string
+
+	// defaultNamespace is the namespace of entities data sources read, if they do not set one.
+	defaultNamespace string
+
+	// metrics records metrics of provider operations.
+	metrics metrics.Recorder
+
+	// requestID is the correlation ID sent with each request to the Backstage API.
+	requestID string
+
+	// audit records reads of entities, if enabled.
+	audit *audit.Log
+
+	// unknownRelationTypes is the handling of relations of unknown types: ignore, warn or fail.
+	unknownRelationTypes string
+
+	// customRelationTypes are the types of relations added by plugins that are not reported as unknown.
+	customRelationTypes map[string]bool
+
+	// fallbackDefaults are the defaults of fallbacks keyed by lower case kind and path of the attribute.
+	fallbackDefaults map[string]map[string]string
+
+	// fallbackDirectory is the directory of descriptors of entities data sources may fall back to, if configured.
+	fallbackDirectory string
+
+	// lastReadDirectory is the directory entities read by data sources are saved to and may be fallen back to, if configured.
+	lastReadDirectory string
+
+	// sensitiveAnnotations are the keys of annotations whose values are sensitive.
+	sensitiveAnnotations map[string]bool
+
+	// stripSensitiveAnnotations reports whether sensitive annotations are removed instead of being marked as sensitive.
+	stripSensitiveAnnotations bool
+
+	// legacyEmptyStrings reports whether optional fields of entities that are not set are written as empty strings instead of null values.
+	legacyEmptyStrings bool
+
+	// aliasAnnotations are the keys of annotations listing former names of entities.
+	aliasAnnotations []string
+
+	// identityToken is the token of the user whose permissions entities are read with, when data sources read as the identity.
+	identityToken string
+
+	// responseHeaders are the names of headers of responses exposed in request_info of data sources.
+	responseHeaders []string
+
+	// policy restricts the kinds and namespaces of entities data sources may read, if configured.
+	policy *accessPolicy
+
+	// caps are the capabilities of the Backstage instance, detected once when first needed.
+	caps     *capabilities.Capabilities
+	capsErr  error
+	capsOnce sync.Once
+}
+
+// withRequestID appends the correlation ID of requests to the detail of a diagnostic.
+func (p *providerData) withRequestID(detail string) string {
+	return fmt.Sprintf("%s (request ID: %s)", detail, p.requestID)
+}
+
+// requestInfo returns the details of the response an entity was read from, with the headers of the response listed in response_headers.
+func (p *providerData) requestInfo(ctx context.Context, response *http.Response, diags *diag.Diagnostics) *entityRequestInfoModel {
+	info := &entityRequestInfoModel{RequestID: types.StringValue(p.requestID), StatusCode: types.Int64Null()}
+	headers := map[string]string{}
+	if response != nil {
+		info.StatusCode = types.Int64Value(int64(response.StatusCode))
+		for _, name := range p.responseHeaders {
+			if v := response.Header.Get(name); v != "" {
+				headers[name] = v
+			}
+		}
+	}
+
+	var d diag.Diagnostics
+	info.Headers, d = types.MapValueFrom(ctx, types.StringType, headers)
+	diags.Append(d...)
+
+	return info
+}
+
+// resolveNamespace returns the namespace a data source reads its entity from: the one it sets, or the default namespace of the provider.
+func (p *providerData) resolveNamespace(namespace types.String) types.String {
+	if namespace.IsNull() || namespace.ValueString() == "" {
+		return types.StringValue(p.defaultNamespace)
+	}
+
+	return namespace
+}
+
+// optionalString returns the value of an optional field of an entity, null if the field is not set, unless legacy_empty_strings is set.
+func (p *providerData) optionalString(v string) types.String {
+	if v == "" && !p.legacyEmptyStrings {
+		return types.StringNull()
+	}
+
+	return types.StringValue(v)
+}
+
+// recordRead records the read of entities in the audit log. Failures to record are logged, but do not fail the read.
+func (p *providerData) recordRead(ctx context.Context, entry audit.Entry) {
+	entry.RequestID = p.requestID
+	if err := p.audit.Record(entry); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Unable to record read of %s in audit log: %s", entry.DataSource, err.Error()))
+	}
+}
+
+// doJSON sends a request with the JSON encoded body, if not nil, to the path of the Backstage API. The response body is decoded into v, if the
+// request succeeds and v is not nil. The response is returned whatever its status code is.
+func (p *providerData) doJSON(ctx context.Context, method string, path string, body interface{}, v interface{}) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		b, err := json.Marshal(body)
+		if err != nil {
+			return nil, fmt.Errorf("unable to encode request body: %w", err)
+		}
+		reader = bytes.NewReader(b)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, strings.TrimSuffix(p.baseURL, "/")+path, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if v != nil && resp.StatusCode >= http.StatusOK && resp.StatusCode < http.StatusMultipleChoices {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil && err != io.EOF {
+			return resp, fmt.Errorf("unable to decode response body: %w", err)
+		}
+	}
+
+	// Bodies of errors are kept for backstageError, as the body of the response is closed on return.
+	if resp.StatusCode >= http.StatusBadRequest {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+		resp.Body = io.NopCloser(bytes.NewReader(b))
+	}
+
+	return resp, nil
+}
+
+// backstageError returns the error Backstage responded with to a request of doJSON, e.g. the errors processing the target of a location,
+// or the status of the response, if it holds no error.
+func backstageError(resp *http.Response) string {
+	var body struct {
+		Error struct {
+			Name    string `json:"name"`
+			Message string `json:"message"`
+		} `json:"error"`
+	}
+	if resp.Body != nil && json.NewDecoder(resp.Body).Decode(&body) == nil && body.Error.Message != "" {
+		return fmt.Sprintf("%s: %s", body.Error.Name, body.Error.Message)
+	}
+
+	return resp.Status
+}
+
+// capabilities returns the capabilities of the Backstage instance, detecting them on the first call.
+func (p *providerData) capabilities(ctx context.Context) (*capabilities.Capabilities, error) {
+	p.capsOnce.Do(func() {
+		tflog.Debug(ctx, "Detecting capabilities of Backstage instance")
+		p.caps, p.capsErr = capabilities.Detect(ctx, p.httpClient, p.baseURL)
+	})
+
+	return p.caps, p.capsErr
+}
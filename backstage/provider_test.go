@@ -1,8 +1,14 @@
 package backstage
 
 import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/h2non/gock"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 )
 
 const testAccProviderConfig = `
@@ -18,3 +24,49 @@ provider "backstage" {
 var testAccProtoV6ProviderFactories = map[string]func() (tfprotov6.ProviderServer, error){
 	"backstage": providerserver.NewProtocol6WithError(New("test")()),
 }
+
+func TestAccProvider_ConfigOverridesEnvironment(t *testing.T) {
+	const baseURL = "http://backstage.test"
+	t.Setenv(envBaseURL, "http://environment.test")
+	t.Setenv(envAPIKey, "environment")
+	t.Setenv(envHeaders, "Custom-Header=environment")
+	// Invalid values of the environment fail the configuration, unless the configuration sets the attributes.
+	t.Setenv(envRetries, "environment")
+	t.Setenv(envTimeoutSeconds, "environment")
+
+	defer gock.Off()
+	// Requests are only answered if sent to the base URL with the API key and headers of the configuration, not of the environment.
+	gock.New(baseURL).Persist().
+		Get("/api/catalog/entities/by-query").
+		MatchHeader("Authorization", "Bearer configuration").
+		MatchHeader("Custom-Header", "configuration").
+		Reply(http.StatusOK).
+		JSON(testAccEntitiesQueryResult("component:artist-web"))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "backstage" {
+					  base_url        = %q
+					  api_key         = "configuration"
+					  retries         = 1
+					  timeout_seconds = 30
+					  headers = {
+					    "Custom-Header" = "configuration"
+					  }
+					}
+
+					data "backstage_entities" "test" {
+					  filters = ["kind=component"]
+					}
+				`, baseURL),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "refs.#", "1"),
+					resource.TestCheckResourceAttr("data.backstage_entities.test", "refs.0", "component:default/artist-web"),
+				),
+			},
+		},
+	})
+}
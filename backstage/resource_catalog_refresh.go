@@ -0,0 +1,210 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/transport"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &catalogRefreshResource{}
+	_ resource.ResourceWithConfigure = &catalogRefreshResource{}
+)
+
+// NewCatalogRefreshResource is a helper function to simplify the provider implementation.
+func NewCatalogRefreshResource() resource.Resource {
+	return &catalogRefreshResource{}
+}
+
+// catalogRefreshResource is the resource implementation.
+type catalogRefreshResource struct {
+	*providerData
+}
+
+// catalogRefreshResourceModel maps the resource schema data.
+type catalogRefreshResourceModel struct {
+	ID           types.String      `tfsdk:"id"`
+	EntityRef    types.String      `tfsdk:"entity_ref"`
+	Triggers     map[string]string `tfsdk:"triggers"`
+	PreviousEtag types.String      `tfsdk:"previous_etag"`
+	RefreshedAt  types.String      `tfsdk:"refreshed_at"`
+}
+
+const (
+	// pathRefresh is the path of the endpoint of the catalog scheduling entities for refresh.
+	pathRefresh = "/api/catalog/refresh"
+
+	descriptionCatalogRefreshID        = "Identifier of the refresh."
+	descriptionCatalogRefreshEntityRef = "Entity reference to the entity to refresh, e.g. `component:default/artist-web`. The namespace " +
+		"defaults to `default`, the kind must be set. Refreshing a `Location` entity refreshes the entities it emits."
+	descriptionCatalogRefreshTriggers = "Arbitrary values that, when changed, refresh the entity again, e.g. the SHA of the commit updating its " +
+		"`catalog-info.yaml`. Set it to `{ run = timestamp() }` to refresh it on every apply."
+	descriptionCatalogRefreshPreviousEtag = "Etag of the entity read right before the refresh was requested. Set it as `wait_for.previous_etag` " +
+		"of data sources reading the entity, so they wait until the refresh is processed."
+	descriptionCatalogRefreshRefreshedAt = "Timestamp the refresh was requested at, in RFC 3339 format."
+)
+
+// Metadata returns the data source type name.
+func (r *catalogRefreshResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_catalog_refresh"
+}
+
+// Schema defines the schema for the resource.
+func (r *catalogRefreshResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this resource to make Backstage Software Catalog refresh an entity when it is applied, so changes Terraform " +
+			"made to the `catalog-info.yaml` of a repository are ingested in the same apply instead of the next processing loop of the " +
+			"catalog. The refresh is requested when the resource is created or replaced, add `depends_on` to request it once the changes are " +
+			"applied. Refreshes are processed asynchronously, set `wait_for` of the data sources reading the entity to wait for them. " +
+			"Destroying the resource requests nothing.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionCatalogRefreshID, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+			"entity_ref": schema.StringAttribute{Required: true, MarkdownDescription: descriptionCatalogRefreshEntityRef, Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(patternEntityRefWithKind), "must be an entity reference with a kind, e.g. `component:artist-web`"),
+			}, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"triggers": schema.MapAttribute{Optional: true, MarkdownDescription: descriptionCatalogRefreshTriggers, ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()}},
+			"previous_etag": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionCatalogRefreshPreviousEtag,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()}},
+			"refreshed_at": schema.StringAttribute{Computed: true, Description: descriptionCatalogRefreshRefreshedAt, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *catalogRefreshResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.providerData = req.ProviderData.(*providerData)
+}
+
+// Create requests the refresh of the entity from Backstage and sets the initial Terraform state.
+func (r *catalogRefreshResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan catalogRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Error refreshing Backstage entity", fmt.Sprintf("Could not generate identifier of the refresh: %s", err.Error()))
+		return
+	}
+
+	ref := canonicalTargetRef(plan.EntityRef.ValueString())
+	kind, namespace, name := parseEntityRef(ref, "", backstage.DefaultNamespaceName)
+	tflog.Debug(ctx, fmt.Sprintf("Getting etag of entity %s from Backstage API", ref))
+	// The etag is read bypassing the cache of responses, as it must be the one of the entity before the refresh.
+	var entity backstage.Entity
+	response, err := r.doJSON(transport.WithoutCache(ctx), http.MethodGet, fmt.Sprintf(pathEntityByName, url.PathEscape(strings.ToLower(kind)),
+		url.PathEscape(namespace), url.PathEscape(name)), nil, &entity)
+	if err != nil {
+		resp.Diagnostics.AddError("Error refreshing Backstage entity",
+			r.withRequestID(fmt.Sprintf("Could not read entity %s, unexpected error: %s", ref, err.Error())),
+		)
+		return
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		resp.Diagnostics.AddAttributeError(path.Root("entity_ref"), "Error refreshing Backstage entity",
+			r.withRequestID(fmt.Sprintf("Could not refresh entity %s, it does not exist in Backstage.", ref)),
+		)
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error refreshing Backstage entity",
+			r.withRequestID(fmt.Sprintf("Could not read entity %s: %s", ref, backstageError(response))),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Requesting refresh of entity %s from Backstage API", ref))
+	response, err = r.doJSON(ctx, http.MethodPost, pathRefresh, map[string]string{"entityRef": ref}, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error refreshing Backstage entity",
+			r.withRequestID(fmt.Sprintf("Could not refresh entity %s, unexpected error: %s", ref, err.Error())),
+		)
+		return
+	}
+
+	if response.StatusCode == http.StatusNotFound {
+		resp.Diagnostics.AddAttributeError(path.Root("entity_ref"), "Error refreshing Backstage entity",
+			r.withRequestID(fmt.Sprintf("Could not refresh entity %s, it does not exist in Backstage.", ref)),
+		)
+		return
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		resp.Diagnostics.AddError("Error refreshing Backstage entity",
+			r.withRequestID(fmt.Sprintf("Could not refresh entity %s: %s", ref, backstageError(response))),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	plan.PreviousEtag = types.StringValue(entity.Metadata.Etag)
+	plan.RefreshedAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the Terraform state as it is, as requested refreshes can not be read back.
+func (r *catalogRefreshResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state catalogRefreshResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called, as changes of any attribute replace the resource, requesting the refresh again.
+func (r *catalogRefreshResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan catalogRefreshResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the refresh from the Terraform state. Nothing is requested from Backstage.
+func (r *catalogRefreshResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
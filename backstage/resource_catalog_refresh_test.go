@@ -0,0 +1,60 @@
+//go:build !resources
+
+package backstage
+
+import (
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceCatalogRefresh(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: testAccProviderConfig + testAccResourceCatalogRefreshConfig1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backstage_catalog_refresh.test", "entity_ref", "component:default/artist-web"),
+					resource.TestCheckResourceAttrSet("backstage_catalog_refresh.test", "id"),
+					resource.TestCheckResourceAttrSet("backstage_catalog_refresh.test", "previous_etag"),
+					resource.TestCheckResourceAttrSet("backstage_catalog_refresh.test", "refreshed_at"),
+				),
+			},
+			// Replace testing
+			{
+				Config: testAccProviderConfig + testAccResourceCatalogRefreshConfig2,
+				Check:  resource.TestCheckResourceAttr("backstage_catalog_refresh.test", "triggers.commit", "2"),
+			},
+			// Validation testing
+			{
+				Config: testAccProviderConfig + `
+					resource "backstage_catalog_refresh" "test" {
+						entity_ref = "artist-web"
+					}
+				`,
+				ExpectError: regexp.MustCompile("must be an entity reference with a kind"),
+			},
+		},
+	})
+}
+
+const testAccResourceCatalogRefreshConfig1 = `
+resource "backstage_catalog_refresh" "test" {
+  entity_ref = "component:default/artist-web"
+  triggers   = { commit = "1" }
+}
+`
+const testAccResourceCatalogRefreshConfig2 = `
+resource "backstage_catalog_refresh" "test" {
+  entity_ref = "component:default/artist-web"
+  triggers   = { commit = "2" }
+}
+`
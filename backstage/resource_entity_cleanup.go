@@ -0,0 +1,286 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &entityCleanupResource{}
+	_ resource.ResourceWithConfigure = &entityCleanupResource{}
+)
+
+// NewEntityCleanupResource is a helper function to simplify the provider implementation.
+func NewEntityCleanupResource() resource.Resource {
+	return &entityCleanupResource{}
+}
+
+// entityCleanupResource is the resource implementation.
+type entityCleanupResource struct {
+	*providerData
+}
+
+// entityCleanupResourceModel maps the resource schema data.
+type entityCleanupResourceModel struct {
+	ID             types.String      `tfsdk:"id"`
+	EntityRefs     []types.String    `tfsdk:"entity_refs"`
+	LocationTarget types.String      `tfsdk:"location_target"`
+	OnDestroy      types.Bool        `tfsdk:"on_destroy"`
+	Triggers       map[string]string `tfsdk:"triggers"`
+	DeletedRefs    []types.String    `tfsdk:"deleted_refs"`
+	CleanedUpAt    types.String      `tfsdk:"cleaned_up_at"`
+}
+
+const (
+	descriptionEntityCleanupID         = "Identifier of the cleanup."
+	descriptionEntityCleanupEntityRefs = "Entity references to the entities to delete, if orphaned, e.g. `component:default/artist-web`. The " +
+		"namespace defaults to `default`, the kind must be set. Entities that are not orphaned are left as they are, with a warning."
+	descriptionEntityCleanupLocationTarget = "Target URL of a location, e.g. one unregistered by a `backstage_location` resource. All orphaned " +
+		"entities last emitted by the location are deleted."
+	descriptionEntityCleanupOnDestroy = "Whether to delete the orphaned entities again when the resource is destroyed (default: true). Make the " +
+		"`backstage_location` resource of `location_target` depend on this resource, so the entities it leaves behind are deleted after it " +
+		"is unregistered."
+	descriptionEntityCleanupTriggers = "Arbitrary values that, when changed, delete the orphaned entities again. Set it to " +
+		"`{ run = timestamp() }` to delete them on every apply."
+	descriptionEntityCleanupDeletedRefs = "Canonical entity references to the entities deleted by the last cleanup, sorted."
+	descriptionEntityCleanupCleanedUpAt = "Timestamp of the last cleanup, in RFC 3339 format."
+)
+
+// Metadata returns the data source type name.
+func (r *entityCleanupResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_entity_cleanup"
+}
+
+// Schema defines the schema for the resource.
+func (r *entityCleanupResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this resource to delete orphaned entities of Backstage Software Catalog, i.e. entities no location emits " +
+			"anymore, as marked by the `" + annotationOrphan + "` annotation, so Terraform can garbage-collect the entities left behind when " +
+			"locations are removed. The orphaned entities are deleted by their UID when the resource is created or replaced, and, unless " +
+			"`on_destroy` is off, when it is destroyed. Entities that are not orphaned, or that the `access_policy` of the provider denies " +
+			"reading, are never deleted. \n\n" +
+			"In order for this resource to work, Backstage instance must NOT be running in " +
+			"[read-only mode](https://backstage.io/docs/features/software-catalog/configuration#readonly-mode).",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionEntityCleanupID, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+			"entity_refs": schema.ListAttribute{Optional: true, MarkdownDescription: descriptionEntityCleanupEntityRefs, ElementType: types.StringType,
+				Validators: []validator.List{
+					listvalidator.SizeAtLeast(1),
+					listvalidator.ValueStringsAre(stringvalidator.RegexMatches(regexp.MustCompile(patternEntityRefWithKind),
+						"must be an entity reference with a kind, e.g. `component:artist-web`")),
+					listvalidator.AtLeastOneOf(path.MatchRoot("location_target")),
+				},
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()}},
+			"location_target": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionEntityCleanupLocationTarget,
+				Validators:    []validator.String{stringvalidator.LengthAtLeast(1)},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"on_destroy": schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionEntityCleanupOnDestroy},
+			"triggers": schema.MapAttribute{Optional: true, MarkdownDescription: descriptionEntityCleanupTriggers, ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()}},
+			"deleted_refs": schema.ListAttribute{Computed: true, Description: descriptionEntityCleanupDeletedRefs, ElementType: types.StringType,
+				PlanModifiers: []planmodifier.List{listplanmodifier.UseStateForUnknown()}},
+			"cleaned_up_at": schema.StringAttribute{Computed: true, Description: descriptionEntityCleanupCleanedUpAt, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *entityCleanupResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.providerData = req.ProviderData.(*providerData)
+}
+
+// Create deletes the orphaned entities from Backstage and sets the initial Terraform state.
+func (r *entityCleanupResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan entityCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Error cleaning up Backstage entities", fmt.Sprintf("Could not generate identifier of the cleanup: %s", err.Error()))
+		return
+	}
+
+	deleted := r.cleanup(ctx, &plan, &resp.Diagnostics)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	plan.DeletedRefs = refsSet(deleted)
+	plan.CleanedUpAt = types.StringValue(time.Now().UTC().Format(time.RFC3339))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the Terraform state as it is, as deleted entities can not be read back.
+func (r *entityCleanupResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state entityCleanupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update sets the updated Terraform state, as only changes of `on_destroy` do not replace the resource. Nothing is deleted.
+func (r *entityCleanupResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan entityCleanupResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete deletes the orphaned entities from Backstage again, unless `on_destroy` is off, and removes the Terraform state on success.
+func (r *entityCleanupResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var state entityCleanupResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if state.OnDestroy.Equal(types.BoolValue(false)) {
+		return
+	}
+
+	r.cleanup(ctx, &state, &resp.Diagnostics)
+}
+
+// cleanup deletes the orphaned entities listed in, or emitted by the location of, the model by their UID, and returns the canonical references
+// to the entities deleted. Entities deleted meanwhile are skipped.
+func (r *entityCleanupResource) cleanup(ctx context.Context, model *entityCleanupResourceModel, diags *diag.Diagnostics) []string {
+	fields := []string{"kind", "metadata.name", "metadata.namespace", "metadata.uid", "metadata.annotations"}
+	var candidates []backstage.Entity
+
+	if len(model.EntityRefs) > 0 {
+		requested := map[string]bool{}
+		for _, ref := range model.EntityRefs {
+			requested[canonicalTargetRef(ref.ValueString())] = true
+		}
+		refs := sortedKeys(requested)
+
+		tflog.Debug(ctx, fmt.Sprintf("Getting entities %v from Backstage API", refs))
+		entities, response, err := r.entitiesByRefs(ctx, refs, fields)
+		if err != nil {
+			diags.AddError("Error cleaning up Backstage entities",
+				r.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", refs, err.Error())))
+			return nil
+		}
+
+		if response.StatusCode != http.StatusOK {
+			diags.AddError("Error cleaning up Backstage entities",
+				r.withRequestID(fmt.Sprintf("Could not read Backstage entities %v: %s", refs, response.Status)))
+			return nil
+		}
+
+		for _, e := range entities {
+			if !isOrphan(e.Metadata.Annotations) {
+				diags.AddWarning("Backstage entity is not orphaned", fmt.Sprintf("Backstage entity %s is still emitted by a location, it is not deleted.",
+					canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)))
+				continue
+			}
+			candidates = append(candidates, e)
+		}
+	}
+
+	if !model.LocationTarget.IsNull() {
+		tflog.Debug(ctx, fmt.Sprintf("Getting orphaned entities of location %s from Backstage API", model.LocationTarget.ValueString()))
+		entities, response, err := r.client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{
+			Filters: []string{fmt.Sprintf("metadata.annotations.%s=true,metadata.annotations.%s=%s:%s", annotationOrphan,
+				annotationManagedByLocation, locationTypeURL, model.LocationTarget.ValueString())},
+			Fields: fields,
+		})
+		if err != nil {
+			diags.AddError("Error cleaning up Backstage entities",
+				r.withRequestID(fmt.Sprintf("Could not read orphaned Backstage entities of location %s: %s", model.LocationTarget.ValueString(),
+					err.Error())))
+			return nil
+		}
+
+		if response.StatusCode != http.StatusOK {
+			diags.AddError("Error cleaning up Backstage entities",
+				r.withRequestID(fmt.Sprintf("Could not read orphaned Backstage entities of location %s: %s", model.LocationTarget.ValueString(),
+					response.Status)))
+			return nil
+		}
+
+		candidates = append(candidates, entities...)
+	}
+
+	// Entities the access policy of the provider denies reading are not deleted either.
+	candidates = r.allowedEntities(candidates, diags)
+
+	deleted := map[string]bool{}
+	for _, e := range candidates {
+		ref := canonicalEntityRef(e.Kind, e.Metadata.Namespace, e.Metadata.Name)
+		if deleted[ref] {
+			continue
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Deleting orphaned entity %s with UID %s from Backstage", ref, e.Metadata.UID))
+		response, err := r.client.Catalog.Entities.Delete(ctx, e.Metadata.UID)
+		if err != nil {
+			diags.AddError("Error cleaning up Backstage entities",
+				r.withRequestID(fmt.Sprintf("Could not delete Backstage entity %s, unexpected error: %s", ref, err.Error())))
+			return nil
+		}
+
+		// Entities deleted meanwhile, e.g. by another cleanup, need no deleting.
+		if response.StatusCode == http.StatusNotFound {
+			continue
+		}
+
+		if response.StatusCode != http.StatusNoContent {
+			diags.AddError("Error cleaning up Backstage entities",
+				r.withRequestID(fmt.Sprintf("Could not delete Backstage entity %s, unexpected status code: %d", ref, response.StatusCode)))
+			return nil
+		}
+
+		deleted[ref] = true
+	}
+
+	return sortedKeys(deleted)
+}
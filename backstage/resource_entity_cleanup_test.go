@@ -0,0 +1,99 @@
+//go:build !resources
+
+package backstage
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceEntityCleanup(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing, entities that are not orphaned are left as they are
+			{
+				Config: testAccProviderConfig + testAccResourceEntityCleanupConfig,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("backstage_entity_cleanup.test", "id"),
+					resource.TestCheckResourceAttrSet("backstage_entity_cleanup.test", "cleaned_up_at"),
+					resource.TestCheckResourceAttr("backstage_entity_cleanup.test", "deleted_refs.#", "0"),
+				),
+			},
+			// Validation testing
+			{
+				Config: testAccProviderConfig + `
+					resource "backstage_entity_cleanup" "test" {
+						on_destroy = false
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Combination"),
+			},
+		},
+	})
+}
+
+func TestAccResourceEntityCleanup_WithAccessPolicy(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	const baseURL = "http://backstage.test"
+	defer gock.Off()
+	gock.New(baseURL).Persist().
+		Post(pathEntitiesByRefs).
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{"items": []map[string]interface{}{{
+			"kind": "Component",
+			"metadata": map[string]interface{}{
+				"name":        "artist-web",
+				"namespace":   "team-b",
+				"uid":         "artist-web-uid",
+				"annotations": map[string]string{annotationOrphan: "true"},
+			},
+		}}})
+	for _, probe := range []string{"/api/catalog/entities/by-query", "/api/catalog/entity-facets", "/api/scaffolder/v2/actions"} {
+		gock.New(baseURL).Persist().Get(probe).Reply(http.StatusNotFound)
+	}
+	// No deletion is mocked: deleting the entity the access policy denies would fail the apply.
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(`
+					provider "backstage" {
+					  base_url = %q
+					  access_policy = {
+					    denied_namespaces = ["team-b"]
+					  }
+					}
+
+					resource "backstage_entity_cleanup" "test" {
+					  entity_refs = ["component:team-b/artist-web"]
+					  on_destroy  = false
+					}
+				`, baseURL),
+				Check: resource.TestCheckResourceAttr("backstage_entity_cleanup.test", "deleted_refs.#", "0"),
+			},
+		},
+	})
+}
+
+const testAccResourceEntityCleanupConfig = `
+resource "backstage_entity_cleanup" "test" {
+  entity_refs     = ["component:default/artist-web", "component:default/non-existent-component-a9ab8"]
+  location_target = "http://test1"
+  on_destroy      = false
+}
+`
@@ -0,0 +1,201 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"time"
+
+	"github.com/hashicorp/go-uuid"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &eventResource{}
+	_ resource.ResourceWithConfigure = &eventResource{}
+)
+
+// NewEventResource is a helper function to simplify the provider implementation.
+func NewEventResource() resource.Resource {
+	return &eventResource{}
+}
+
+// eventResource is the resource implementation.
+type eventResource struct {
+	*providerData
+}
+
+// eventResourceModel maps the resource schema data.
+type eventResourceModel struct {
+	ID         types.String      `tfsdk:"id"`
+	Topic      types.String      `tfsdk:"topic"`
+	EntityRefs []types.String    `tfsdk:"entity_refs"`
+	Message    types.String      `tfsdk:"message"`
+	Metadata   map[string]string `tfsdk:"metadata"`
+	Triggers   map[string]string `tfsdk:"triggers"`
+	EmittedAt  types.String      `tfsdk:"emitted_at"`
+}
+
+// eventPayload is the body of the event posted to the HTTP ingress of the events backend.
+type eventPayload struct {
+	ID         string            `json:"id"`
+	Source     string            `json:"source"`
+	EntityRefs []string          `json:"entityRefs"`
+	Message    string            `json:"message,omitempty"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	EmittedAt  string            `json:"emittedAt"`
+}
+
+const (
+	pathEventsHTTP = "/api/events/http/"
+	eventSource    = "terraform"
+
+	descriptionEventID         = "Identifier of the event, sent along with it as `id`."
+	descriptionEventTopic      = "Topic to post the event to. It must be allowed in `events.http.topics` of the Backstage app config."
+	descriptionEventEntityRefs = "Entity references to the entities the apply changed infrastructure of, e.g. `component:default/artist-web`."
+	descriptionEventMessage    = "A human readable description of the change, shown in the activity feeds of the entities."
+	descriptionEventMetadata   = "Metadata of the run, such as the workspace, the commit or a link to the run, sent along with the event."
+	descriptionEventTriggers   = "Arbitrary values that, when changed, post the event again. Set it to `{ run = timestamp() }` to post the " +
+		"event on every apply."
+	descriptionEventEmittedAt = "Timestamp the event was posted at, in RFC 3339 format."
+)
+
+// Metadata returns the data source type name.
+func (r *eventResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_event"
+}
+
+// Schema defines the schema for the resource.
+func (r *eventResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this resource to post an event about entities to the " +
+			"[events backend](https://github.com/backstage/backstage/tree/master/plugins/events-backend) of Backstage when it is applied, so the " +
+			"activity feeds of the entities show the infrastructure changes driven by Terraform. The event is posted when the resource is " +
+			"created or replaced, add `depends_on` to post it once the resources it describes are applied. Destroying the resource posts " +
+			"nothing. \n\n" +
+			"In order for this resource to work, the HTTP ingress of the events backend must be enabled for `topic`.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionEventID, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+			"topic": schema.StringAttribute{Required: true, Description: descriptionEventTopic, Validators: []validator.String{
+				stringvalidator.RegexMatches(regexp.MustCompile(`^[a-zA-Z0-9._-]+$`), "must be a valid topic name"),
+			}, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"entity_refs": schema.ListAttribute{Required: true, Description: descriptionEventEntityRefs, ElementType: types.StringType,
+				Validators:    []validator.List{listvalidator.SizeAtLeast(1)},
+				PlanModifiers: []planmodifier.List{listplanmodifier.RequiresReplace()}},
+			"message": schema.StringAttribute{Optional: true, Description: descriptionEventMessage,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"metadata": schema.MapAttribute{Optional: true, Description: descriptionEventMetadata, ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()}},
+			"triggers": schema.MapAttribute{Optional: true, Description: descriptionEventTriggers, ElementType: types.StringType,
+				PlanModifiers: []planmodifier.Map{mapplanmodifier.RequiresReplace()}},
+			"emitted_at": schema.StringAttribute{Computed: true, Description: descriptionEventEmittedAt, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *eventResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.providerData = req.ProviderData.(*providerData)
+}
+
+// Create posts the event to Backstage and sets the initial Terraform state.
+func (r *eventResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan eventResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	id, err := uuid.GenerateUUID()
+	if err != nil {
+		resp.Diagnostics.AddError("Error posting Backstage event", fmt.Sprintf("Could not generate identifier of the event: %s", err.Error()))
+		return
+	}
+
+	payload := eventPayload{
+		ID:        id,
+		Source:    eventSource,
+		Message:   plan.Message.ValueString(),
+		Metadata:  plan.Metadata,
+		EmittedAt: time.Now().UTC().Format(time.RFC3339),
+	}
+	for _, ref := range plan.EntityRefs {
+		payload.EntityRefs = append(payload.EntityRefs, ref.ValueString())
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Posting event %s to topic %s of Backstage events backend", id, plan.Topic.ValueString()))
+	response, err := r.doJSON(ctx, http.MethodPost, pathEventsHTTP+url.PathEscape(plan.Topic.ValueString()), payload, nil)
+	if err != nil {
+		resp.Diagnostics.AddError("Error posting Backstage event",
+			r.withRequestID(fmt.Sprintf("Could not post event to topic %s, unexpected error: %s", plan.Topic.ValueString(), err.Error())),
+		)
+		return
+	}
+
+	if response.StatusCode < http.StatusOK || response.StatusCode >= http.StatusMultipleChoices {
+		resp.Diagnostics.AddError("Error posting Backstage event",
+			r.withRequestID(fmt.Sprintf("Could not post event to topic %s, unexpected status code: %d", plan.Topic.ValueString(), response.StatusCode)),
+		)
+		return
+	}
+
+	plan.ID = types.StringValue(id)
+	plan.EmittedAt = types.StringValue(payload.EmittedAt)
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read keeps the Terraform state as it is, as posted events can not be read back.
+func (r *eventResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state eventResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update is never called, as changes of any attribute replace the resource, posting the event again.
+func (r *eventResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan eventResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the event from the Terraform state. Nothing is posted to Backstage.
+func (r *eventResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
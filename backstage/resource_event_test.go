@@ -0,0 +1,57 @@
+//go:build !resources
+
+package backstage
+
+import (
+	"os"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceEvent(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create testing
+			{
+				Config: testAccProviderConfig + testAccResourceEventConfig1,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backstage_event.test", "topic", "terraform"),
+					resource.TestCheckResourceAttr("backstage_event.test", "entity_refs.0", "component:default/artist-web"),
+					resource.TestCheckResourceAttrSet("backstage_event.test", "id"),
+					resource.TestCheckResourceAttrSet("backstage_event.test", "emitted_at"),
+				),
+			},
+			// Replace testing
+			{
+				Config: testAccProviderConfig + testAccResourceEventConfig2,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backstage_event.test", "triggers.run", "2"),
+					resource.TestCheckResourceAttrSet("backstage_event.test", "id"),
+				),
+			},
+		},
+	})
+}
+
+const testAccResourceEventConfig1 = `
+resource "backstage_event" "test" {
+  topic       = "terraform"
+  entity_refs = ["component:default/artist-web"]
+  message     = "Infrastructure of artist-web applied"
+  triggers    = { run = "1" }
+}
+`
+const testAccResourceEventConfig2 = `
+resource "backstage_event" "test" {
+  topic       = "terraform"
+  entity_refs = ["component:default/artist-web"]
+  message     = "Infrastructure of artist-web applied"
+  triggers    = { run = "2" }
+}
+`
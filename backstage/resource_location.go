@@ -4,9 +4,12 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/transport"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
@@ -14,12 +17,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
 )
 
 var (
 	_ resource.Resource                = &locationResource{}
 	_ resource.ResourceWithConfigure   = &locationResource{}
 	_ resource.ResourceWithImportState = &locationResource{}
+	_ resource.ResourceWithModifyPlan  = &locationResource{}
 )
 
 // NewLocationResource is a helper function to simplify the provider implementation.
@@ -29,7 +34,7 @@ func NewLocationResource() resource.Resource {
 
 // locationResource is the resource implementation.
 type locationResource struct {
-	client *backstage.Client
+	*providerData
 }
 
 // locationResourceModel maps the resource schema data.
@@ -37,13 +42,23 @@ type locationResourceModel struct {
 	ID          types.String `tfsdk:"id"`
 	Type        types.String `tfsdk:"type"`
 	Target      types.String `tfsdk:"target"`
+	DryRun      types.Bool   `tfsdk:"dry_run"`
 	LastUpdated types.String `tfsdk:"last_updated"`
 }
 
 const (
-	descriptionLocationID          = "Identifier of the location."
-	descriptionLocationType        = "Type of the location. Always `url`."
-	descriptionLocationTarget      = "Target as a string. Should be a valid URL."
+	// pathLocations is the path of the locations endpoint of the catalog.
+	pathLocations = "/api/catalog/locations"
+
+	// locationTypeURL is the type of locations registered through the API of the catalog.
+	locationTypeURL = "url"
+
+	descriptionLocationID     = "Identifier of the location."
+	descriptionLocationType   = "Type of the location. Always `" + locationTypeURL + "`."
+	descriptionLocationTarget = "Target as a string. Should be a valid URL."
+	descriptionLocationDryRun = "Whether to register the target in dry-run mode when planning to create the location, so targets Backstage " +
+		"can not process, e.g. missing or invalid `catalog-info.yaml` files, fail the plan with the errors of Backstage instead of the apply " +
+		"(default: false). Leave it off for targets Backstage can not read yet, e.g. of repositories created in the same apply."
 	descriptionLocationLastUpdated = "Timestamp of the last Terraform update of the location."
 )
 
@@ -55,30 +70,82 @@ func (r *locationResource) Metadata(_ context.Context, req resource.MetadataRequ
 // Schema defines the schema for the resource.
 func (r *locationResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
-		MarkdownDescription: "Use this resource to manage Backstage locations. \n\n" +
+		MarkdownDescription: "Use this resource to manage Backstage locations, e.g. to register the `catalog-info.yaml` of a repository when " +
+			"provisioning it. Creating the resource registers the location, destroying it unregisters the location. Locations unregistered " +
+			"outside of Terraform are registered again by the next apply. Locations can be imported by their ID or by their target URL. \n\n" +
 			"In order for this resource to work, Backstage instance must NOT be running in " +
 			"[read-only mode](https://backstage.io/docs/features/software-catalog/configuration#readonly-mode).",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.StringAttribute{Computed: true, Description: descriptionLocationID, PlanModifiers: []planmodifier.String{
 				stringplanmodifier.UseStateForUnknown(),
 			}},
-			"type": schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionLocationType, Validators: []validator.String{}, PlanModifiers: []planmodifier.String{
-				stringplanmodifier.UseStateForUnknown(),
-			}},
+			"type": schema.StringAttribute{Optional: true, Computed: true, MarkdownDescription: descriptionLocationType,
+				Validators: []validator.String{stringvalidator.OneOf(locationTypeURL)}, PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(), stringplanmodifier.RequiresReplace(),
+				}},
 			"target": schema.StringAttribute{Required: true, Description: descriptionLocationTarget,
 				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"dry_run":      schema.BoolAttribute{Optional: true, MarkdownDescription: descriptionLocationDryRun},
 			"last_updated": schema.StringAttribute{Computed: true, Description: descriptionLocationLastUpdated},
 		},
 	}
 }
 
+// ModifyPlan registers the targets of locations to create in dry-run mode, if `dry_run` is set, so targets Backstage can not process, e.g.
+// missing or invalid `catalog-info.yaml` files, fail the plan instead of the apply.
+func (r *locationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Locations to destroy need no validating, and neither do those planned before the provider is configured.
+	if req.Plan.Raw.IsNull() || r.providerData == nil {
+		return
+	}
+
+	var plan, state locationResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	}
+	if resp.Diagnostics.HasError() || plan.Target.IsUnknown() || plan.Target.Equal(state.Target) {
+		return
+	}
+	if !plan.DryRun.ValueBool() {
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Registering location %s in dry-run mode", plan.Target.ValueString()))
+	var result backstage.LocationCreateResponse
+	response, err := r.doJSON(ctx, http.MethodPost, pathLocations+"?dryRun=true", map[string]string{
+		"type":   locationTypeURL,
+		"target": plan.Target.ValueString(),
+	}, &result)
+	if err != nil {
+		resp.Diagnostics.AddWarning("Could not validate location",
+			r.withRequestID(fmt.Sprintf("Could not register location %s in dry-run mode: %s", plan.Target.ValueString(), err.Error())))
+		return
+	}
+
+	switch {
+	case response.StatusCode == http.StatusBadRequest || response.StatusCode == http.StatusNotFound ||
+		response.StatusCode == http.StatusUnprocessableEntity:
+		resp.Diagnostics.AddAttributeError(path.Root("target"), "Invalid location",
+			r.withRequestID(fmt.Sprintf("Backstage could not process location %s: %s", plan.Target.ValueString(), backstageError(response))))
+	case response.StatusCode == http.StatusConflict || (response.StatusCode == http.StatusCreated && result.Exists):
+		resp.Diagnostics.AddAttributeError(path.Root("target"), "Invalid location",
+			r.withRequestID(fmt.Sprintf("Location %s is already registered in Backstage. Import it by its target URL to manage it with Terraform.",
+				plan.Target.ValueString())))
+	case response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK:
+		// Instances in read-only mode or denying dry-runs to the token still register locations the usual way on apply.
+		resp.Diagnostics.AddWarning("Could not validate location",
+			r.withRequestID(fmt.Sprintf("Could not register location %s in dry-run mode: %s", plan.Target.ValueString(), backstageError(response))))
+	}
+}
+
 // Configure adds the provider configured client to the data source.
 func (r *locationResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
 	if req.ProviderData == nil {
 		return
 	}
 
-	r.client = req.ProviderData.(*backstage.Client)
+	r.providerData = req.ProviderData.(*providerData)
 }
 
 // Create registers a new location in Backstage and sets the initial Terraform state.
@@ -93,14 +160,22 @@ func (r *locationResource) Create(ctx context.Context, req resource.CreateReques
 	location, response, err := r.client.Catalog.Locations.Create(ctx, plan.Target.ValueString(), false)
 	if err != nil {
 		resp.Diagnostics.AddError("Error creating location",
-			fmt.Sprintf("Could not create location, unexpected error: %s", err.Error()),
+			r.withRequestID(fmt.Sprintf("Could not create location, unexpected error: %s", err.Error())),
+		)
+		return
+	}
+
+	if response.StatusCode == http.StatusConflict {
+		resp.Diagnostics.AddAttributeError(path.Root("target"), "Error creating location",
+			r.withRequestID(fmt.Sprintf("Location %s is already registered in Backstage. Import it by its target URL to manage it with Terraform.",
+				plan.Target.ValueString())),
 		)
 		return
 	}
 
 	if response.StatusCode != http.StatusCreated {
 		resp.Diagnostics.AddError("Error creating location",
-			fmt.Sprintf("Could not create location, unexpected status code: %d", response.StatusCode),
+			r.withRequestID(fmt.Sprintf("Could not create location, unexpected status code: %d", response.StatusCode)),
 		)
 		return
 	}
@@ -126,17 +201,25 @@ func (r *locationResource) Read(ctx context.Context, req resource.ReadRequest, r
 		return
 	}
 
-	location, response, err := r.client.Catalog.Locations.GetByID(ctx, state.ID.ValueString())
+	// Refreshes bypass the cache of responses, so locations changed since are not missed.
+	location, response, err := r.client.Catalog.Locations.GetByID(transport.WithoutCache(ctx), state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error reading Backstage location",
-			fmt.Sprintf("Could not read Backstage location ID %s: %s", state.ID.ValueString(), err.Error()),
+			r.withRequestID(fmt.Sprintf("Could not read Backstage location ID %s: %s", state.ID.ValueString(), err.Error())),
 		)
 		return
 	}
 
+	// Locations unregistered outside of Terraform are registered again by the next apply.
+	if response.StatusCode == http.StatusNotFound {
+		tflog.Warn(ctx, fmt.Sprintf("Backstage location ID %s not found, removing it from the state", state.ID.ValueString()))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
 	if response.StatusCode != http.StatusOK {
 		resp.Diagnostics.AddError("Error reading Backstage location",
-			fmt.Sprintf("Could not read Backstage location ID %s, unexpected status code: %d", state.ID.ValueString(), response.StatusCode),
+			r.withRequestID(fmt.Sprintf("Could not read Backstage location ID %s, unexpected status code: %d", state.ID.ValueString(), response.StatusCode)),
 		)
 		return
 	}
@@ -151,7 +234,7 @@ func (r *locationResource) Read(ctx context.Context, req resource.ReadRequest, r
 	}
 }
 
-// Update updates the resource and sets the updated Terraform state on success.
+// Update sets the updated Terraform state, as only changes of `dry_run` do not replace the resource. Nothing is requested from Backstage.
 func (r *locationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
 	// Retrieve values from plan
 	var plan locationResourceModel
@@ -167,6 +250,13 @@ func (r *locationResource) Update(ctx context.Context, req resource.UpdateReques
 	if resp.Diagnostics.HasError() {
 		return
 	}
+
+	plan.ID = state.ID
+	plan.Type = state.Type
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
 }
 
 // Delete deletes the location and removes the Terraform state on success.
@@ -181,20 +271,49 @@ func (r *locationResource) Delete(ctx context.Context, req resource.DeleteReques
 	response, err := r.client.Catalog.Locations.DeleteByID(ctx, state.ID.ValueString())
 	if err != nil {
 		resp.Diagnostics.AddError("Error deleting Backstage location",
-			fmt.Sprintf("Could not delete location, unexpected error: %s", err.Error()),
+			r.withRequestID(fmt.Sprintf("Could not delete location, unexpected error: %s", err.Error())),
 		)
 		return
 	}
 
-	if response.StatusCode != http.StatusNoContent {
+	// Locations already unregistered outside of Terraform need no deleting.
+	if response.StatusCode != http.StatusNoContent && response.StatusCode != http.StatusNotFound {
 		resp.Diagnostics.AddError("Error deleting Backstage location",
-			fmt.Sprintf("Could not delete location, unexpected status code: %d", response.StatusCode),
+			r.withRequestID(fmt.Sprintf("Could not delete location, unexpected status code: %d", response.StatusCode)),
 		)
 		return
 	}
 }
 
-// ImportState imports the resource into Terraform state.
+// ImportState imports the resource into Terraform state, by the ID of the location or by its target URL, which is resolved to the ID through
+// the list of locations.
 func (r *locationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
-	resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+	if !strings.Contains(req.ID, "://") {
+		resource.ImportStatePassthroughID(ctx, path.Root("id"), req, resp)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Getting location with target %s from Backstage API", req.ID))
+	locations, response, err := r.client.Catalog.Locations.List(transport.WithoutCache(ctx))
+	if err != nil {
+		resp.Diagnostics.AddError("Error importing Backstage location",
+			r.withRequestID(fmt.Sprintf("Could not read Backstage locations: %s", err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error importing Backstage location",
+			r.withRequestID(fmt.Sprintf("Could not read Backstage locations: %s", response.Status)))
+		return
+	}
+
+	for _, l := range locations {
+		if l.Data != nil && l.Data.Target == req.ID {
+			resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), l.Data.ID)...)
+			return
+		}
+	}
+
+	resp.Diagnostics.AddError("Error importing Backstage location",
+		fmt.Sprintf("Could not find Backstage location with target %s. Import it by its ID, or register it by applying the resource.", req.ID))
 }
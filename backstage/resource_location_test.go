@@ -4,6 +4,7 @@ package backstage
 
 import (
 	"os"
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
@@ -17,6 +18,32 @@ func TestAccResourceLocation(t *testing.T) {
 	resource.Test(t, resource.TestCase{
 		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
 		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: testAccProviderConfig + `
+					resource "backstage_location" "test" {
+					  type   = "file"
+					  target = "http://test1"
+					}
+				`,
+				ExpectError: regexp.MustCompile(`Attribute type value must be one of`),
+			},
+			// Dry-run testing: targets are not registered in dry-run mode by default, so the plan succeeds.
+			{
+				Config:             testAccProviderConfig + testAccResourceLocationConfig1,
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccProviderConfig + `
+					resource "backstage_location" "test" {
+					  target  = "http://test1"
+					  dry_run = true
+					}
+				`,
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Invalid location`),
+			},
 			// Create testing
 			{
 				Config: testAccProviderConfig + testAccResourceLocationConfig1,
@@ -34,6 +61,27 @@ func TestAccResourceLocation(t *testing.T) {
 				ImportStateVerify:       true,
 				ImportStateVerifyIgnore: []string{"last_updated"},
 			},
+			// ImportState by target testing
+			{
+				ResourceName:            "backstage_location.test",
+				ImportState:             true,
+				ImportStateId:           "http://test1",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update of dry_run testing: registered targets are not registered in dry-run mode again.
+			{
+				Config: testAccProviderConfig + `
+					resource "backstage_location" "test" {
+					  target  = "http://test1"
+					  dry_run = true
+					}
+				`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backstage_location.test", "target", "http://test1"),
+					resource.TestCheckResourceAttr("backstage_location.test", "dry_run", "true"),
+				),
+			},
 			// Update and Read testing
 			{
 				Config: testAccProviderConfig + testAccResourceLocationConfig2,
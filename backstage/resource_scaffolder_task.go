@@ -0,0 +1,327 @@
+package backstage
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/transport"
+	"github.com/hashicorp/terraform-plugin-framework-jsontypes/jsontypes"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+var (
+	_ resource.Resource              = &scaffolderTaskResource{}
+	_ resource.ResourceWithConfigure = &scaffolderTaskResource{}
+)
+
+// NewScaffolderTaskResource is a helper function to simplify the provider implementation.
+func NewScaffolderTaskResource() resource.Resource {
+	return &scaffolderTaskResource{}
+}
+
+// scaffolderTaskResource is the resource implementation.
+type scaffolderTaskResource struct {
+	*providerData
+}
+
+// scaffolderTaskResourceModel maps the resource schema data.
+type scaffolderTaskResourceModel struct {
+	ID                types.String         `tfsdk:"id"`
+	TemplateRef       types.String         `tfsdk:"template_ref"`
+	Parameters        jsontypes.Normalized `tfsdk:"parameters"`
+	Secrets           map[string]string    `tfsdk:"secrets"`
+	SecretsVersion    types.String         `tfsdk:"secrets_version"`
+	WaitForCompletion types.Bool           `tfsdk:"wait_for_completion"`
+	TimeoutSeconds    types.Int64          `tfsdk:"timeout_seconds"`
+	Status            types.String         `tfsdk:"status"`
+	CreatedAt         types.String         `tfsdk:"created_at"`
+}
+
+// scaffolderTask is a task of the scaffolder v2 API.
+type scaffolderTask struct {
+	ID              string `json:"id"`
+	Status          string `json:"status"`
+	CreatedAt       string `json:"createdAt"`
+	LastHeartbeatAt string `json:"lastHeartbeatAt"`
+	CreatedBy       string `json:"createdBy"`
+	Spec            struct {
+		TemplateInfo struct {
+			EntityRef string `json:"entityRef"`
+		} `json:"templateInfo"`
+		Parameters interface{}          `json:"parameters"`
+		Steps      []scaffolderTaskStep `json:"steps"`
+	} `json:"spec"`
+}
+
+// scaffolderTaskStep is a step of the template a task of the scaffolder v2 API runs.
+type scaffolderTaskStep struct {
+	ID     string `json:"id"`
+	Name   string `json:"name"`
+	Action string `json:"action"`
+}
+
+// scaffolderTaskRequest is the request body of the tasks endpoint of the scaffolder v2 API.
+type scaffolderTaskRequest struct {
+	TemplateRef string            `json:"templateRef"`
+	Values      interface{}       `json:"values"`
+	Secrets     map[string]string `json:"secrets,omitempty"`
+}
+
+const (
+	pathScaffolderTasks = "/api/scaffolder/v2/tasks"
+
+	scaffolderTaskStatusCompleted = "completed"
+	scaffolderTaskStatusFailed    = "failed"
+	scaffolderTaskStatusCancelled = "cancelled"
+
+	scaffolderTaskDefaultTimeout = 10 * time.Minute
+	scaffolderTaskPollInterval   = 5 * time.Second
+
+	descriptionScaffolderTaskID          = "Identifier of the task."
+	descriptionScaffolderTaskTemplateRef = "An entity reference to the template to run, e.g. `template:default/react-ssr-template`. The kind and " +
+		"namespace default to `template` and `default`."
+	descriptionScaffolderTaskParameters = "Values of the parameters of the template, as JSON."
+	descriptionScaffolderTaskSecrets    = "Secrets passed to the template, available to its steps as `${{ secrets.<name> }}`. Write-only: they are " +
+		"never stored in the Terraform state or plan, so changes of them are not detected. Change `secrets_version` to run the template with " +
+		"changed secrets. Requires Terraform 1.11 or later."
+	descriptionScaffolderTaskSecretsVersion = "Arbitrary value that, when changed, runs the template again, e.g. with changed `secrets`."
+	descriptionScaffolderTaskWait           = "Whether to wait for the task to complete, failing the apply if the task fails (default: true)."
+	descriptionScaffolderTaskTimeoutSeconds = "Maximum time to wait for the task to complete in seconds (default: 600)."
+	descriptionScaffolderTaskStatus         = "Status of the task: `open`, `processing`, `completed`, `failed` or `cancelled`."
+	descriptionScaffolderTaskCreatedAt      = "Timestamp the task was created at."
+)
+
+// Metadata returns the data source type name.
+func (r *scaffolderTaskResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_scaffolder_task"
+}
+
+// Schema defines the schema for the resource.
+func (r *scaffolderTaskResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		MarkdownDescription: "Use this resource to run a [Software Template](https://backstage.io/docs/features/software-templates/) with the " +
+			"scaffolder of Backstage. The template is run when the resource is created or replaced, destroying the resource leaves the task and " +
+			"what the template created as they are. Tokens for the template can be passed in `secrets`, which are never stored in the Terraform " +
+			"state.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskID, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+			"template_ref": schema.StringAttribute{Required: true, MarkdownDescription: descriptionScaffolderTaskTemplateRef, Validators: []validator.String{
+				stringvalidator.LengthAtLeast(1),
+			}, PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"parameters": schema.StringAttribute{Optional: true, Description: descriptionScaffolderTaskParameters, CustomType: jsontypes.NormalizedType{},
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"secrets": schema.MapAttribute{Optional: true, Sensitive: true, WriteOnly: true, MarkdownDescription: descriptionScaffolderTaskSecrets,
+				ElementType: types.StringType},
+			"secrets_version": schema.StringAttribute{Optional: true, MarkdownDescription: descriptionScaffolderTaskSecretsVersion,
+				PlanModifiers: []planmodifier.String{stringplanmodifier.RequiresReplace()}},
+			"wait_for_completion": schema.BoolAttribute{Optional: true, Description: descriptionScaffolderTaskWait},
+			"timeout_seconds": schema.Int64Attribute{Optional: true, Description: descriptionScaffolderTaskTimeoutSeconds, Validators: []validator.Int64{
+				int64validator.AtLeast(1),
+			}},
+			"status": schema.StringAttribute{Computed: true, MarkdownDescription: descriptionScaffolderTaskStatus},
+			"created_at": schema.StringAttribute{Computed: true, Description: descriptionScaffolderTaskCreatedAt, PlanModifiers: []planmodifier.String{
+				stringplanmodifier.UseStateForUnknown(),
+			}},
+		},
+	}
+}
+
+// Configure adds the provider configured client to the data source.
+func (r *scaffolderTaskResource) Configure(_ context.Context, req resource.ConfigureRequest, _ *resource.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+
+	r.providerData = req.ProviderData.(*providerData)
+}
+
+// Create runs the template with the scaffolder and sets the initial Terraform state.
+func (r *scaffolderTaskResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan scaffolderTaskResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only attributes are null in the plan, so the secrets are taken from the configuration.
+	var secrets map[string]string
+	resp.Diagnostics.Append(req.Config.GetAttribute(ctx, path.Root("secrets"), &secrets)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	kind, namespace, name := parseEntityRef(plan.TemplateRef.ValueString(), "template", backstage.DefaultNamespaceName)
+	body := scaffolderTaskRequest{TemplateRef: canonicalEntityRef(kind, namespace, name), Values: map[string]interface{}{}, Secrets: secrets}
+	if !plan.Parameters.IsNull() {
+		resp.Diagnostics.Append(plan.Parameters.Unmarshal(&body.Values)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Running template %s with Backstage scaffolder", body.TemplateRef))
+	var created struct {
+		ID string `json:"id"`
+	}
+	response, err := r.doJSON(ctx, http.MethodPost, pathScaffolderTasks, body, &created)
+	if err != nil {
+		resp.Diagnostics.AddError("Error running Backstage template",
+			r.withRequestID(fmt.Sprintf("Could not run template %s, unexpected error: %s", body.TemplateRef, err.Error())))
+		return
+	}
+
+	if response.StatusCode != http.StatusCreated && response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error running Backstage template",
+			r.withRequestID(fmt.Sprintf("Could not run template %s: %s", body.TemplateRef, backstageError(response))))
+		return
+	}
+
+	plan.ID = types.StringValue(created.ID)
+	plan.Secrets = nil
+	plan.Status, plan.CreatedAt = types.StringNull(), types.StringNull()
+
+	task := r.waitForTask(ctx, plan, &resp.Diagnostics)
+	if task != nil {
+		plan.Status = types.StringValue(task.Status)
+		plan.CreatedAt = types.StringValue(task.CreatedAt)
+	}
+
+	// The state is set even if the task failed, so the resource is tainted and the template is run again by the next apply.
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read reads the status of the task and refreshes the Terraform state with it.
+func (r *scaffolderTaskResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var state scaffolderTaskResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	task, response, err := r.getTask(ctx, state.ID.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddError("Error reading Backstage scaffolder task",
+			r.withRequestID(fmt.Sprintf("Could not read Backstage scaffolder task %s: %s", state.ID.ValueString(), err.Error())))
+		return
+	}
+
+	// Tasks removed by the retention of the scaffolder are kept in the state, as the template did run.
+	if response.StatusCode == http.StatusNotFound {
+		tflog.Warn(ctx, fmt.Sprintf("Backstage scaffolder task %s not found, keeping it in the state", state.ID.ValueString()))
+		return
+	}
+
+	if response.StatusCode != http.StatusOK {
+		resp.Diagnostics.AddError("Error reading Backstage scaffolder task",
+			r.withRequestID(fmt.Sprintf("Could not read Backstage scaffolder task %s: %s", state.ID.ValueString(), response.Status)))
+		return
+	}
+
+	state.Status = types.StringValue(task.Status)
+	state.CreatedAt = types.StringValue(task.CreatedAt)
+
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Update sets the updated Terraform state, as only changes of the waiting do not replace the resource. The template is not run again.
+func (r *scaffolderTaskResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var plan, state scaffolderTaskResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	plan.Secrets = nil
+	plan.Status = state.Status
+
+	diags := resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+}
+
+// Delete removes the task from the Terraform state. Tasks and what the templates created are left as they are.
+func (r *scaffolderTaskResource) Delete(_ context.Context, _ resource.DeleteRequest, _ *resource.DeleteResponse) {
+}
+
+// getTask reads the task from the scaffolder, bypassing the cache of responses, as it is polled until the task finishes.
+func (p *providerData) getTask(ctx context.Context, id string) (*scaffolderTask, *http.Response, error) {
+	var task scaffolderTask
+	response, err := p.doJSON(transport.WithoutCache(ctx), http.MethodGet, pathScaffolderTasks+"/"+url.PathEscape(id), nil, &task)
+	if err != nil || response.StatusCode != http.StatusOK {
+		return nil, response, err
+	}
+
+	return &task, response, nil
+}
+
+// waitForTask polls the task until it is completed, failed or cancelled, unless waiting is turned off, and returns the last read task. Tasks
+// that failed or were cancelled are reported as errors, tasks that did not complete in time as warnings.
+func (r *scaffolderTaskResource) waitForTask(ctx context.Context, model scaffolderTaskResourceModel, diags *diag.Diagnostics) *scaffolderTask {
+	timeout := scaffolderTaskDefaultTimeout
+	if !model.TimeoutSeconds.IsNull() {
+		timeout = time.Duration(model.TimeoutSeconds.ValueInt64()) * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	for {
+		task, response, err := r.getTask(ctx, model.ID.ValueString())
+		if err != nil {
+			diags.AddWarning("Could not read Backstage scaffolder task",
+				r.withRequestID(fmt.Sprintf("Could not read Backstage scaffolder task %s: %s", model.ID.ValueString(), err.Error())))
+			return nil
+		}
+
+		if response.StatusCode != http.StatusOK {
+			diags.AddWarning("Could not read Backstage scaffolder task",
+				r.withRequestID(fmt.Sprintf("Could not read Backstage scaffolder task %s: %s", model.ID.ValueString(), response.Status)))
+			return nil
+		}
+
+		switch {
+		case model.WaitForCompletion.Equal(types.BoolValue(false)) || task.Status == scaffolderTaskStatusCompleted:
+			return task
+		case task.Status == scaffolderTaskStatusFailed || task.Status == scaffolderTaskStatusCancelled:
+			diags.AddError("Backstage scaffolder task did not complete",
+				fmt.Sprintf("Backstage scaffolder task %s running template %s is %s. Its log shows the reasons.", task.ID,
+					model.TemplateRef.ValueString(), task.Status))
+			return task
+		}
+
+		if time.Now().Add(scaffolderTaskPollInterval).After(deadline) {
+			diags.AddWarning("Timeout waiting for Backstage scaffolder task",
+				fmt.Sprintf("Backstage scaffolder task %s did not complete within %s, it is %s.", task.ID, timeout, task.Status))
+			return task
+		}
+
+		tflog.Debug(ctx, fmt.Sprintf("Waiting for Backstage scaffolder task %s to complete", task.ID))
+		select {
+		case <-ctx.Done():
+			diags.AddWarning("Timeout waiting for Backstage scaffolder task", ctx.Err().Error())
+			return task
+		case <-time.After(scaffolderTaskPollInterval):
+		}
+	}
+}
@@ -0,0 +1,175 @@
+//go:build !resources
+
+package backstage
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccResourceScaffolderTask(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: testAccProviderConfig + `
+					resource "backstage_scaffolder_task" "test" {
+						template_ref    = "template:default/react-ssr-template"
+						timeout_seconds = 0
+					}
+				`,
+				ExpectError: regexp.MustCompile("Invalid Attribute Value"),
+			},
+		},
+	})
+}
+
+func TestAccResourceScaffolderTask_Completed(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	const baseURL = "http://backstage.test"
+	defer gock.Off()
+	// The secrets are sent with the task, but never stored in the state.
+	gock.New(baseURL).
+		Post(pathScaffolderTasks).
+		MatchType("json").
+		JSON(map[string]interface{}{
+			"templateRef": "template:default/react-ssr-template",
+			"values":      map[string]interface{}{"name": "artist-web"},
+			"secrets":     map[string]interface{}{"token": "s3cr3t"},
+		}).
+		Reply(http.StatusCreated).
+		JSON(map[string]interface{}{"id": "task-completed"})
+	gock.New(baseURL).
+		Get(pathScaffolderTasks + "/task-completed").
+		Reply(http.StatusOK).
+		JSON(testAccScaffolderTask("task-completed", "processing"))
+	gock.New(baseURL).Persist().
+		Get(pathScaffolderTasks + "/task-completed").
+		Reply(http.StatusOK).
+		JSON(testAccScaffolderTask("task-completed", scaffolderTaskStatusCompleted))
+
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceScaffolderTaskConfig, baseURL, `secrets = { token = "s3cr3t" }`),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backstage_scaffolder_task.test", "id", "task-completed"),
+					resource.TestCheckResourceAttr("backstage_scaffolder_task.test", "status", scaffolderTaskStatusCompleted),
+					resource.TestCheckResourceAttr("backstage_scaffolder_task.test", "created_at", "2025-01-01T00:00:00Z"),
+					resource.TestCheckNoResourceAttr("backstage_scaffolder_task.test", "secrets"),
+					resource.TestCheckNoResourceAttr("backstage_scaffolder_task.test", "secrets.%"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccResourceScaffolderTask_NotCompleted(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	for _, status := range []string{scaffolderTaskStatusFailed, scaffolderTaskStatusCancelled} {
+		t.Run(status, func(t *testing.T) {
+			const baseURL = "http://backstage.test"
+			id := "task-" + status
+			defer gock.Off()
+			gock.New(baseURL).
+				Post(pathScaffolderTasks).
+				Reply(http.StatusCreated).
+				JSON(map[string]interface{}{"id": id})
+			gock.New(baseURL).Persist().
+				Get(pathScaffolderTasks + "/" + id).
+				Reply(http.StatusOK).
+				JSON(testAccScaffolderTask(id, status))
+
+			resource.Test(t, resource.TestCase{
+				ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+				Steps: []resource.TestStep{
+					{
+						Config:      fmt.Sprintf(testAccResourceScaffolderTaskConfig, baseURL, ""),
+						ExpectError: regexp.MustCompile(fmt.Sprintf("%s[\\s\\S]*is\\s+%s\\.", id, status)),
+					},
+					// The task is kept in the state tainted, so the template is run again by the next apply.
+					{
+						Config:             fmt.Sprintf(testAccResourceScaffolderTaskConfig, baseURL, ""),
+						PlanOnly:           true,
+						ExpectNonEmptyPlan: true,
+					},
+				},
+			})
+		})
+	}
+}
+
+func TestAccResourceScaffolderTask_Timeout(t *testing.T) {
+	if os.Getenv("ACCTEST_SKIP_RESOURCE_TEST") != "" {
+		t.Skip("Skipping as ACCTEST_SKIP_RESOURCE_TEST is set")
+	}
+
+	const baseURL = "http://backstage.test"
+	defer gock.Off()
+	gock.New(baseURL).
+		Post(pathScaffolderTasks).
+		Reply(http.StatusCreated).
+		JSON(map[string]interface{}{"id": "task-processing"})
+	gock.New(baseURL).Persist().
+		Get(pathScaffolderTasks + "/task-processing").
+		Reply(http.StatusOK).
+		JSON(testAccScaffolderTask("task-processing", "processing"))
+
+	// The timeout expires before the next poll, which is only warned about, so the apply succeeds with the task still processing.
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: fmt.Sprintf(testAccResourceScaffolderTaskConfig, baseURL, "timeout_seconds = 1"),
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("backstage_scaffolder_task.test", "id", "task-processing"),
+					resource.TestCheckResourceAttr("backstage_scaffolder_task.test", "status", "processing"),
+				),
+			},
+		},
+	})
+}
+
+// testAccScaffolderTask returns a response of the tasks endpoint of the scaffolder v2 API with a task of the status.
+func testAccScaffolderTask(id, status string) map[string]interface{} {
+	return map[string]interface{}{
+		"id":        id,
+		"status":    status,
+		"createdAt": "2025-01-01T00:00:00Z",
+		"spec": map[string]interface{}{
+			"templateInfo": map[string]interface{}{"entityRef": "template:default/react-ssr-template"},
+			"parameters":   map[string]interface{}{"name": "artist-web"},
+			"steps":        []interface{}{},
+		},
+	}
+}
+
+const testAccResourceScaffolderTaskConfig = `
+provider "backstage" {
+  base_url = %q
+}
+
+resource "backstage_scaffolder_task" "test" {
+  template_ref = "react-ssr-template"
+  parameters   = jsonencode({ name = "artist-web" })
+  %s
+}
+`
@@ -0,0 +1,11 @@
+# Retrieves the repository slugs of several services with a single request:
+data "backstage_annotations" "example" {
+  // Entity references to the services, the namespace defaults to "default":
+  entity_refs = ["component:artist-web", "component:playback-order"]
+  // Keys of the annotations to read:
+  keys = ["github.com/project-slug"]
+}
+
+output "project_slugs" {
+  value = { for ref, a in data.backstage_annotations.example.values : ref => lookup(a, "github.com/project-slug", null) }
+}
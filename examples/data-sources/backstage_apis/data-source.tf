@@ -0,0 +1,29 @@
+# Retrieves all APIs in a namespace with the components providing and consuming them:
+data "backstage_apis" "example" {
+  // Namespace of the APIs:
+  namespace = "default"
+  // Include references to the providing and consuming components:
+  include_relations = true
+}
+
+# Outputs the components consuming each API:
+output "example" {
+  value = { for a in data.backstage_apis.example.apis : a.ref => a.consumed_by }
+}
+
+# Retrieves the OpenAPI definitions of all APIs of a system:
+data "backstage_apis" "system" {
+  // Type of the APIs:
+  type = "openapi"
+  // System of the APIs, kind and namespace default to "system" and "default":
+  system = "audio-playback"
+  // Include the definitions of the APIs:
+  include_definitions = true
+}
+
+# Creates a file per API definition:
+resource "local_file" "example" {
+  for_each = { for a in data.backstage_apis.system.apis : a.ref => a }
+  filename = "${each.value.name}.yaml"
+  content  = each.value.definition
+}
@@ -0,0 +1,8 @@
+# Detects APIs supported by the Backstage instance:
+data "backstage_capabilities" "example" {}
+
+# Reads entities page by page only if the instance supports it:
+data "backstage_entities" "example" {
+  filters   = ["kind=Component"]
+  page_size = data.backstage_capabilities.example.entities_by_query ? 100 : null
+}
@@ -1,7 +1,26 @@
 # Retrieves specific component data:
 data "backstage_component" "example" {
-  # Required name of the component:
+  # Name of the component, unless it is looked up by uid, annotation or title:
   name = "example-component"
   # If not provided, namespace defaults to "default" or the the one set in the provider:
   namespace = "example-namespace"
 }
+
+# Finds the component of a repository by its annotation:
+data "backstage_component" "by_repo" {
+  annotation = {
+    "github.com/project-slug" = "example-org/example-repo"
+  }
+}
+
+# Retrieves a field not in the schema of the data source with a JMESPath query:
+data "backstage_component" "query" {
+  name = "example-component"
+  # JMESPath expression applied to the raw JSON of the component:
+  query = "metadata.annotations.\"github.com/project-slug\""
+}
+
+# Outputs the decoded result of the query:
+output "project_slug" {
+  value = jsondecode(data.backstage_component.query.query_result)
+}
@@ -0,0 +1,22 @@
+# Retrieves the services of a team in production:
+data "backstage_components" "example" {
+  // Type of the components:
+  type = "service"
+  // Lifecycle state of the components:
+  lifecycle = "production"
+  // Owner of the components, kind and namespace default to "group" and "default":
+  owner = "team-a"
+  // Components having any of the tags:
+  tags = ["go", "java"]
+}
+
+# Outputs references to the components:
+output "example" {
+  value = data.backstage_components.example.components[*].ref
+}
+
+# Creates a repository per component, keyed by the stable set of references:
+resource "github_repository" "example" {
+  for_each = data.backstage_components.example.refs
+  name     = split("/", each.value)[1]
+}
@@ -0,0 +1,16 @@
+# Gets the kinds, lifecycles and relation types known to the provider:
+data "backstage_constants" "example" {}
+
+# Validates inputs of a module against them:
+variable "lifecycle" {
+  type = string
+}
+
+output "lifecycle" {
+  value = var.lifecycle
+
+  precondition {
+    condition     = contains(data.backstage_constants.example.lifecycles, var.lifecycle)
+    error_message = "The lifecycle must be one of the lifecycles defined by Backstage."
+  }
+}
@@ -0,0 +1,13 @@
+# Retrieves the entities the services of a rollout depend on, in the order to deploy them in:
+data "backstage_dependency_closure" "example" {
+  // Entity references to the services, the namespace defaults to "default":
+  entity_refs = ["component:artist-web", "component:playback-order"]
+  // Types of the relations to follow, defaults to ["dependsOn"]:
+  relation_types = ["dependsOn"]
+  // Fails instead of warning, if the dependencies have cycles:
+  fail_on_cycle = true
+}
+
+output "rollout_order" {
+  value = data.backstage_dependency_closure.example.ordered_refs
+}
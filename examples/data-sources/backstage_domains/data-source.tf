@@ -0,0 +1,12 @@
+# Retrieves the domains owned by a team:
+data "backstage_domains" "example" {
+  // Owner of the domains, kind and namespace default to "group" and "default":
+  owner = "team-a"
+}
+
+# Creates a cloud project per domain:
+resource "google_project" "example" {
+  for_each   = data.backstage_domains.example.refs
+  name       = split("/", each.value)[1]
+  project_id = "domain-${split("/", each.value)[1]}"
+}
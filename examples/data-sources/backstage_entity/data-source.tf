@@ -0,0 +1,10 @@
+# Retrieves an entity of a custom kind:
+data "backstage_entity" "example" {
+  // Entity reference to the entity, the namespace defaults to the default namespace of the provider:
+  entity_ref = "environment:default/production"
+}
+
+# Reads fields of the spec of the entity:
+locals {
+  example_region = jsondecode(data.backstage_entity.example.spec).region
+}
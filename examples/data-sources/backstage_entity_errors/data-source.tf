@@ -0,0 +1,17 @@
+# Retrieves status items, including processing errors, of an entity:
+data "backstage_entity_errors" "example" {
+  // The kind of the entity:
+  kind = "Component"
+  // The name of the entity:
+  name = "example"
+  // The namespace of the entity, defaults to "default":
+  namespace = "default"
+}
+
+# Fails the plan if the descriptor of the entity could not be processed:
+check "example_descriptor" {
+  assert {
+    condition     = !data.backstage_entity_errors.example.has_errors
+    error_message = join("\n", [for i in data.backstage_entity_errors.example.items : i.message if i.level == "error"])
+  }
+}
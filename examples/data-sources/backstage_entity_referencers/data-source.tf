@@ -0,0 +1,15 @@
+# Retrieves the entities having a relation to a resource:
+data "backstage_entity_referencers" "example" {
+  // Entity reference to the resource, the namespace defaults to "default":
+  entity_ref = "resource:artists-db"
+  // Types of the relations, defaults to the well-known and custom relation types:
+  relation_types = ["dependencyOf", "dependsOn"]
+}
+
+# Fails the plan while components still depend on the resource to be decommissioned:
+check "artists_db_decommission" {
+  assert {
+    condition     = length(data.backstage_entity_referencers.example.referencers) == 0
+    error_message = "Still referenced by: ${join(", ", data.backstage_entity_referencers.example.refs)}"
+  }
+}
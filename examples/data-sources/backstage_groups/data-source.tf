@@ -0,0 +1,28 @@
+# Retrieves all groups:
+data "backstage_groups" "all" {}
+
+# Retrieves the groups a user is a member of, directly or through a child group:
+data "backstage_groups" "example" {
+  // An entity reference to the user:
+  member = "user:default/guest"
+}
+
+# Outputs references of the groups of the user:
+output "example" {
+  value = [for g in data.backstage_groups.example.groups : g.ref]
+}
+
+# Retrieves the teams of a business unit:
+data "backstage_groups" "teams" {
+  // Type of the groups:
+  type = "team"
+  // Parent group of the groups, kind and namespace default to "group" and "default":
+  parent = "infrastructure"
+}
+
+# Creates a cloud account per team:
+resource "aws_organizations_account" "example" {
+  for_each = data.backstage_groups.teams.refs
+  name     = split("/", each.value)[1]
+  email    = "${split("/", each.value)[1]}@example.com"
+}
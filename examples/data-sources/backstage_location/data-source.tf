@@ -5,3 +5,11 @@ data "backstage_location" "example" {
   # If not provided, namespace defaults to "default" or the the one set in the provider:
   namespace = "example-namespace"
 }
+
+# Outputs the targets the location fans out to, e.g. for aggregated locations:
+output "example_targets" {
+  value = compact(concat(
+    [data.backstage_location.example.spec.target],
+    data.backstage_location.example.spec.targets == null ? [] : data.backstage_location.example.spec.targets,
+  ))
+}
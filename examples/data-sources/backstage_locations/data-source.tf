@@ -0,0 +1,17 @@
+# Retrieves the locations registered from the repositories of an organization:
+data "backstage_locations" "example" {
+  // Type of the locations:
+  type = "url"
+  // Prefix of the targets of the locations:
+  target_prefix = "https://github.com/acme/"
+}
+
+# Outputs the targets of the locations:
+output "example" {
+  value = [for l in data.backstage_locations.example.locations : l.target]
+}
+
+# Outputs the targets registered in the catalog but not managed by Terraform:
+output "unmanaged" {
+  value = setsubtract(data.backstage_locations.example.targets, [for l in backstage_location.example : l.target])
+}
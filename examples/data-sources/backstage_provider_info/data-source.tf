@@ -0,0 +1,10 @@
+# Gets the version of the provider and its active deprecations:
+data "backstage_provider_info" "example" {}
+
+# Fails the plan while the configuration of the provider still sets deprecated attributes:
+check "backstage_deprecations" {
+  assert {
+    condition     = alltrue([for d in data.backstage_provider_info.example.deprecations : !d.in_use])
+    error_message = "Migrate off: ${join(", ", [for d in data.backstage_provider_info.example.deprecations : d.address if d.in_use])}"
+  }
+}
@@ -0,0 +1,12 @@
+# Retrieves the S3 buckets of a team declared in the catalog:
+data "backstage_resources" "example" {
+  // Type of the resources:
+  type = "s3-bucket"
+  // Owner of the resources, kind and namespace default to "group" and "default":
+  owner = "team-a"
+}
+
+# Outputs the buckets declared in the catalog but not managed by Terraform:
+output "example" {
+  value = setsubtract([for r in data.backstage_resources.example.resources : r.name], keys(aws_s3_bucket.example))
+}
@@ -0,0 +1,15 @@
+# Simulates a run of a template and gets the entities it would register:
+data "backstage_scaffolder_dry_run" "example" {
+  // Entity reference to the template:
+  template_ref = "template:default/react-ssr-template"
+  // Values of the parameters of the template:
+  parameters = jsonencode({
+    component_id = "my-service"
+    owner        = "group:default/team-a"
+  })
+}
+
+# Outputs references to the entities the template would register:
+output "example" {
+  value = data.backstage_scaffolder_dry_run.example.entities[*].ref
+}
@@ -0,0 +1,16 @@
+# Gets a task of the scaffolder started in the Backstage UI:
+data "backstage_scaffolder_task" "example" {
+  id = "2c1b0c8e-3f4a-4c1e-9b1a-7f0e4c2d5a6b"
+
+  lifecycle {
+    postcondition {
+      condition     = self.status == "completed"
+      error_message = "The template has not completed."
+    }
+  }
+}
+
+# Uses the output of the template:
+output "repository_url" {
+  value = jsondecode(data.backstage_scaffolder_task.example.output).remoteUrl
+}
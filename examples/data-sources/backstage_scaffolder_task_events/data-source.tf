@@ -0,0 +1,11 @@
+# Gets the log of a task of the scaffolder:
+data "backstage_scaffolder_task_events" "example" {
+  task_id = backstage_scaffolder_task.example.id
+  # Optionally, only the events of a step of the template:
+  step_id = "publish"
+}
+
+# Surfaces why the task failed:
+output "failures" {
+  value = data.backstage_scaffolder_task_events.example.failures
+}
@@ -0,0 +1,13 @@
+# Retrieves entities starred by the identity the provider is configured with:
+data "backstage_starred_entities" "starred" {}
+
+# Retrieves entities tagged as featured:
+data "backstage_starred_entities" "featured" {
+  // The tag marking featured entities:
+  featured_tag = "featured"
+}
+
+# Outputs references of the featured entities:
+output "featured" {
+  value = data.backstage_starred_entities.featured.entity_refs
+}
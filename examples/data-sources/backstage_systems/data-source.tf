@@ -0,0 +1,14 @@
+# Retrieves the systems of a domain:
+data "backstage_systems" "example" {
+  // Domain of the systems, kind and namespace default to "domain" and "default":
+  domain = "playback"
+  // Owner of the systems, kind and namespace default to "group" and "default":
+  owner = "team-a"
+}
+
+# Instantiates a module per system of the domain:
+module "system" {
+  source   = "./modules/system"
+  for_each = data.backstage_systems.example.refs
+  ref      = each.value
+}
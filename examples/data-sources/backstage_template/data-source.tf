@@ -0,0 +1,17 @@
+# Retrieves a golden-path template:
+data "backstage_template" "example" {
+  // The name of the template:
+  name = "react-ssr-template"
+  // The namespace of the template, defaults to "default":
+  namespace = "default"
+}
+
+# Lists the actions used by the steps of the template:
+output "example_actions" {
+  value = distinct([for s in jsondecode(data.backstage_template.example.spec.steps) : s.action])
+}
+
+# Lists the parameters of the template picking owners:
+output "example_owner_parameters" {
+  value = [for p in data.backstage_template.example.entity_pickers : p.parameter if p.field == "OwnerPicker"]
+}
@@ -0,0 +1,16 @@
+# Retrieves the templates recommended by the platform team:
+data "backstage_templates" "example" {
+  // Owner of the templates, kind and namespace default to "group" and "default":
+  owner = "platform-team"
+  // Tags of the templates, templates having any of them are returned:
+  tags = ["recommended"]
+}
+
+# Lists the titles of the templates with the names of their parameters:
+output "example_templates" {
+  value = {
+    for t in data.backstage_templates.example.templates : coalesce(t.title, t.name) => flatten([
+      for step in try(tolist(jsondecode(t.parameters)), [jsondecode(t.parameters)]) : keys(try(step.properties, {}))
+    ])
+  }
+}
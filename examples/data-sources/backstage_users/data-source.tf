@@ -0,0 +1,17 @@
+# Retrieves the direct members of a team:
+data "backstage_users" "example" {
+  // Group of the users, kind and namespace default to "group" and "default":
+  member_of = "team-a"
+  // Domains of the emails of the users, to leave out bot and service accounts:
+  email_domains = ["example.com"]
+  // Regular expressions the attributes of the profiles of the users must match:
+  profile_matches = { email = "^[a-z]+[.][a-z]+@" }
+}
+
+# Grants access to a project to every member of the team:
+resource "google_project_iam_member" "example" {
+  for_each = { for u in data.backstage_users.example.users : u.ref => u }
+  project  = "team-a"
+  role     = "roles/viewer"
+  member   = "user:${each.value.email}"
+}
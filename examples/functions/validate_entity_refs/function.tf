@@ -0,0 +1,9 @@
+# Validates references to entities passed to a module:
+variable "owners" {
+  type = list(string)
+
+  validation {
+    condition     = provider::backstage::validate_entity_refs(var.owners)
+    error_message = "All owners must be entity references, e.g. group:default/team-a."
+  }
+}
@@ -8,4 +8,10 @@ provider "backstage" {
   headers = {
     "Custom-Header" = "header_value"
   }
+  # Cache responses of the Backstage API for the duration of the run:
+  cache = {
+    type = "memory"
+    # Read the components of the team into the cache up front:
+    warm_filters = ["kind=component,spec.owner=group:default/team-a"]
+  }
 }
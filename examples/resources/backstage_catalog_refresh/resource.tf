@@ -0,0 +1,19 @@
+# Refreshes a component whenever Terraform updates its catalog-info.yaml:
+resource "github_repository_file" "catalog_info" {
+  repository = "artist-web"
+  file       = "catalog-info.yaml"
+  content    = file("${path.module}/catalog-info.yaml")
+}
+
+resource "backstage_catalog_refresh" "example" {
+  entity_ref = "component:default/artist-web"
+  triggers   = { commit = github_repository_file.catalog_info.commit_sha }
+}
+
+# Reads the component once the refresh is processed, i.e. its etag changed:
+data "backstage_component" "example" {
+  name = "artist-web"
+  wait_for = {
+    previous_etag = backstage_catalog_refresh.example.previous_etag
+  }
+}
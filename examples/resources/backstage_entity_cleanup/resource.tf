@@ -0,0 +1,14 @@
+# Deletes the entities left behind by a location, once it is unregistered:
+resource "backstage_entity_cleanup" "example" {
+  // Target URL of the location, all orphaned entities it emitted are deleted:
+  location_target = "https://github.com/org/repo/blob/main/catalog-info.yaml"
+  // Entity references to further entities to delete, if orphaned:
+  entity_refs = ["component:default/artist-web"]
+}
+
+resource "backstage_location" "example" {
+  target = "https://github.com/org/repo/blob/main/catalog-info.yaml"
+
+  // Destroys the cleanup, deleting the orphaned entities, after the location is unregistered:
+  depends_on = [backstage_entity_cleanup.example]
+}
@@ -0,0 +1,20 @@
+# Posts an event to the activity feed of a component on every apply:
+resource "backstage_event" "example" {
+  # Topic allowed for the HTTP ingress of the events backend:
+  topic = "terraform"
+  # Entities the infrastructure belongs to:
+  entity_refs = ["component:default/example-component"]
+  # Description of the change:
+  message = "Infrastructure of example-component applied"
+  # Metadata of the run:
+  metadata = {
+    workspace = terraform.workspace
+  }
+  # Posts the event again on every apply:
+  triggers = {
+    run = timestamp()
+  }
+
+  # Posts the event once the infrastructure is applied:
+  depends_on = [backstage_location.example]
+}
@@ -0,0 +1,5 @@
+# Locations can be imported by their ID:
+terraform import backstage_location.example 4f9e0b4c-8f6d-4f0e-9f2a-3d5c1b2a7e61
+
+# Or by their target URL:
+terraform import backstage_location.example https://github.com/backstage/backstage/blob/master/catalog-info.yaml
@@ -2,4 +2,6 @@
 resource "backstage_location" "example" {
   # URL to the location target:
   target = "http://example-target"
+  # Whether to fail the plan if Backstage can not process the target, defaults to false:
+  dry_run = true
 }
@@ -0,0 +1,19 @@
+# Runs a template with a token that is never stored in the state:
+variable "github_token" {
+  type      = string
+  sensitive = true
+  ephemeral = true
+}
+
+resource "backstage_scaffolder_task" "example" {
+  template_ref = "template:default/react-ssr-template"
+  parameters = jsonencode({
+    component_id = "artist-web"
+    owner        = "group:default/team-a"
+  })
+  secrets = {
+    GITHUB_TOKEN = var.github_token
+  }
+  # Change to run the template again with changed secrets:
+  secrets_version = "1"
+}
@@ -0,0 +1,99 @@
+// Package audit records reads of Backstage entities to a JSON Lines file, providing evidence of what data drove a Terraform run.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+// Environment variables describing the Terraform run the entries are recorded for.
+const (
+	envWorkspace      = "TF_WORKSPACE"
+	envCloudWorkspace = "TFC_WORKSPACE_NAME"
+	envCloudRunID     = "TFC_RUN_ID"
+)
+
+// Entry is a single record of the audit log.
+type Entry struct {
+	// Time is the time of the read. It will default to the current time if zero.
+	Time time.Time `json:"time"`
+
+	// DataSource is the type name of the data source that read the data.
+	DataSource string `json:"data_source"`
+
+	// EntityRef is the reference of the read entity, if a single entity was read.
+	EntityRef string `json:"entity_ref,omitempty"`
+
+	// Filters are the filters of the read entities, if a list of entities was read.
+	Filters []string `json:"filters,omitempty"`
+
+	// Fallback reports whether fallback data was used instead of data from the Backstage API.
+	Fallback bool `json:"fallback"`
+
+	// RequestID is the correlation ID of requests sent to the Backstage API.
+	RequestID string `json:"request_id,omitempty"`
+
+	// Workspace is the name of the Terraform workspace the read was requested by.
+	Workspace string `json:"workspace,omitempty"`
+
+	// RunID is the ID of the Terraform Cloud/Enterprise run the read was requested by.
+	RunID string `json:"run_id,omitempty"`
+}
+
+// Log appends entries to a JSON Lines file. It is safe for concurrent use.
+type Log struct {
+	mu        sync.Mutex
+	path      string
+	workspace string
+	runID     string
+}
+
+// New returns a new Log writing to the file at path. The workspace and run ID of entries are taken from the environment.
+func New(path string) *Log {
+	workspace := os.Getenv(envCloudWorkspace)
+	if workspace == "" {
+		workspace = os.Getenv(envWorkspace)
+	}
+
+	return &Log{path: path, workspace: workspace, runID: os.Getenv(envCloudRunID)}
+}
+
+// Record appends the entry to the audit log. It does nothing if the log is nil.
+func (l *Log) Record(e Entry) error {
+	if l == nil {
+		return nil
+	}
+
+	if e.Time.IsZero() {
+		e.Time = time.Now().UTC()
+	}
+	if e.Workspace == "" {
+		e.Workspace = l.workspace
+	}
+	if e.RunID == "" {
+		e.RunID = l.runID
+	}
+
+	line, err := json.Marshal(e)
+	if err != nil {
+		return fmt.Errorf("unable to encode audit log entry: %w", err)
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	f, err := os.OpenFile(l.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("unable to open audit log: %w", err)
+	}
+	defer f.Close()
+
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("unable to write audit log entry: %w", err)
+	}
+
+	return nil
+}
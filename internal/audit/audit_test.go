@@ -0,0 +1,51 @@
+package audit
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLog_Record(t *testing.T) {
+	t.Setenv(envCloudWorkspace, "")
+	t.Setenv(envWorkspace, "production")
+	t.Setenv(envCloudRunID, "run-123")
+
+	path := filepath.Join(t.TempDir(), "audit.jsonl")
+	l := New(path)
+
+	assert.NoErrorf(t, l.Record(Entry{DataSource: "backstage_component", EntityRef: "component:default/artist-web", RequestID: "id"}),
+		"Record should not return an error")
+	assert.NoErrorf(t, l.Record(Entry{DataSource: "backstage_entities", Filters: []string{"kind=User"}, Fallback: true}),
+		"Record should not return an error")
+
+	f, err := os.Open(path)
+	assert.NoErrorf(t, err, "audit log should be created")
+	defer f.Close()
+
+	var entries []Entry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var e Entry
+		assert.NoErrorf(t, json.Unmarshal(scanner.Bytes(), &e), "audit log line should be valid JSON")
+		entries = append(entries, e)
+	}
+
+	assert.Len(t, entries, 2)
+	assert.Equal(t, "component:default/artist-web", entries[0].EntityRef)
+	assert.Equal(t, "production", entries[0].Workspace)
+	assert.Equal(t, "run-123", entries[0].RunID)
+	assert.False(t, entries[0].Time.IsZero())
+	assert.Equal(t, []string{"kind=User"}, entries[1].Filters)
+	assert.True(t, entries[1].Fallback)
+}
+
+func TestLog_RecordNil(t *testing.T) {
+	var l *Log
+
+	assert.NoErrorf(t, l.Record(Entry{DataSource: "backstage_component"}), "Record should not return an error for nil log")
+}
@@ -0,0 +1,28 @@
+// Package cache provides storage for responses of the Backstage API, so they can be shared between data sources, Terraform runs and runners.
+package cache
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+)
+
+// Cache stores values identified by keys. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value stored for the key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value for the key.
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// Key returns a cache key derived from the provided parts, safe to be used as file name or URL path segment.
+func Key(parts ...string) string {
+	h := sha256.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+		h.Write([]byte{0})
+	}
+
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Disk is a Cache storing values as files in a directory, so they survive between Terraform runs.
+type Disk struct {
+	// Directory is the path of the directory to store values in. It is created if it does not exist.
+	Directory string
+
+	// TTL is the time after which stored values expire, based on modification time of the files. Values never expire if it is zero.
+	TTL time.Duration
+}
+
+// NewDisk returns a new Disk cache storing values in the directory with the provided TTL.
+func NewDisk(directory string, ttl time.Duration) *Disk {
+	return &Disk{Directory: directory, TTL: ttl}
+}
+
+// Get implements the Cache interface.
+func (c *Disk) Get(_ context.Context, key string) ([]byte, bool, error) {
+	p := filepath.Join(c.Directory, key)
+
+	info, err := os.Stat(p)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	if c.TTL > 0 && time.Since(info.ModTime()) > c.TTL {
+		return nil, false, nil
+	}
+
+	value, err := os.ReadFile(p)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+// Set implements the Cache interface. Values are written to a temporary file first and renamed, so concurrent readers never see partial values.
+func (c *Disk) Set(_ context.Context, key string, value []byte) error {
+	if err := os.MkdirAll(c.Directory, 0o700); err != nil {
+		return err
+	}
+
+	f, err := os.CreateTemp(c.Directory, key+".*.tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err = f.Write(value); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	if err = f.Close(); err != nil {
+		_ = os.Remove(f.Name())
+		return err
+	}
+
+	return os.Rename(f.Name(), filepath.Join(c.Directory, key))
+}
@@ -0,0 +1,39 @@
+package cache
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDisk_SetAndGet(t *testing.T) {
+	c := NewDisk(filepath.Join(t.TempDir(), "cache"), 0)
+
+	_, ok, err := c.Get(context.Background(), Key("key"))
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Falsef(t, ok, "Get should not find value that was not set")
+
+	assert.NoErrorf(t, c.Set(context.Background(), Key("key"), []byte("value")), "Set should not return an error")
+
+	value, ok, err := c.Get(context.Background(), Key("key"))
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Truef(t, ok, "Get should find value that was set")
+	assert.Equal(t, "value", string(value))
+}
+
+func TestDisk_Expired(t *testing.T) {
+	c := NewDisk(t.TempDir(), time.Minute)
+
+	assert.NoErrorf(t, c.Set(context.Background(), Key("key"), []byte("value")), "Set should not return an error")
+
+	past := time.Now().Add(-time.Hour)
+	assert.NoError(t, os.Chtimes(filepath.Join(c.Directory, Key("key")), past, past))
+
+	_, ok, err := c.Get(context.Background(), Key("key"))
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Falsef(t, ok, "Get should not find expired value")
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// HTTP is a Cache storing values in a remote key/value store exposing a simple HTTP interface: values are read with `GET {endpoint}/{key}`
+// (404 when not found) and stored with `PUT {endpoint}/{key}`. The TTL is passed in `X-Cache-TTL` header in seconds. It allows sharing the
+// cache between runners, e.g. by fronting Redis with a small HTTP service.
+type HTTP struct {
+	// Endpoint is the base URL of the key/value store.
+	Endpoint string
+
+	// TTL is the time after which stored values should expire. Values never expire if it is zero.
+	TTL time.Duration
+
+	// Client is the HTTP client used to communicate with the store. It will default to http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// NewHTTP returns a new HTTP cache using the store at the endpoint with the provided TTL.
+func NewHTTP(endpoint string, ttl time.Duration) *HTTP {
+	return &HTTP{Endpoint: endpoint, TTL: ttl}
+}
+
+// Get implements the Cache interface.
+func (c *HTTP) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	u, err := url.JoinPath(c.Endpoint, key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, false, err
+	}
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return nil, false, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		value, err := io.ReadAll(resp.Body)
+		return value, err == nil, err
+	case http.StatusNotFound:
+		return nil, false, nil
+	default:
+		return nil, false, fmt.Errorf("unexpected status code from cache: %d", resp.StatusCode)
+	}
+}
+
+// Set implements the Cache interface.
+func (c *HTTP) Set(ctx context.Context, key string, value []byte) error {
+	u, err := url.JoinPath(c.Endpoint, key)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(value))
+	if err != nil {
+		return err
+	}
+
+	if c.TTL > 0 {
+		req.Header.Set("X-Cache-TTL", strconv.Itoa(int(c.TTL.Seconds())))
+	}
+
+	resp, err := c.client().Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code from cache: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// client returns the HTTP client to use. If none is set, http.DefaultClient is used.
+func (c *HTTP) client() *http.Client {
+	if c.Client != nil {
+		return c.Client
+	}
+
+	return http.DefaultClient
+}
@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHTTP_SetAndGet(t *testing.T) {
+	const endpoint = "http://localhost:8080/cache"
+
+	defer gock.Off()
+	gock.New(endpoint).
+		Put("/key").
+		MatchHeader("X-Cache-TTL", "60").
+		BodyString("value").
+		Reply(http.StatusNoContent)
+	gock.New(endpoint).
+		Get("/key").
+		Reply(http.StatusOK).
+		BodyString("value")
+	gock.New(endpoint).
+		Get("/missing").
+		Reply(http.StatusNotFound)
+
+	c := NewHTTP(endpoint, time.Minute)
+
+	assert.NoErrorf(t, c.Set(context.Background(), "key", []byte("value")), "Set should not return an error")
+
+	value, ok, err := c.Get(context.Background(), "key")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Truef(t, ok, "Get should find value that was set")
+	assert.Equal(t, "value", string(value))
+
+	_, ok, err = c.Get(context.Background(), "missing")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Falsef(t, ok, "Get should not find value that was not set")
+	assert.Truef(t, gock.IsDone(), "All requests to the cache should be made")
+}
@@ -0,0 +1,53 @@
+package cache
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// Memory is a Cache storing values in memory of the provider process.
+type Memory struct {
+	// TTL is the time after which stored values expire. Values never expire if it is zero.
+	TTL time.Duration
+
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+}
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+// NewMemory returns a new Memory cache with the provided TTL.
+func NewMemory(ttl time.Duration) *Memory {
+	return &Memory{TTL: ttl, entries: make(map[string]memoryEntry)}
+}
+
+// Get implements the Cache interface.
+func (c *Memory) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+
+	e, ok := c.entries[key]
+	if !ok || (!e.expires.IsZero() && time.Now().After(e.expires)) {
+		return nil, false, nil
+	}
+
+	return e.value, true, nil
+}
+
+// Set implements the Cache interface.
+func (c *Memory) Set(_ context.Context, key string, value []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := memoryEntry{value: value}
+	if c.TTL > 0 {
+		e.expires = time.Now().Add(c.TTL)
+	}
+	c.entries[key] = e
+
+	return nil
+}
@@ -0,0 +1,35 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemory_SetAndGet(t *testing.T) {
+	c := NewMemory(0)
+
+	_, ok, err := c.Get(context.Background(), "key")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Falsef(t, ok, "Get should not find value that was not set")
+
+	assert.NoErrorf(t, c.Set(context.Background(), "key", []byte("value")), "Set should not return an error")
+
+	value, ok, err := c.Get(context.Background(), "key")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Truef(t, ok, "Get should find value that was set")
+	assert.Equal(t, "value", string(value))
+}
+
+func TestMemory_Expired(t *testing.T) {
+	c := NewMemory(time.Millisecond)
+
+	assert.NoErrorf(t, c.Set(context.Background(), "key", []byte("value")), "Set should not return an error")
+	time.Sleep(5 * time.Millisecond)
+
+	_, ok, err := c.Get(context.Background(), "key")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Falsef(t, ok, "Get should not find expired value")
+}
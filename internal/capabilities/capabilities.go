@@ -0,0 +1,69 @@
+// Package capabilities detects which APIs used by the provider are supported by a Backstage instance, as older versions of Backstage lack
+// some of them.
+package capabilities
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+)
+
+// Capabilities describes the APIs supported by a Backstage instance.
+type Capabilities struct {
+	// EntitiesByQuery reports whether the catalog supports querying entities with cursor based pagination (Backstage 1.7+).
+	EntitiesByQuery bool
+
+	// EntitiesByRefs reports whether the catalog supports reading entities in batches by their refs (Backstage 1.1+).
+	EntitiesByRefs bool
+
+	// EntityFacets reports whether the catalog supports reading facets of entities.
+	EntityFacets bool
+
+	// ScaffolderV2 reports whether the scaffolder supports the v2 API, including dry-runs of templates (Backstage 1.4+).
+	ScaffolderV2 bool
+}
+
+// probe is a request used to detect a capability.
+type probe struct {
+	method string
+	path   string
+	body   string
+	set    func(c *Capabilities)
+}
+
+// probes are the requests used to detect capabilities. A capability is supported, unless its probe is answered with 404 Not Found or
+// 405 Method Not Allowed, as other errors (e.g. 401 Unauthorized) still prove the endpoint exists.
+var probes = []probe{
+	{http.MethodGet, "/api/catalog/entities/by-query?limit=1", "", func(c *Capabilities) { c.EntitiesByQuery = true }},
+	{http.MethodPost, "/api/catalog/entities/by-refs", `{"entityRefs":[]}`, func(c *Capabilities) { c.EntitiesByRefs = true }},
+	{http.MethodGet, "/api/catalog/entity-facets?facet=kind", "", func(c *Capabilities) { c.EntityFacets = true }},
+	{http.MethodGet, "/api/scaffolder/v2/actions", "", func(c *Capabilities) { c.ScaffolderV2 = true }},
+}
+
+// Detect probes the Backstage instance at the base URL for its capabilities. Errors of requests are returned, as they do not tell whether
+// the capability is supported.
+func Detect(ctx context.Context, client *http.Client, baseURL string) (*Capabilities, error) {
+	c := &Capabilities{}
+	for _, p := range probes {
+		req, err := http.NewRequestWithContext(ctx, p.method, strings.TrimSuffix(baseURL, "/")+p.path, bytes.NewBufferString(p.body))
+		if err != nil {
+			return nil, err
+		}
+		if p.body != "" {
+			req.Header.Set("Content-Type", "application/json")
+		}
+
+		resp, err := client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+		resp.Body.Close()
+
+		if resp.StatusCode != http.StatusNotFound && resp.StatusCode != http.StatusMethodNotAllowed {
+			p.set(c)
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,72 @@
+package capabilities
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+// fixture describes the responses of a version of Backstage to the probes and the capabilities expected to be detected from them.
+type fixture struct {
+	Version      string          `json:"version"`
+	Responses    map[string]int  `json:"responses"`
+	Capabilities map[string]bool `json:"capabilities"`
+}
+
+func TestDetect(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	files, err := filepath.Glob("testdata/backstage-*.json")
+	assert.NoErrorf(t, err, "fixtures should be listed")
+	assert.NotEmptyf(t, files, "fixtures should exist")
+
+	for _, f := range files {
+		b, err := os.ReadFile(f)
+		assert.NoErrorf(t, err, "fixture %s should be read", f)
+
+		var fx fixture
+		assert.NoErrorf(t, json.Unmarshal(b, &fx), "fixture %s should be valid JSON", f)
+
+		t.Run(fx.Version, func(t *testing.T) {
+			defer gock.Off()
+			for request, status := range fx.Responses {
+				method, target, _ := strings.Cut(request, " ")
+				u, err := url.Parse(target)
+				assert.NoErrorf(t, err, "fixture request %s should be valid", request)
+
+				mock := gock.New(baseURL)
+				if method == http.MethodPost {
+					mock.Post(u.Path).Reply(status)
+				} else {
+					mock.Get(u.Path).Reply(status)
+				}
+			}
+
+			c, err := Detect(context.Background(), &http.Client{}, baseURL)
+			assert.NoErrorf(t, err, "Detect should not return an error")
+			assert.Equal(t, fx.Capabilities, map[string]bool{
+				"EntitiesByQuery": c.EntitiesByQuery,
+				"EntitiesByRefs":  c.EntitiesByRefs,
+				"EntityFacets":    c.EntityFacets,
+				"ScaffolderV2":    c.ScaffolderV2,
+			})
+			assert.Truef(t, gock.IsDone(), "all probes should be sent")
+		})
+	}
+}
+
+func TestDetect_Error(t *testing.T) {
+	defer gock.Off()
+	gock.New("http://localhost:7007").Get("/api/catalog/entities/by-query").ReplyError(assert.AnError)
+
+	_, err := Detect(context.Background(), &http.Client{}, "http://localhost:7007")
+	assert.Errorf(t, err, "Detect should return an error of the request")
+}
@@ -0,0 +1,44 @@
+// Package export writes data read from Backstage to local files, so large exports do not have to be held in the Terraform state.
+package export
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+)
+
+// WriteNDJSON writes the items to the file at path as newline delimited JSON, one item per line, replacing the file if it exists. The items
+// are written to a temporary file next to it first, so readers never see a partially written file. It returns the absolute path of the file.
+func WriteNDJSON[T any](path string, items []T) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(abs), "."+filepath.Base(abs)+".*")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	w := bufio.NewWriter(tmp)
+	enc := json.NewEncoder(w)
+	for i, item := range items {
+		if err := enc.Encode(item); err != nil {
+			tmp.Close()
+			return "", fmt.Errorf("could not write item %d: %w", i, err)
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+
+	return abs, os.Rename(tmp.Name(), abs)
+}
@@ -0,0 +1,47 @@
+package export
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testItem struct {
+	Name string `json:"name"`
+}
+
+func TestWriteNDJSON(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "entities.ndjson")
+	assert.NoError(t, os.WriteFile(path, []byte("stale\n"), 0o600))
+
+	written, err := WriteNDJSON(path, []testItem{{Name: "artist-web"}, {Name: "artist-lookup"}})
+	assert.NoError(t, err)
+	assert.Equal(t, path, written)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, "{\"name\":\"artist-web\"}\n{\"name\":\"artist-lookup\"}\n", string(b))
+
+	entries, err := os.ReadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, entries, 1, "temporary file is removed")
+}
+
+func TestWriteNDJSON_Empty(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "entities.ndjson")
+
+	_, err := WriteNDJSON(path, []testItem{})
+	assert.NoError(t, err)
+
+	b, err := os.ReadFile(path)
+	assert.NoError(t, err)
+	assert.Empty(t, b)
+}
+
+func TestWriteNDJSON_MissingDirectory(t *testing.T) {
+	_, err := WriteNDJSON(filepath.Join(t.TempDir(), "missing", "entities.ndjson"), []testItem{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,55 @@
+// Package metrics provides recorders emitting metrics of provider operations to monitoring systems.
+package metrics
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Recorder records metrics of provider operations. Implementations must be safe for concurrent use.
+type Recorder interface {
+	// Count increments the counter identified by the name and tags by the value.
+	Count(name string, value int64, tags map[string]string)
+
+	// Timing records the duration of an operation identified by the name and tags.
+	Timing(name string, d time.Duration, tags map[string]string)
+}
+
+// Closer is implemented by recorders holding back metrics, which must be closed to emit them before the provider exits.
+type Closer interface {
+	// Close stops the recorder and emits the metrics it holds back.
+	Close(ctx context.Context) error
+}
+
+// Noop is a Recorder that discards all metrics.
+type Noop struct{}
+
+// Count implements the Recorder interface.
+func (Noop) Count(string, int64, map[string]string) {}
+
+// Timing implements the Recorder interface.
+func (Noop) Timing(string, time.Duration, map[string]string) {}
+
+// sortedKeys returns keys of the tags in a stable order.
+func sortedKeys(tags map[string]string) []string {
+	keys := make([]string, 0, len(tags))
+	for k := range tags {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// seriesKey returns a key identifying the series of the metric with the tags.
+func seriesKey(name string, tags map[string]string) string {
+	var b strings.Builder
+	b.WriteString(name)
+	for _, k := range sortedKeys(tags) {
+		b.WriteString("\x00" + k + "=" + tags[k])
+	}
+
+	return b.String()
+}
@@ -0,0 +1,223 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Pushgateway is a Recorder aggregating metrics in memory and pushing them to a Prometheus Pushgateway. Metrics are pushed in the background
+// at most once per interval, whenever they changed, and a last time when the recorder is closed.
+type Pushgateway struct {
+	// Endpoint is the base URL of the Pushgateway.
+	Endpoint string
+
+	// Job is the name of the job the metrics are grouped by.
+	Job string
+
+	// Prefix is prepended to names of all metrics, separated by an underscore.
+	Prefix string
+
+	// Client is the HTTP client used to push metrics. It will default to http.DefaultClient if nil.
+	Client *http.Client
+
+	mu       sync.Mutex
+	counters map[string]*series
+	timings  map[string]*series
+	dirty    bool
+
+	ticker    *time.Ticker
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+type series struct {
+	name  string
+	tags  map[string]string
+	value float64
+	count int64
+}
+
+// NewPushgateway returns a new Pushgateway recorder pushing metrics of the job to the endpoint every interval, until it is closed.
+func NewPushgateway(endpoint string, job string, prefix string, interval time.Duration) *Pushgateway {
+	p := &Pushgateway{
+		Endpoint: endpoint,
+		Job:      job,
+		Prefix:   prefix,
+		counters: make(map[string]*series),
+		timings:  make(map[string]*series),
+		ticker:   time.NewTicker(interval),
+		done:     make(chan struct{}),
+	}
+
+	go func() {
+		for {
+			select {
+			case <-p.ticker.C:
+				_ = p.Push(context.Background())
+			case <-p.done:
+				return
+			}
+		}
+	}()
+
+	return p
+}
+
+// Close implements the Closer interface. It stops pushing metrics in the background and pushes the metrics recorded since the last push.
+func (p *Pushgateway) Close(ctx context.Context) error {
+	p.closeOnce.Do(func() {
+		if p.ticker != nil {
+			p.ticker.Stop()
+			close(p.done)
+		}
+	})
+
+	return p.Push(ctx)
+}
+
+// Count implements the Recorder interface.
+func (p *Pushgateway) Count(name string, value int64, tags map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.series(p.counters, name, tags).value += float64(value)
+	p.dirty = true
+}
+
+// Timing implements the Recorder interface.
+func (p *Pushgateway) Timing(name string, d time.Duration, tags map[string]string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	s := p.series(p.timings, name, tags)
+	s.value += d.Seconds()
+	s.count++
+	p.dirty = true
+}
+
+// Push sends the current state of all metrics to the Pushgateway, if anything changed since the last push.
+func (p *Pushgateway) Push(ctx context.Context) error {
+	p.mu.Lock()
+	if !p.dirty {
+		p.mu.Unlock()
+		return nil
+	}
+	body := p.exposition()
+	p.dirty = false
+	p.mu.Unlock()
+
+	u, err := url.JoinPath(p.Endpoint, "metrics", "job", p.Job)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "text/plain; version=0.0.4")
+
+	client := p.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("unexpected status code from pushgateway: %d", resp.StatusCode)
+	}
+
+	return nil
+}
+
+// series returns the series of the metric with the tags, creating it if needed. The caller must hold the lock.
+func (p *Pushgateway) series(m map[string]*series, name string, tags map[string]string) *series {
+	key := seriesKey(name, tags)
+	s, ok := m[key]
+	if !ok {
+		s = &series{name: name, tags: tags}
+		m[key] = s
+	}
+
+	return s
+}
+
+// exposition renders all metrics in Prometheus text exposition format. The caller must hold the lock.
+func (p *Pushgateway) exposition() []byte {
+	var b bytes.Buffer
+
+	for _, group := range groupByName(p.counters) {
+		name := p.metricName(group[0].name)
+		fmt.Fprintf(&b, "# TYPE %s counter\n", name)
+		for _, s := range group {
+			fmt.Fprintf(&b, "%s%s %g\n", name, labels(s.tags), s.value)
+		}
+	}
+
+	for _, group := range groupByName(p.timings) {
+		name := p.metricName(group[0].name + "_seconds")
+		fmt.Fprintf(&b, "# TYPE %s summary\n", name)
+		for _, s := range group {
+			fmt.Fprintf(&b, "%s_sum%s %g\n", name, labels(s.tags), s.value)
+			fmt.Fprintf(&b, "%s_count%s %d\n", name, labels(s.tags), s.count)
+		}
+	}
+
+	return b.Bytes()
+}
+
+// metricName returns the name of the metric with the prefix.
+func (p *Pushgateway) metricName(name string) string {
+	if p.Prefix == "" {
+		return name
+	}
+
+	return p.Prefix + "_" + name
+}
+
+// groupByName returns the series grouped by metric names, in a stable order.
+func groupByName(m map[string]*series) [][]*series {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var groups [][]*series
+	for _, k := range keys {
+		s := m[k]
+		if len(groups) > 0 && groups[len(groups)-1][0].name == s.name {
+			groups[len(groups)-1] = append(groups[len(groups)-1], s)
+			continue
+		}
+		groups = append(groups, []*series{s})
+	}
+
+	return groups
+}
+
+// labels renders the tags as Prometheus labels.
+func labels(tags map[string]string) string {
+	if len(tags) == 0 {
+		return ""
+	}
+
+	pairs := make([]string, 0, len(tags))
+	for _, k := range sortedKeys(tags) {
+		pairs = append(pairs, fmt.Sprintf("%s=%q", k, tags[k]))
+	}
+
+	return "{" + strings.Join(pairs, ",") + "}"
+}
@@ -0,0 +1,69 @@
+package metrics
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPushgateway_Push(t *testing.T) {
+	const endpoint = "http://localhost:9091"
+
+	defer gock.Off()
+	gock.New(endpoint).
+		Put("/metrics/job/terraform").
+		BodyString(`# TYPE backstage_requests_total counter
+backstage_requests_total{method="GET",status="200"} 2
+# TYPE backstage_request_duration_seconds summary
+backstage_request_duration_seconds_sum{method="GET"} 1.5
+backstage_request_duration_seconds_count{method="GET"} 2
+`).
+		Reply(http.StatusOK)
+
+	p := NewPushgateway(endpoint, "terraform", "backstage", time.Hour)
+	p.Count("requests_total", 1, map[string]string{"method": "GET", "status": "200"})
+	p.Count("requests_total", 1, map[string]string{"method": "GET", "status": "200"})
+	p.Timing("request_duration", time.Second, map[string]string{"method": "GET"})
+	p.Timing("request_duration", 500*time.Millisecond, map[string]string{"method": "GET"})
+
+	assert.NoErrorf(t, p.Push(context.Background()), "Push should not return an error")
+	assert.NoErrorf(t, p.Push(context.Background()), "Push should not push unchanged metrics")
+	assert.Truef(t, gock.IsDone(), "Metrics should be pushed once")
+}
+
+func TestPushgateway_Background(t *testing.T) {
+	const endpoint = "http://localhost:9091"
+
+	defer gock.Off()
+	gock.New(endpoint).
+		Put("/metrics/job/terraform").
+		BodyString("# TYPE backstage_requests_total counter\nbackstage_requests_total 1\n").
+		Reply(http.StatusOK)
+
+	p := NewPushgateway(endpoint, "terraform", "backstage", 10*time.Millisecond)
+	defer func() { _ = p.Close(context.Background()) }()
+	p.Count("requests_total", 1, nil)
+
+	assert.Eventuallyf(t, gock.IsDone, time.Second, 10*time.Millisecond, "Metrics should be pushed in the background")
+}
+
+func TestPushgateway_Close(t *testing.T) {
+	const endpoint = "http://localhost:9091"
+
+	defer gock.Off()
+	gock.New(endpoint).
+		Put("/metrics/job/terraform").
+		BodyString("# TYPE backstage_requests_total counter\nbackstage_requests_total 1\n").
+		Reply(http.StatusOK)
+
+	p := NewPushgateway(endpoint, "terraform", "backstage", time.Hour)
+	p.Count("requests_total", 1, nil)
+
+	assert.NoErrorf(t, p.Close(context.Background()), "Close should not return an error")
+	assert.Truef(t, gock.IsDone(), "Metrics should be pushed on close")
+	assert.NoErrorf(t, p.Close(context.Background()), "Close should be safe to call again")
+}
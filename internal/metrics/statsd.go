@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// Statsd is a Recorder sending metrics to a StatsD daemon over UDP. Tags are sent using DogStatsD format.
+type Statsd struct {
+	// Prefix is prepended to names of all metrics, separated by a dot.
+	Prefix string
+
+	conn net.Conn
+}
+
+// NewStatsd returns a new Statsd recorder sending metrics to the address (`host:port`) of a StatsD daemon.
+func NewStatsd(address string, prefix string) (*Statsd, error) {
+	conn, err := net.Dial("udp", address)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Statsd{Prefix: prefix, conn: conn}, nil
+}
+
+// Count implements the Recorder interface.
+func (s *Statsd) Count(name string, value int64, tags map[string]string) {
+	s.send(name, fmt.Sprintf("%d|c", value), tags)
+}
+
+// Timing implements the Recorder interface.
+func (s *Statsd) Timing(name string, d time.Duration, tags map[string]string) {
+	s.send(name, fmt.Sprintf("%d|ms", d.Milliseconds()), tags)
+}
+
+// send writes a single metric to the daemon. Errors are ignored, as metrics must never affect provider operations.
+func (s *Statsd) send(name string, value string, tags map[string]string) {
+	if s.Prefix != "" {
+		name = s.Prefix + "." + name
+	}
+
+	line := name + ":" + value
+	if len(tags) > 0 {
+		pairs := make([]string, 0, len(tags))
+		for _, k := range sortedKeys(tags) {
+			pairs = append(pairs, k+":"+tags[k])
+		}
+		line += "|#" + strings.Join(pairs, ",")
+	}
+
+	_, _ = s.conn.Write([]byte(line))
+}
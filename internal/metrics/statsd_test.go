@@ -0,0 +1,26 @@
+package metrics
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsd_Count(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	assert.NoErrorf(t, err, "ListenPacket should not return an error")
+	defer func() { _ = conn.Close() }()
+
+	s, err := NewStatsd(conn.LocalAddr().String(), "backstage")
+	assert.NoErrorf(t, err, "NewStatsd should not return an error")
+
+	s.Count("requests_total", 1, map[string]string{"status": "200", "method": "GET"})
+
+	buf := make([]byte, 1024)
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	assert.NoErrorf(t, err, "ReadFrom should not return an error")
+	assert.Equal(t, "backstage.requests_total:1|c|#method:GET,status:200", string(buf[:n]))
+}
@@ -0,0 +1,36 @@
+package scaffolder
+
+import "sort"
+
+// Action is an action installed in the scaffolder, as listed by the actions endpoint of the scaffolder v2 API.
+type Action struct {
+	ID          string `json:"id"`
+	Description string `json:"description"`
+}
+
+// UnavailableActions returns the actions used by the steps of a template that are not installed in the scaffolder, sorted and unique. Steps
+// without an action are ignored.
+func UnavailableActions(steps interface{}, installed []Action) []string {
+	ids := map[string]bool{}
+	for _, a := range installed {
+		ids[a.ID] = true
+	}
+
+	list, _ := steps.([]interface{})
+	unavailable := map[string]bool{}
+	for _, s := range list {
+		step, _ := s.(map[string]interface{})
+		action, _ := step["action"].(string)
+		if action != "" && !ids[action] {
+			unavailable[action] = true
+		}
+	}
+
+	actions := make([]string, 0, len(unavailable))
+	for a := range unavailable {
+		actions = append(actions, a)
+	}
+	sort.Strings(actions)
+
+	return actions
+}
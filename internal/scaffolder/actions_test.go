@@ -0,0 +1,33 @@
+package scaffolder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTemplateSteps = `[
+  {"id": "fetch", "action": "fetch:template"},
+  {"id": "publish", "action": "publish:gitlab"},
+  {"id": "publish-again", "action": "publish:gitlab"},
+  {"id": "register", "action": "catalog:register"},
+  {"id": "custom", "action": "acme:deploy"},
+  {"id": "noop"}
+]`
+
+func TestUnavailableActions(t *testing.T) {
+	var steps interface{}
+	assert.NoError(t, json.Unmarshal([]byte(testTemplateSteps), &steps))
+
+	installed := []Action{{ID: "fetch:template"}, {ID: "catalog:register"}, {ID: "publish:github"}}
+	assert.Equal(t, []string{"acme:deploy", "publish:gitlab"}, UnavailableActions(steps, installed))
+}
+
+func TestUnavailableActions_AllInstalled(t *testing.T) {
+	var steps interface{}
+	assert.NoError(t, json.Unmarshal([]byte(`[{"action": "fetch:template"}]`), &steps))
+
+	assert.Empty(t, UnavailableActions(steps, []Action{{ID: "fetch:template"}}))
+	assert.Empty(t, UnavailableActions(nil, nil))
+}
@@ -0,0 +1,158 @@
+package scaffolder
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ActionCatalogRegister is the ID of the scaffolder action registering entities in the catalog.
+const ActionCatalogRegister = "catalog:register"
+
+// defaultCatalogInfoPath is the path of the descriptor file registered by the catalog:register action, if it does not set catalogInfoPath.
+const defaultCatalogInfoPath = "catalog-info.yaml"
+
+// DryRunResult is the response of the dry-run endpoint of the scaffolder v2 API.
+type DryRunResult struct {
+	Log []struct {
+		Body struct {
+			Message string `json:"message"`
+			StepID  string `json:"stepId"`
+			Status  string `json:"status"`
+		} `json:"body"`
+	} `json:"log"`
+	DirectoryContents []DryRunFile           `json:"directoryContents"`
+	Output            map[string]interface{} `json:"output"`
+	Steps             []DryRunStep           `json:"steps"`
+}
+
+// DryRunFile is a file of the workspace produced by a dry-run of a template.
+type DryRunFile struct {
+	Path          string `json:"path"`
+	Base64Content string `json:"base64Content"`
+	Executable    bool   `json:"executable"`
+}
+
+// DryRunStep is a step of a template executed by a dry-run.
+type DryRunStep struct {
+	ID     string                 `json:"id"`
+	Name   string                 `json:"name"`
+	Action string                 `json:"action"`
+	Input  map[string]interface{} `json:"input"`
+}
+
+// Entity identifies an entity a template would register in the catalog.
+type Entity struct {
+	// Ref is the entity reference to the entity, with lower case kind.
+	Ref string
+
+	// Kind is the kind of the entity as written in its descriptor.
+	Kind string
+
+	// Namespace is the namespace of the entity, `default` if not set in its descriptor.
+	Namespace string
+
+	// Name is the name of the entity.
+	Name string
+
+	// Path is the path of the descriptor file of the entity in the workspace.
+	Path string
+}
+
+// RegisteredEntities returns the entities the template would register, read from the descriptor files of the workspace that catalog:register
+// steps point to. If the template has no such steps, descriptor files named catalog-info.yaml anywhere in the workspace are read. Entities are
+// sorted by their references.
+func RegisteredEntities(result *DryRunResult) ([]Entity, error) {
+	paths := map[string]bool{}
+	for _, s := range result.Steps {
+		if s.Action != ActionCatalogRegister {
+			continue
+		}
+
+		p, _ := s.Input["catalogInfoPath"].(string)
+		if p == "" {
+			// Steps registering an existing repository by URL do not add any descriptor of the workspace.
+			if _, ok := s.Input["catalogInfoUrl"]; ok {
+				continue
+			}
+			p = defaultCatalogInfoPath
+		}
+		paths[cleanPath(p)] = true
+	}
+
+	var entities []Entity
+	for _, f := range result.DirectoryContents {
+		p := cleanPath(f.Path)
+		if len(paths) > 0 && !paths[p] {
+			continue
+		}
+		if len(paths) == 0 && path.Base(p) != defaultCatalogInfoPath {
+			continue
+		}
+
+		content, err := base64.StdEncoding.DecodeString(f.Base64Content)
+		if err != nil {
+			return nil, fmt.Errorf("unable to decode %s: %w", p, err)
+		}
+
+		e, err := parseDescriptors(content, p)
+		if err != nil {
+			return nil, err
+		}
+		entities = append(entities, e...)
+	}
+
+	sort.Slice(entities, func(i, j int) bool { return entities[i].Ref < entities[j].Ref })
+
+	return entities, nil
+}
+
+// parseDescriptors parses the entities of a YAML descriptor file, which may hold multiple documents.
+func parseDescriptors(content []byte, filePath string) ([]Entity, error) {
+	var entities []Entity
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	for {
+		var descriptor struct {
+			Kind     string `yaml:"kind"`
+			Metadata struct {
+				Name      string `yaml:"name"`
+				Namespace string `yaml:"namespace"`
+			} `yaml:"metadata"`
+		}
+		if err := decoder.Decode(&descriptor); err != nil {
+			if errors.Is(err, io.EOF) {
+				return entities, nil
+			}
+			return nil, fmt.Errorf("unable to parse %s: %w", filePath, err)
+		}
+
+		if descriptor.Kind == "" || descriptor.Metadata.Name == "" {
+			continue
+		}
+
+		namespace := descriptor.Metadata.Namespace
+		if namespace == "" {
+			namespace = "default"
+		}
+
+		entities = append(entities, Entity{
+			Ref:       fmt.Sprintf("%s:%s/%s", strings.ToLower(descriptor.Kind), namespace, descriptor.Metadata.Name),
+			Kind:      descriptor.Kind,
+			Namespace: namespace,
+			Name:      descriptor.Metadata.Name,
+			Path:      filePath,
+		})
+	}
+}
+
+// cleanPath normalizes a path of the workspace to a relative path without leading `./` or `/`.
+func cleanPath(p string) string {
+	return strings.TrimPrefix(path.Clean("/"+p), "/")
+}
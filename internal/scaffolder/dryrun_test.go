@@ -0,0 +1,64 @@
+package scaffolder
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisteredEntities(t *testing.T) {
+	encode := func(s string) string { return base64.StdEncoding.EncodeToString([]byte(s)) }
+
+	files := []DryRunFile{
+		{Path: "catalog-info.yaml", Base64Content: encode("apiVersion: backstage.io/v1alpha1\nkind: Component\nmetadata:\n  name: svc\n")},
+		{Path: "./infra/catalog-info.yaml", Base64Content: encode("kind: Resource\nmetadata:\n  name: db\n  namespace: data\n---\n" +
+			"kind: System\nmetadata:\n  name: sys\n")},
+		{Path: "README.md", Base64Content: encode("# svc")},
+	}
+
+	tests := map[string]struct {
+		steps    []DryRunStep
+		expected []string
+	}{
+		"without register steps": {
+			expected: []string{"component:default/svc", "resource:data/db", "system:default/sys"},
+		},
+		"with default path": {
+			steps:    []DryRunStep{{ID: "register", Action: ActionCatalogRegister, Input: map[string]interface{}{"repoContentsUrl": "x"}}},
+			expected: []string{"component:default/svc"},
+		},
+		"with custom path": {
+			steps: []DryRunStep{{ID: "register", Action: ActionCatalogRegister, Input: map[string]interface{}{
+				"catalogInfoPath": "/infra/catalog-info.yaml",
+			}}},
+			expected: []string{"resource:data/db", "system:default/sys"},
+		},
+		"with URL": {
+			steps: []DryRunStep{{ID: "register", Action: ActionCatalogRegister, Input: map[string]interface{}{
+				"catalogInfoUrl": "https://github.com/org/repo/blob/main/catalog-info.yaml",
+			}}},
+			expected: []string{"component:default/svc", "resource:data/db", "system:default/sys"},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			entities, err := RegisteredEntities(&DryRunResult{DirectoryContents: files, Steps: test.steps})
+			assert.NoError(t, err)
+
+			var refs []string
+			for _, e := range entities {
+				refs = append(refs, e.Ref)
+			}
+			assert.Equal(t, test.expected, refs)
+		})
+	}
+}
+
+func TestRegisteredEntities_InvalidDescriptor(t *testing.T) {
+	_, err := RegisteredEntities(&DryRunResult{DirectoryContents: []DryRunFile{
+		{Path: "catalog-info.yaml", Base64Content: base64.StdEncoding.EncodeToString([]byte("kind: [Component"))},
+	}})
+	assert.ErrorContains(t, err, "unable to parse catalog-info.yaml")
+}
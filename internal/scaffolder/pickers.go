@@ -0,0 +1,197 @@
+// Package scaffolder contains helpers for working with Backstage Software Templates.
+package scaffolder
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Names of the form fields of Backstage Software Templates that pick entities from the catalog.
+const (
+	FieldEntityPicker      = "EntityPicker"
+	FieldMultiEntityPicker = "MultiEntityPicker"
+	FieldOwnerPicker       = "OwnerPicker"
+	FieldOwnedEntityPicker = "OwnedEntityPicker"
+)
+
+// PickerConstraint describes the constraints a template parameter picking entities puts on the entity refs supplied for it.
+type PickerConstraint struct {
+	// Parameter is the name of the template parameter.
+	Parameter string
+
+	// Step is the index of the step of the template form the parameter belongs to.
+	Step int
+
+	// Field is the name of the form field used for the parameter, e.g. EntityPicker.
+	Field string
+
+	// Required reports whether the parameter is required by the step.
+	Required bool
+
+	// AllowedKinds are the kinds of entities that can be picked.
+	AllowedKinds []string
+
+	// DefaultKind is the kind assumed for refs without a kind.
+	DefaultKind string
+
+	// DefaultNamespace is the namespace assumed for refs without a namespace.
+	DefaultNamespace string
+
+	// AllowArbitraryValues reports whether values not matching any entity are accepted.
+	AllowArbitraryValues bool
+
+	// CatalogFilters are the filters of the catalog the picked entity must match, in the format of the Backstage catalog API filter query
+	// parameter. An entity must match at least one of them. Allowed kinds are included in the filters.
+	CatalogFilters []string
+}
+
+// ParsePickerConstraints extracts constraints of the parameters picking entities from the parameters of a template. Parameters may be a single
+// JSON schema object or a list of them, one per step of the template form.
+func ParsePickerConstraints(parameters interface{}) []PickerConstraint {
+	var steps []interface{}
+	switch p := parameters.(type) {
+	case []interface{}:
+		steps = p
+	case map[string]interface{}:
+		steps = []interface{}{p}
+	default:
+		return nil
+	}
+
+	var constraints []PickerConstraint
+	for i, s := range steps {
+		step, ok := s.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		properties, _ := step["properties"].(map[string]interface{})
+		required := map[string]bool{}
+		for _, r := range asStrings(step["required"]) {
+			required[r] = true
+		}
+
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+
+		for _, name := range names {
+			property, ok := properties[name].(map[string]interface{})
+			if !ok {
+				continue
+			}
+
+			field, _ := property["ui:field"].(string)
+			if !isPickerField(field) {
+				continue
+			}
+
+			constraints = append(constraints, parsePickerConstraint(name, i, field, required[name], property))
+		}
+	}
+
+	return constraints
+}
+
+// parsePickerConstraint extracts the constraint of a single parameter picking entities from its JSON schema.
+func parsePickerConstraint(name string, step int, field string, required bool, property map[string]interface{}) PickerConstraint {
+	constraint := PickerConstraint{
+		Parameter: name,
+		Step:      step,
+		Field:     field,
+		Required:  required,
+	}
+
+	options, _ := property["ui:options"].(map[string]interface{})
+	constraint.AllowedKinds = asStrings(options["allowedKinds"])
+	constraint.DefaultKind, _ = options["defaultKind"].(string)
+	constraint.DefaultNamespace, _ = options["defaultNamespace"].(string)
+	constraint.AllowArbitraryValues, _ = options["allowArbitraryValues"].(bool)
+
+	if field == FieldOwnerPicker && len(constraint.AllowedKinds) == 0 {
+		constraint.AllowedKinds = []string{"Group", "User"}
+	}
+
+	var filters []interface{}
+	switch f := options["catalogFilter"].(type) {
+	case []interface{}:
+		filters = f
+	case map[string]interface{}:
+		filters = []interface{}{f}
+	}
+
+	for _, f := range filters {
+		if filter, ok := f.(map[string]interface{}); ok {
+			constraint.CatalogFilters = append(constraint.CatalogFilters, filterQuery(filter, constraint.AllowedKinds))
+		}
+	}
+
+	if len(constraint.CatalogFilters) == 0 && len(constraint.AllowedKinds) > 0 {
+		constraint.CatalogFilters = []string{filterQuery(nil, constraint.AllowedKinds)}
+	}
+
+	return constraint
+}
+
+// filterQuery converts the catalog filter of a picker to the format of the Backstage catalog API filter query parameter. Conditions on the same
+// key match any of its values, keys with `exists` condition match any entity having the key.
+func filterQuery(filter map[string]interface{}, allowedKinds []string) string {
+	var conditions []string
+	if _, ok := filter["kind"]; !ok {
+		for _, k := range allowedKinds {
+			conditions = append(conditions, "kind="+k)
+		}
+	}
+
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	for _, k := range keys {
+		if exists, ok := filter[k].(map[string]interface{}); ok {
+			if v, _ := exists["exists"].(bool); v {
+				conditions = append(conditions, k)
+			}
+			continue
+		}
+
+		for _, v := range asStrings(filter[k]) {
+			conditions = append(conditions, fmt.Sprintf("%s=%s", k, v))
+		}
+	}
+
+	return strings.Join(conditions, ",")
+}
+
+// isPickerField reports whether the form field picks entities from the catalog.
+func isPickerField(field string) bool {
+	switch field {
+	case FieldEntityPicker, FieldMultiEntityPicker, FieldOwnerPicker, FieldOwnedEntityPicker:
+		return true
+	default:
+		return false
+	}
+}
+
+// asStrings converts a single value or a list of values of JSON to a list of strings.
+func asStrings(v interface{}) []string {
+	switch s := v.(type) {
+	case string:
+		return []string{s}
+	case bool, float64:
+		return []string{fmt.Sprint(s)}
+	case []interface{}:
+		var values []string
+		for _, i := range s {
+			values = append(values, asStrings(i)...)
+		}
+		return values
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,88 @@
+package scaffolder
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const testTemplateParameters = `[
+  {
+    "title": "Provide some simple information",
+    "required": ["component_id", "owner"],
+    "properties": {
+      "component_id": {"title": "Name", "type": "string", "ui:field": "EntityNamePicker"},
+      "owner": {
+        "title": "Owner",
+        "type": "string",
+        "ui:field": "OwnerPicker",
+        "ui:options": {"catalogFilter": {"kind": "Group", "spec.type": ["team", "business-unit"]}}
+      }
+    }
+  },
+  {
+    "properties": {
+      "system": {
+        "type": "string",
+        "ui:field": "EntityPicker",
+        "ui:options": {
+          "allowedKinds": ["System"],
+          "defaultNamespace": "default",
+          "catalogFilter": [{"metadata.annotations.backstage.io/techdocs-ref": {"exists": true}}, {"spec.lifecycle": "production"}]
+        }
+      },
+      "reviewers": {"type": "array", "ui:field": "MultiEntityPicker"}
+    }
+  }
+]`
+
+func TestParsePickerConstraints(t *testing.T) {
+	var parameters interface{}
+	assert.NoErrorf(t, json.Unmarshal([]byte(testTemplateParameters), &parameters), "test parameters should be valid JSON")
+
+	constraints := ParsePickerConstraints(parameters)
+
+	assert.Equal(t, []PickerConstraint{
+		{
+			Parameter:      "owner",
+			Step:           0,
+			Field:          FieldOwnerPicker,
+			Required:       true,
+			AllowedKinds:   []string{"Group", "User"},
+			CatalogFilters: []string{"kind=Group,spec.type=team,spec.type=business-unit"},
+		},
+		{
+			Parameter: "reviewers",
+			Step:      1,
+			Field:     FieldMultiEntityPicker,
+		},
+		{
+			Parameter:        "system",
+			Step:             1,
+			Field:            FieldEntityPicker,
+			AllowedKinds:     []string{"System"},
+			DefaultNamespace: "default",
+			CatalogFilters: []string{
+				"kind=System,metadata.annotations.backstage.io/techdocs-ref",
+				"kind=System,spec.lifecycle=production",
+			},
+		},
+	}, constraints)
+}
+
+func TestParsePickerConstraints_SingleStep(t *testing.T) {
+	var parameters interface{}
+	assert.NoErrorf(t, json.Unmarshal([]byte(`{"properties": {"api": {"ui:field": "EntityPicker", "ui:options": {"allowedKinds": ["API"]}}}}`),
+		&parameters), "test parameters should be valid JSON")
+
+	constraints := ParsePickerConstraints(parameters)
+
+	assert.Len(t, constraints, 1)
+	assert.Equal(t, []string{"kind=API"}, constraints[0].CatalogFilters)
+}
+
+func TestParsePickerConstraints_Invalid(t *testing.T) {
+	assert.Nil(t, ParsePickerConstraints("invalid"))
+	assert.Nil(t, ParsePickerConstraints(nil))
+}
@@ -0,0 +1,130 @@
+// Package selector parses Kubernetes style label selectors (https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/),
+// both equality based, e.g. `env=production,tier!=frontend`, and set based, e.g. `env in (staging,production),!canary`.
+package selector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Operator is the operator of a requirement of a label selector.
+type Operator string
+
+const (
+	// In requires the label to have one of the values. Equality requirements have a single value.
+	In Operator = "in"
+	// NotIn requires the label to have none of the values, or not to be set. Inequality requirements have a single value.
+	NotIn Operator = "notin"
+	// Exists requires the label to be set, whatever its value.
+	Exists Operator = "exists"
+	// DoesNotExist requires the label not to be set.
+	DoesNotExist Operator = "!"
+)
+
+var (
+	patternKey   = regexp.MustCompile(`^([a-zA-Z0-9]([-a-zA-Z0-9.]*[a-zA-Z0-9])?/)?[a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?$`)
+	patternValue = regexp.MustCompile(`^([a-zA-Z0-9]([-a-zA-Z0-9_.]*[a-zA-Z0-9])?)?$`)
+	patternSet   = regexp.MustCompile(`^(\S+)\s+(in|notin)\s*\((.*)\)$`)
+	patternEqual = regexp.MustCompile(`^([^=!\s]+)\s*(==|=|!=)\s*(\S*)$`)
+)
+
+// Requirement is a single requirement of a label selector. All requirements of a selector must be met.
+type Requirement struct {
+	Key      string
+	Operator Operator
+	Values   []string
+}
+
+// Parse parses the label selector into its requirements, in their order in the selector. An empty selector has no requirements.
+func Parse(selector string) ([]Requirement, error) {
+	requirements := []Requirement{}
+	for _, term := range split(selector) {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			if strings.TrimSpace(selector) == "" {
+				continue
+			}
+			return nil, fmt.Errorf("empty requirement in label selector %q", selector)
+		}
+
+		var r Requirement
+		switch {
+		case strings.HasPrefix(term, "!"):
+			r = Requirement{Key: strings.TrimSpace(term[1:]), Operator: DoesNotExist}
+		case patternSet.MatchString(term):
+			m := patternSet.FindStringSubmatch(term)
+			r = Requirement{Key: m[1], Operator: Operator(m[2])}
+			for _, v := range strings.Split(m[3], ",") {
+				r.Values = append(r.Values, strings.TrimSpace(v))
+			}
+		case patternEqual.MatchString(term):
+			m := patternEqual.FindStringSubmatch(term)
+			r = Requirement{Key: m[1], Operator: In, Values: []string{m[3]}}
+			if m[2] == "!=" {
+				r.Operator = NotIn
+			}
+		default:
+			r = Requirement{Key: term, Operator: Exists}
+		}
+
+		if !patternKey.MatchString(r.Key) {
+			return nil, fmt.Errorf("invalid label key %q in label selector %q", r.Key, selector)
+		}
+		for _, v := range r.Values {
+			if !patternValue.MatchString(v) {
+				return nil, fmt.Errorf("invalid value %q of label %q in label selector %q", v, r.Key, selector)
+			}
+		}
+		requirements = append(requirements, r)
+	}
+
+	return requirements, nil
+}
+
+// Matches reports whether the labels meet the requirement. Values are compared case insensitively, the way the Backstage catalog matches
+// filters.
+func (r Requirement) Matches(labels map[string]string) bool {
+	value, ok := labels[r.Key]
+	switch r.Operator {
+	case Exists:
+		return ok
+	case DoesNotExist:
+		return !ok
+	case NotIn:
+		return !ok || !r.hasValue(value)
+	default:
+		return ok && r.hasValue(value)
+	}
+}
+
+func (r Requirement) hasValue(value string) bool {
+	for _, v := range r.Values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// split splits the selector on the commas separating its requirements, leaving those separating the values of sets.
+func split(selector string) []string {
+	terms := []string{}
+	depth, start := 0, 0
+	for i, c := range selector {
+		switch c {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				terms = append(terms, selector[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(terms, selector[start:])
+}
@@ -0,0 +1,53 @@
+package selector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		selector string
+		expected []Requirement
+	}{
+		{"", []Requirement{}},
+		{"env=production", []Requirement{{Key: "env", Operator: In, Values: []string{"production"}}}},
+		{"env == production", []Requirement{{Key: "env", Operator: In, Values: []string{"production"}}}},
+		{"tier!=frontend", []Requirement{{Key: "tier", Operator: NotIn, Values: []string{"frontend"}}}},
+		{"env in (staging, production),!canary", []Requirement{
+			{Key: "env", Operator: In, Values: []string{"staging", "production"}},
+			{Key: "canary", Operator: DoesNotExist},
+		}},
+		{"example.com/team notin (a,b), managed", []Requirement{
+			{Key: "example.com/team", Operator: NotIn, Values: []string{"a", "b"}},
+			{Key: "managed", Operator: Exists},
+		}},
+	}
+
+	for _, test := range tests {
+		requirements, err := Parse(test.selector)
+		assert.NoErrorf(t, err, "selector %q should parse", test.selector)
+		assert.Equalf(t, test.expected, requirements, "selector %q should parse into its requirements", test.selector)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	for _, selector := range []string{"env=production,", "env in (a,b", "-env", "env=bad value", "env in (a,-b)", "a/b/c"} {
+		_, err := Parse(selector)
+		assert.Errorf(t, err, "selector %q should not parse", selector)
+	}
+}
+
+func TestRequirementMatches(t *testing.T) {
+	labels := map[string]string{"env": "Production", "tier": "backend"}
+
+	assert.True(t, Requirement{Key: "env", Operator: In, Values: []string{"staging", "production"}}.Matches(labels))
+	assert.False(t, Requirement{Key: "env", Operator: In, Values: []string{"staging"}}.Matches(labels))
+	assert.True(t, Requirement{Key: "tier", Operator: NotIn, Values: []string{"frontend"}}.Matches(labels))
+	assert.True(t, Requirement{Key: "canary", Operator: NotIn, Values: []string{"true"}}.Matches(labels))
+	assert.False(t, Requirement{Key: "tier", Operator: NotIn, Values: []string{"backend"}}.Matches(labels))
+	assert.True(t, Requirement{Key: "tier", Operator: Exists}.Matches(labels))
+	assert.False(t, Requirement{Key: "tier", Operator: DoesNotExist}.Matches(labels))
+	assert.True(t, Requirement{Key: "canary", Operator: DoesNotExist}.Matches(labels))
+}
@@ -0,0 +1,71 @@
+// Package token checks the validity period of JSON Web Tokens (https://www.rfc-editor.org/rfc/rfc7519) used to authenticate to Backstage,
+// so tokens expiring before or during a run are reported when the provider is configured instead of failing requests midway.
+package token
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Claims are the claims of a token bounding its validity period. Claims that are not set are zero.
+type Claims struct {
+	// ExpiresAt is the time after which the token is not accepted anymore, from the `exp` claim.
+	ExpiresAt time.Time
+	// NotBefore is the time before which the token is not accepted yet, from the `nbf` claim.
+	NotBefore time.Time
+}
+
+// Parse decodes the claims of the token, without verifying its signature, which only Backstage can. It reports false, if the token is not
+// a JSON Web Token, e.g. a static token of Backstage, which has no validity period.
+func Parse(token string) (Claims, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return Claims{}, false
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return Claims{}, false
+	}
+
+	var claims struct {
+		ExpiresAt *json.Number `json:"exp"`
+		NotBefore *json.Number `json:"nbf"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return Claims{}, false
+	}
+
+	return Claims{ExpiresAt: numericDate(claims.ExpiresAt), NotBefore: numericDate(claims.NotBefore)}, true
+}
+
+// Check returns an error, if the token is expired or not valid yet at now, allowing for clock skew between the clocks of the provider and of
+// Backstage. Otherwise, it reports whether the token expires within the run duration from now, in which case requests late in the run would
+// fail.
+func (c Claims) Check(now time.Time, skew time.Duration, run time.Duration) (expiresDuringRun bool, err error) {
+	if !c.ExpiresAt.IsZero() && now.After(c.ExpiresAt.Add(skew)) {
+		return false, fmt.Errorf("token expired at %s", c.ExpiresAt.UTC().Format(time.RFC3339))
+	}
+	if !c.NotBefore.IsZero() && now.Before(c.NotBefore.Add(-skew)) {
+		return false, fmt.Errorf("token is not valid before %s", c.NotBefore.UTC().Format(time.RFC3339))
+	}
+
+	return !c.ExpiresAt.IsZero() && now.Add(run).After(c.ExpiresAt.Add(skew)), nil
+}
+
+// numericDate returns the time of the NumericDate, the seconds since the epoch, or the zero time if it is not set or invalid.
+func numericDate(n *json.Number) time.Time {
+	if n == nil {
+		return time.Time{}
+	}
+
+	seconds, err := n.Float64()
+	if err != nil {
+		return time.Time{}
+	}
+
+	return time.Unix(0, int64(seconds*float64(time.Second)))
+}
@@ -0,0 +1,56 @@
+package token
+
+import (
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// jwt returns an unsigned token with the payload.
+func jwt(payload string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`)) + "." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + "."
+}
+
+func TestParse(t *testing.T) {
+	claims, ok := Parse(jwt(`{"sub":"user:default/guest","exp":1700000600,"nbf":1700000000}`))
+	assert.True(t, ok, "JSON Web Token should parse")
+	assert.Equal(t, time.Unix(1700000600, 0), claims.ExpiresAt)
+	assert.Equal(t, time.Unix(1700000000, 0), claims.NotBefore)
+
+	claims, ok = Parse(jwt(`{"sub":"user:default/guest"}`))
+	assert.True(t, ok, "JSON Web Token without validity period should parse")
+	assert.True(t, claims.ExpiresAt.IsZero(), "missing exp claim should be zero")
+
+	for _, token := range []string{"static-token", "a.b", "a.!.c", jwt(`not json`)} {
+		_, ok := Parse(token)
+		assert.Falsef(t, ok, "token %q should not parse", token)
+	}
+}
+
+func TestClaimsCheck(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	claims := Claims{ExpiresAt: now.Add(10 * time.Minute), NotBefore: now.Add(-time.Minute)}
+
+	expiresDuringRun, err := claims.Check(now, time.Minute, 5*time.Minute)
+	assert.NoError(t, err)
+	assert.False(t, expiresDuringRun, "token should outlast the run")
+
+	expiresDuringRun, err = claims.Check(now, time.Minute, 30*time.Minute)
+	assert.NoError(t, err)
+	assert.True(t, expiresDuringRun, "token should expire during the run")
+
+	_, err = claims.Check(now.Add(10*time.Minute+30*time.Second), time.Minute, 0)
+	assert.NoError(t, err, "token expired within the clock skew should be accepted")
+
+	_, err = claims.Check(now.Add(12*time.Minute), time.Minute, 0)
+	assert.ErrorContains(t, err, "token expired at")
+
+	_, err = Claims{NotBefore: now.Add(5 * time.Minute)}.Check(now, time.Minute, 0)
+	assert.ErrorContains(t, err, "token is not valid before")
+
+	expiresDuringRun, err = Claims{}.Check(now, time.Minute, time.Hour)
+	assert.NoError(t, err)
+	assert.False(t, expiresDuringRun, "token without expiry should never expire")
+}
@@ -0,0 +1,122 @@
+package transport
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httputil"
+	"sort"
+
+	"github.com/datolabs-io/terraform-provider-backstage/internal/cache"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/metrics"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// noCacheContextKey is the key of the bypass of the cache in the context of requests.
+type noCacheContextKey struct{}
+
+// WithoutCache returns a copy of the context whose requests are sent to Backstage instead of being served from the cache, e.g. to poll for
+// changes or to detect drift. Their responses are stored in the cache, so later requests see them.
+func WithoutCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noCacheContextKey{}, true)
+}
+
+// CacheTransport is a http.RoundTripper that serves successful GET responses from a cache, storing the ones that were not cached yet.
+type CacheTransport struct {
+	// Cache is the cache to store responses in.
+	Cache cache.Cache
+
+	// Recorder is used to record cache hits and misses, if set.
+	Recorder metrics.Recorder
+
+	// BaseTransport is the underlying HTTP transport to use when making requests. It will default to http.DefaultTransport if nil.
+	BaseTransport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *CacheTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.Cache == nil || req.Method != http.MethodGet {
+		return t.transport().RoundTrip(req)
+	}
+
+	ctx := req.Context()
+	key := requestCacheKey(req)
+
+	// Requests with a bypass of the cache, or with a `Cache-Control: no-cache` header, are not served from the cache.
+	var value []byte
+	var ok bool
+	var err error
+	if bypass, _ := ctx.Value(noCacheContextKey{}).(bool); !bypass && req.Header.Get("Cache-Control") != "no-cache" {
+		if value, ok, err = t.Cache.Get(ctx, key); err != nil {
+			tflog.Warn(ctx, "Unable to read response from cache", map[string]interface{}{"error": err.Error()})
+		}
+	}
+
+	if ok {
+		resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(value)), req)
+		if err == nil {
+			tflog.Debug(ctx, "Serving response from cache", map[string]interface{}{"url": req.URL.String()})
+			t.record("cache_hits_total")
+			return resp, nil
+		}
+		tflog.Warn(ctx, "Unable to parse cached response", map[string]interface{}{"error": err.Error()})
+	}
+
+	t.record("cache_misses_total")
+
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+
+	if value, err = httputil.DumpResponse(resp, true); err != nil {
+		return nil, err
+	}
+
+	if err = t.Cache.Set(ctx, key, value); err != nil {
+		tflog.Warn(ctx, "Unable to store response in cache", map[string]interface{}{"error": err.Error()})
+	}
+
+	return resp, nil
+}
+
+// transport returns the underlying HTTP transport. If none is set, http.DefaultTransport is used.
+func (t *CacheTransport) transport() http.RoundTripper {
+	if t.BaseTransport != nil {
+		return t.BaseTransport
+	}
+
+	return http.DefaultTransport
+}
+
+// record increments the counter, if the recorder is set.
+func (t *CacheTransport) record(name string) {
+	if t.Recorder != nil {
+		t.Recorder.Count(name, 1, nil)
+	}
+}
+
+// requestCacheKey returns the cache key for the request. Headers are part of the key, so responses are never shared between identities.
+// The correlation ID is left out, as it differs between Terraform runs, and so is the bypass of the cache, so responses to requests bypassing
+// it are served to later requests.
+func requestCacheKey(req *http.Request) string {
+	parts := []string{req.Method, req.URL.String()}
+
+	names := make([]string, 0, len(req.Header))
+	for k := range req.Header {
+		if k == HeaderRequestID || k == "Cache-Control" {
+			continue
+		}
+		names = append(names, k)
+	}
+	sort.Strings(names)
+
+	for _, k := range names {
+		for _, v := range req.Header[k] {
+			parts = append(parts, k+": "+v)
+		}
+	}
+
+	return cache.Key(parts...)
+}
@@ -0,0 +1,67 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/datolabs-io/terraform-provider-backstage/internal/cache"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCacheTransport_ResponseCached(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		Get("/api/catalog/entities/by-name/component/default/artist-lookup").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{"kind": "Component", "metadata": map[string]string{"name": "artist-lookup"}})
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{
+		Transport: &CacheTransport{
+			Cache: cache.NewMemory(time.Minute),
+		},
+	})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	for i := 0; i < 2; i++ {
+		component, _, err := client.Catalog.Components.Get(context.Background(), "artist-lookup", "default")
+		assert.NoErrorf(t, err, "Get should not return an error")
+		assert.Equal(t, "artist-lookup", component.Metadata.Name)
+	}
+
+	assert.Truef(t, gock.IsDone(), "Backstage API should be called only once")
+}
+
+func TestCacheTransport_WithoutCache(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	for _, etag := range []string{"1", "2"} {
+		gock.New(baseURL).
+			Get("/api/catalog/entities/by-name/component/default/artist-lookup").
+			Reply(http.StatusOK).
+			JSON(map[string]interface{}{"kind": "Component", "metadata": map[string]string{"name": "artist-lookup", "etag": etag}})
+	}
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{
+		Transport: &CacheTransport{
+			Cache: cache.NewMemory(time.Minute),
+		},
+	})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	// The first request fills the cache, the second one bypasses it and the third one is served the response of the second one.
+	etags := []string{"1", "2", "2"}
+	for i, ctx := range []context.Context{context.Background(), WithoutCache(context.Background()), context.Background()} {
+		component, _, err := client.Catalog.Components.Get(ctx, "artist-lookup", "default")
+		assert.NoErrorf(t, err, "Get should not return an error")
+		assert.Equal(t, etags[i], component.Metadata.Etag)
+	}
+
+	assert.Truef(t, gock.IsDone(), "Backstage API should be called twice")
+}
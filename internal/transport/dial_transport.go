@@ -0,0 +1,32 @@
+package transport
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"net/http"
+	"time"
+)
+
+// NewDialTransport returns a clone of http.DefaultTransport connecting to address over network, e.g. `unix` and the path of a socket or
+// `tcp` and the local end of a port-forward, instead of to the hosts of the requests, if address is set. Certificates are verified against
+// serverName, if set, and the host of the request otherwise. Requests keep the host of their URL in the Host header, so Backstage behind a
+// sidecar socket or a port-forward answers them as if it was reached directly.
+func NewDialTransport(network, address, serverName string) *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+
+	if address != "" {
+		dialer := &net.Dialer{Timeout: 30 * time.Second, KeepAlive: 30 * time.Second}
+		t.DialContext = func(ctx context.Context, _, _ string) (net.Conn, error) {
+			return dialer.DialContext(ctx, network, address)
+		}
+		// Proxies would be connected to instead of the address.
+		t.Proxy = nil
+	}
+
+	if serverName != "" {
+		t.TLSClientConfig = &tls.Config{ServerName: serverName, MinVersion: tls.VersionTLS12}
+	}
+
+	return t
+}
@@ -0,0 +1,99 @@
+package transport
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewDialTransport_UnixSocket(t *testing.T) {
+	// Paths of Unix sockets are limited to about 100 bytes, which temporary directories of tests may exceed.
+	dir, err := os.MkdirTemp("", "backstage")
+	assert.NoErrorf(t, err, "MkdirTemp should not return an error")
+	defer os.RemoveAll(dir)
+	socket := filepath.Join(dir, "backstage.sock")
+
+	listener, err := net.Listen("unix", socket)
+	assert.NoErrorf(t, err, "Listen should not return an error")
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Host)
+	})}
+	go func() { _ = server.Serve(listener) }()
+	defer server.Close()
+
+	client := &http.Client{Transport: NewDialTransport("unix", socket, "")}
+	response, err := client.Get("http://backstage.example.com/api/catalog/entities")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	assert.Equal(t, "backstage.example.com", string(body), "request should keep the host of its URL")
+}
+
+func TestNewDialTransport_PortForward(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Host)
+	}))
+	defer server.Close()
+
+	client := &http.Client{Transport: NewDialTransport("tcp", server.Listener.Addr().String(), "")}
+	response, err := client.Get("http://backstage.internal:7007/api/catalog/entities")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	defer response.Body.Close()
+
+	body, _ := io.ReadAll(response.Body)
+	assert.Equal(t, "backstage.internal:7007", string(body), "request should keep the host of its URL")
+}
+
+func TestNewDialTransport_ServerName(t *testing.T) {
+	transport := NewDialTransport("", "", "backstage.example.com")
+
+	assert.Equal(t, "backstage.example.com", transport.TLSClientConfig.ServerName)
+}
+
+func TestNewDialTransport_ServerNameVerification(t *testing.T) {
+	// The certificate of the server is for example.com, not for the host of the requests, which is reached through a port-forward.
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = io.WriteString(w, r.Host)
+	}))
+	defer server.Close()
+	roots := x509.NewCertPool()
+	roots.AddCert(server.Certificate())
+
+	for name, test := range map[string]struct {
+		serverName string
+		wantErr    bool
+	}{
+		"with server name":    {serverName: "example.com"},
+		"without server name": {wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			transport := NewDialTransport("tcp", server.Listener.Addr().String(), test.serverName)
+			if transport.TLSClientConfig == nil {
+				transport.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+			}
+			transport.TLSClientConfig.RootCAs = roots
+
+			client := &http.Client{Transport: transport}
+			response, err := client.Get("https://backstage.internal:7007/api/catalog/entities")
+			if test.wantErr {
+				var hostnameErr x509.HostnameError
+				assert.ErrorAsf(t, err, &hostnameErr, "Get should fail verifying the certificate")
+				return
+			}
+
+			assert.NoErrorf(t, err, "Get should not return an error")
+			defer response.Body.Close()
+			body, _ := io.ReadAll(response.Body)
+			assert.Equal(t, "backstage.internal:7007", string(body), "request should keep the host of its URL")
+		})
+	}
+}
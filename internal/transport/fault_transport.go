@@ -0,0 +1,91 @@
+package transport
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/datolabs-io/terraform-provider-backstage/internal/metrics"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// FaultTransport is a http.RoundTripper that fails a share of requests without sending them, to test how configurations behave when the
+// Backstage instance degrades.
+type FaultTransport struct {
+	// Rate is the share of requests to fail, between 0 and 1.
+	Rate float64
+
+	// StatusCode is the status code of the responses of failed requests. If 0, requests fail with an error instead.
+	StatusCode int
+
+	// Rand returns random numbers in [0, 1) deciding which requests fail. It defaults to a source seeded with the current time if nil.
+	Rand func() float64
+
+	// Recorder is used to record injected failures, if set.
+	Recorder metrics.Recorder
+
+	// BaseTransport is the underlying HTTP transport to use when making requests. It will default to http.DefaultTransport if nil.
+	BaseTransport http.RoundTripper
+
+	once sync.Once
+	mu   sync.Mutex
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *FaultTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !t.fail() {
+		return t.transport().RoundTrip(req)
+	}
+
+	tflog.Warn(req.Context(), "Injecting failure of request", map[string]interface{}{"url": req.URL.String(), "status_code": t.StatusCode})
+	if t.Recorder != nil {
+		t.Recorder.Count("injected_failures_total", 1, nil)
+	}
+
+	if t.StatusCode == 0 {
+		return nil, fmt.Errorf("injected failure of request to %s", req.URL.Redacted())
+	}
+
+	return &http.Response{
+		Status:     fmt.Sprintf("%d %s", t.StatusCode, http.StatusText(t.StatusCode)),
+		StatusCode: t.StatusCode,
+		Proto:      "HTTP/1.1",
+		ProtoMajor: 1,
+		ProtoMinor: 1,
+		Header:     http.Header{"Content-Type": []string{"text/plain"}},
+		Body:       io.NopCloser(bytes.NewBufferString("injected failure")),
+		Request:    req,
+	}, nil
+}
+
+// fail reports whether the request should fail.
+func (t *FaultTransport) fail() bool {
+	if t.Rate <= 0 {
+		return false
+	}
+
+	t.once.Do(func() {
+		if t.Rand == nil {
+			t.Rand = rand.New(rand.NewSource(time.Now().UnixNano())).Float64
+		}
+	})
+
+	// Sources of math/rand are not safe for concurrent use.
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	return t.Rand() < t.Rate
+}
+
+// transport returns the underlying HTTP transport. If none is set, http.DefaultTransport is used.
+func (t *FaultTransport) transport() http.RoundTripper {
+	if t.BaseTransport != nil {
+		return t.BaseTransport
+	}
+
+	return http.DefaultTransport
+}
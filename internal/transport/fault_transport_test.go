@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFaultTransport_FailuresInjected(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		Get("/api/catalog/entities/by-name/component/default/artist-lookup").
+		Reply(http.StatusOK).
+		JSON(map[string]interface{}{"kind": "Component", "metadata": map[string]string{"name": "artist-lookup"}})
+
+	values := []float64{0.1, 0.9}
+	recorder := &testRecorder{counts: map[string]int64{}}
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{
+		Transport: &FaultTransport{
+			Rate:       0.5,
+			StatusCode: http.StatusServiceUnavailable,
+			Rand: func() float64 {
+				v := values[0]
+				values = values[1:]
+				return v
+			},
+			Recorder: recorder,
+		},
+	})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	_, response, _ := client.Catalog.Components.Get(context.Background(), "artist-lookup", "default")
+	assert.Equal(t, http.StatusServiceUnavailable, response.StatusCode, "first request should fail")
+	assert.Equal(t, int64(1), recorder.counts["injected_failures_total/"])
+
+	component, response, err := client.Catalog.Components.Get(context.Background(), "artist-lookup", "default")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	assert.Equal(t, http.StatusOK, response.StatusCode, "second request should be sent")
+	assert.Equal(t, "artist-lookup", component.Metadata.Name)
+	assert.Truef(t, gock.IsDone(), "Backstage API should be called once")
+}
+
+func TestFaultTransport_ErrorInjected(t *testing.T) {
+	client := &http.Client{Transport: &FaultTransport{Rate: 1}}
+
+	_, err := client.Get("http://localhost:7007/api/catalog/entities")
+	assert.ErrorContainsf(t, err, "injected failure of request", "request should fail with an error")
+}
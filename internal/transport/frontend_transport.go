@@ -0,0 +1,81 @@
+package transport
+
+import (
+	"fmt"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// Ports the Backstage frontend and backend listen on in local development setups created by `npx @backstage/create-app`.
+const (
+	portFrontend = "3000"
+	portBackend  = "7007"
+)
+
+// FrontendError is the error of requests to the Backstage API answered with an HTML page, as the Backstage frontend answers requests to any
+// path. It usually means that the base URL points at the frontend instead of the backend.
+type FrontendError struct {
+	// URL is the URL of the request.
+	URL *url.URL
+}
+
+// Error implements the error interface.
+func (e *FrontendError) Error() string {
+	msg := fmt.Sprintf("%s was answered with an HTML page instead of JSON, so base_url likely points at the Backstage frontend (app) "+
+		"instead of the backend serving the API under /api", e.URL.Redacted())
+	if suggested := e.SuggestedBaseURL(); suggested != "" {
+		msg += fmt.Sprintf("; the backend is likely at %s", suggested)
+	}
+
+	return msg
+}
+
+// SuggestedBaseURL returns the likely base URL of the backend, or an empty string if it can not be guessed. Only the ports of local
+// development setups are known: the frontend listens on port 3000 and the backend on port 7007.
+func (e *FrontendError) SuggestedBaseURL() string {
+	host, port, err := net.SplitHostPort(e.URL.Host)
+	if err != nil || port != portFrontend {
+		return ""
+	}
+
+	return (&url.URL{Scheme: e.URL.Scheme, Host: net.JoinHostPort(host, portBackend)}).String()
+}
+
+// FrontendTransport is a http.RoundTripper that fails requests to the Backstage API successfully answered with an HTML page with a
+// FrontendError, instead of leaving the response to fail decoding.
+type FrontendTransport struct {
+	// BaseTransport is the underlying HTTP transport to use when making requests. It will default to http.DefaultTransport if nil.
+	BaseTransport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *FrontendTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	resp, err := t.transport().RoundTrip(req)
+	if err != nil || !strings.Contains(req.URL.Path, "/api/") {
+		return resp, err
+	}
+
+	// Error pages of proxies and load balancers in front of the backend, e.g. for 502 responses, are HTML as well and left as they are.
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp, nil
+	}
+
+	if mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type")); mediaType != "text/html" {
+		return resp, nil
+	}
+
+	resp.Body.Close()
+	return nil, &FrontendError{URL: req.URL}
+}
+
+// transport returns the underlying HTTP transport. If none is set, http.DefaultTransport is used.
+func (t *FrontendTransport) transport() http.RoundTripper {
+	if t.BaseTransport != nil {
+		return t.BaseTransport
+	}
+
+	return http.DefaultTransport
+}
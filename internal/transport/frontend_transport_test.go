@@ -0,0 +1,87 @@
+package transport
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFrontendTransport_HTMLResponse(t *testing.T) {
+	const baseURL = "http://localhost:3000"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		Get("/api/catalog/entities").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "text/html; charset=utf-8").
+		BodyString("<!DOCTYPE html><html></html>")
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{Transport: &FrontendTransport{}})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	_, _, err = client.Catalog.Entities.List(context.Background(), &backstage.ListEntityOptions{})
+
+	var frontendErr *FrontendError
+	assert.True(t, errors.As(err, &frontendErr), "ListEntities should return a FrontendError")
+	assert.Equal(t, "http://localhost:7007", frontendErr.SuggestedBaseURL())
+	assert.Contains(t, err.Error(), "the backend is likely at http://localhost:7007")
+}
+
+func TestFrontendTransport_JSONResponse(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		Get("/api/catalog/entities").
+		Reply(http.StatusOK).
+		JSON([]backstage.Entity{})
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{Transport: &FrontendTransport{}})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	_, _, err = client.Catalog.Entities.List(context.Background(), &backstage.ListEntityOptions{})
+	assert.NoErrorf(t, err, "ListEntities should not return an error")
+}
+
+func TestFrontendTransport_HTMLErrorResponse(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		Get("/api/catalog/entities").
+		Reply(http.StatusBadGateway).
+		SetHeader("Content-Type", "text/html").
+		BodyString("<html><body><h1>502 Bad Gateway</h1></body></html>")
+
+	httpClient := &http.Client{Transport: &FrontendTransport{}}
+	resp, err := httpClient.Get(baseURL + "/api/catalog/entities")
+	assert.NoErrorf(t, err, "Get should not return an error")
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadGateway, resp.StatusCode)
+}
+
+func TestFrontendError_NoSuggestedBaseURL(t *testing.T) {
+	const baseURL = "https://backstage.example.com"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		Get("/api/catalog/entities").
+		Reply(http.StatusOK).
+		SetHeader("Content-Type", "text/html")
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{Transport: &FrontendTransport{}})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	_, _, err = client.Catalog.Entities.List(context.Background(), &backstage.ListEntityOptions{})
+
+	var frontendErr *FrontendError
+	assert.True(t, errors.As(err, &frontendErr), "ListEntities should return a FrontendError")
+	assert.Empty(t, frontendErr.SuggestedBaseURL())
+	assert.NotContains(t, err.Error(), "likely at")
+}
@@ -1,6 +1,18 @@
 package transport
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
+
+// headersContextKey is the key of the headers of requests in their context.
+type headersContextKey struct{}
+
+// WithHeaders returns a copy of the context with headers that HeadersTransport sets on requests sent with the context, in place of its own
+// headers of the same names, e.g. to send a request with another identity.
+func WithHeaders(ctx context.Context, headers map[string]string) context.Context {
+	return context.WithValue(ctx, headersContextKey{}, headers)
+}
 
 // HeadersTransport is a http.RoundTripper that supports adding custom HTTP headers to requests.
 type HeadersTransport struct {
@@ -19,6 +31,12 @@ func (t *HeadersTransport) RoundTrip(req *http.Request) (*http.Response, error)
 		req.Header.Add(k, v)
 	}
 
+	if headers, ok := req.Context().Value(headersContextKey{}).(map[string]string); ok {
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+	}
+
 	return t.transport().RoundTrip(req)
 }
 
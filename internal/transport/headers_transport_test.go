@@ -34,3 +34,26 @@ func TestHeadersTransport_HeadersAdded(t *testing.T) {
 
 	assert.NoErrorf(t, err, "ListEntities should not return an error")
 }
+
+func TestHeadersTransport_ContextHeadersReplaceHeaders(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		MatchHeader("Authorization", "^Bearer identity-token$").
+		MatchHeader("test-header", "test-value").
+		Reply(http.StatusOK)
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{
+		Transport: &HeadersTransport{
+			Headers: map[string]string{"Authorization": "Bearer service-token", "test-header": "test-value"},
+		},
+	})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	ctx := WithHeaders(context.Background(), map[string]string{"Authorization": "Bearer identity-token"})
+	_, _, err = client.Catalog.Entities.List(ctx, &backstage.ListEntityOptions{})
+
+	assert.NoErrorf(t, err, "ListEntities should not return an error")
+	assert.True(t, gock.IsDone(), "request should be sent with the headers of the context")
+}
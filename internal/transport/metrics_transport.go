@@ -0,0 +1,43 @@
+package transport
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/datolabs-io/terraform-provider-backstage/internal/metrics"
+)
+
+// MetricsTransport is a http.RoundTripper that records count and latency of requests.
+type MetricsTransport struct {
+	// Recorder is the recorder to record metrics with.
+	Recorder metrics.Recorder
+
+	// BaseTransport is the underlying HTTP transport to use when making requests. It will default to http.DefaultTransport if nil.
+	BaseTransport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *MetricsTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	start := time.Now()
+	resp, err := t.transport().RoundTrip(req)
+
+	status := "error"
+	if err == nil {
+		status = strconv.Itoa(resp.StatusCode)
+	}
+
+	t.Recorder.Count("requests_total", 1, map[string]string{"method": req.Method, "status": status})
+	t.Recorder.Timing("request_duration", time.Since(start), map[string]string{"method": req.Method})
+
+	return resp, err
+}
+
+// transport returns the underlying HTTP transport. If none is set, http.DefaultTransport is used.
+func (t *MetricsTransport) transport() http.RoundTripper {
+	if t.BaseTransport != nil {
+		return t.BaseTransport
+	}
+
+	return http.DefaultTransport
+}
@@ -0,0 +1,52 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+type testRecorder struct {
+	mu      sync.Mutex
+	counts  map[string]int64
+	timings int
+}
+
+func (r *testRecorder) Count(name string, value int64, tags map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[name+"/"+tags["status"]] += value
+}
+
+func (r *testRecorder) Timing(string, time.Duration, map[string]string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.timings++
+}
+
+func TestMetricsTransport_RequestsRecorded(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		Reply(http.StatusNotFound)
+
+	recorder := &testRecorder{counts: map[string]int64{}}
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{
+		Transport: &MetricsTransport{
+			Recorder: recorder,
+		},
+	})
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+
+	_, _, _ = client.Catalog.Components.Get(context.Background(), "artist-lookup", "default")
+
+	assert.Equal(t, int64(1), recorder.counts["requests_total/404"])
+	assert.Equal(t, 1, recorder.timings)
+}
@@ -0,0 +1,36 @@
+package transport
+
+import "net/http"
+
+// HeaderRequestID is the name of the header used to correlate requests with the logs of the Backstage backend.
+const HeaderRequestID = "X-Request-Id"
+
+// RequestIDTransport is a http.RoundTripper that sets the correlation ID as X-Request-Id header of each request.
+type RequestIDTransport struct {
+	// RequestID is the correlation ID to send with each request.
+	RequestID string
+
+	// BaseTransport is the underlying HTTP transport to use when making requests. It will default to http.DefaultTransport if nil.
+	BaseTransport http.RoundTripper
+}
+
+// RoundTrip implements the RoundTripper interface.
+func (t *RequestIDTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if t.RequestID == "" || req.Header.Get(HeaderRequestID) != "" {
+		return t.transport().RoundTrip(req)
+	}
+
+	req = cloneRequest(req)
+	req.Header.Set(HeaderRequestID, t.RequestID)
+
+	return t.transport().RoundTrip(req)
+}
+
+// transport returns the underlying HTTP transport. If none is set, http.DefaultTransport is used.
+func (t *RequestIDTransport) transport() http.RoundTripper {
+	if t.BaseTransport != nil {
+		return t.BaseTransport
+	}
+
+	return http.DefaultTransport
+}
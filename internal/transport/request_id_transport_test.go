@@ -0,0 +1,54 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/datolabs-io/go-backstage/v3"
+	"github.com/h2non/gock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestIDTransport_HeaderAdded(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		MatchHeader(HeaderRequestID, "test-request-id").
+		Reply(http.StatusOK)
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{
+		Transport: &RequestIDTransport{
+			RequestID: "test-request-id",
+		},
+	})
+
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+	_, _, err = client.Catalog.Entities.List(context.Background(), &backstage.ListEntityOptions{})
+
+	assert.NoErrorf(t, err, "ListEntities should not return an error")
+}
+
+func TestRequestIDTransport_HeaderPreserved(t *testing.T) {
+	const baseURL = "http://localhost:7007"
+
+	defer gock.Off()
+	gock.New(baseURL).
+		MatchHeader(HeaderRequestID, "custom-request-id").
+		Reply(http.StatusOK)
+
+	client, err := backstage.NewClient(baseURL, "default", &http.Client{
+		Transport: &HeadersTransport{
+			Headers: map[string]string{HeaderRequestID: "custom-request-id"},
+			BaseTransport: &RequestIDTransport{
+				RequestID: "test-request-id",
+			},
+		},
+	})
+
+	assert.NoErrorf(t, err, "NewClient should not return an error")
+	_, _, err = client.Catalog.Entities.List(context.Background(), &backstage.ListEntityOptions{})
+
+	assert.NoErrorf(t, err, "ListEntities should not return an error")
+}
@@ -0,0 +1,60 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// RetryBudget caps the total time spent waiting between retries of requests, shared by all requests of a client. Once the budget is spent,
+// requests are no longer retried, so a degraded Backstage instance can not stall a Terraform run for the number of retries of each request.
+type RetryBudget struct {
+	limit time.Duration
+	spent time.Duration
+	mu    sync.Mutex
+}
+
+// NewRetryBudget returns a retry budget allowing to wait up to limit in total between retries.
+func NewRetryBudget(limit time.Duration) *RetryBudget {
+	return &RetryBudget{limit: limit}
+}
+
+// CheckRetry wraps the retry policy, so requests are not retried once the budget is spent.
+func (b *RetryBudget) CheckRetry(policy retryablehttp.CheckRetry) retryablehttp.CheckRetry {
+	return func(ctx context.Context, resp *http.Response, err error) (bool, error) {
+		retry, checkErr := policy(ctx, resp, err)
+		if retry && b.Remaining() <= 0 {
+			return false, checkErr
+		}
+
+		return retry, checkErr
+	}
+}
+
+// Backoff wraps the backoff, so waits are spent from the budget and never exceed what is left of it.
+func (b *RetryBudget) Backoff(backoff retryablehttp.Backoff) retryablehttp.Backoff {
+	return func(min, max time.Duration, attemptNum int, resp *http.Response) time.Duration {
+		wait := backoff(min, max, attemptNum, resp)
+
+		b.mu.Lock()
+		defer b.mu.Unlock()
+
+		if remaining := b.limit - b.spent; wait > remaining {
+			wait = remaining
+		}
+		b.spent += wait
+
+		return wait
+	}
+}
+
+// Remaining returns the time left to wait between retries.
+func (b *RetryBudget) Remaining() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.limit - b.spent
+}
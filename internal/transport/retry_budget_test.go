@@ -0,0 +1,37 @@
+package transport
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryBudget_BackoffCapped(t *testing.T) {
+	budget := NewRetryBudget(3 * time.Second)
+	backoff := budget.Backoff(func(time.Duration, time.Duration, int, *http.Response) time.Duration { return 2 * time.Second })
+
+	assert.Equal(t, 2*time.Second, backoff(0, 0, 0, nil), "wait should be spent in full while the budget allows")
+	assert.Equal(t, time.Second, backoff(0, 0, 1, nil), "wait should be capped to the rest of the budget")
+	assert.Equal(t, time.Duration(0), backoff(0, 0, 2, nil), "wait should be zero once the budget is spent")
+	assert.Equal(t, time.Duration(0), budget.Remaining())
+}
+
+func TestRetryBudget_CheckRetryStopsWhenSpent(t *testing.T) {
+	budget := NewRetryBudget(time.Second)
+	checkRetry := budget.CheckRetry(retryablehttp.DefaultRetryPolicy)
+	resp := &http.Response{StatusCode: http.StatusServiceUnavailable}
+
+	retry, err := checkRetry(context.Background(), resp, nil)
+	assert.NoErrorf(t, err, "CheckRetry should not return an error")
+	assert.Truef(t, retry, "request should be retried while the budget is left")
+
+	budget.Backoff(retryablehttp.DefaultBackoff)(2*time.Second, 2*time.Second, 0, resp)
+
+	retry, err = checkRetry(context.Background(), resp, nil)
+	assert.NoErrorf(t, err, "CheckRetry should not return an error")
+	assert.Falsef(t, retry, "request should not be retried once the budget is spent")
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 	"flag"
 	"log"
+	"time"
 
+	fwprovider "github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/providerserver"
 
 	provider "github.com/datolabs-io/terraform-provider-backstage/backstage"
@@ -26,7 +28,15 @@ func main() {
 		Debug:   debug,
 	}
 
-	err := providerserver.Serve(context.Background(), provider.New(version), opts)
+	p := provider.New(version)()
+	err := providerserver.Serve(context.Background(), func() fwprovider.Provider { return p }, opts)
+
+	// Metrics held back by the provider are emitted once Terraform stops serving it, so the ones of short runs are not lost.
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	if err := provider.Close(ctx, p); err != nil {
+		log.Printf("[WARN] Unable to emit metrics: %s", err.Error())
+	}
+	cancel()
 
 	if err != nil {
 		log.Fatal(err.Error())